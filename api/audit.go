@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func (srv *server) ListAuditEvents(ctx context.Context, req *proto.ListAuditEventsRequest) (*proto.AuditEventsListResponse, error) {
+	_, err := srv.authenticateRequest(ctx, manager.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := manager.AuditFilter{
+		Actor: req.Actor,
+		Limit: int(req.Limit),
+	}
+	if req.Since > 0 {
+		filter.Since = time.Unix(req.Since, 0)
+	}
+
+	rawEvents, err := srv.AuditRepo.List(filter)
+	if err != nil {
+		return nil, repositoryError(err, "unable to fetch audit events")
+	}
+
+	events := []*proto.AuditEvent{}
+	for _, e := range rawEvents {
+		event := transformToProtoAuditEvent(e)
+		events = append(events, &event)
+	}
+
+	return &proto.AuditEventsListResponse{Events: events}, nil
+}
+
+// recordAuditEvent appends an event to the audit trail.
+// The result of the action is deduced from err.
+// A failure to record the event is only logged, to avoid blocking the action.
+func (srv *server) recordAuditEvent(ctx context.Context, actor string, action manager.AuditAction, target string, err error) {
+	if srv.AuditRepo == nil {
+		return
+	}
+
+	event := manager.AuditEvent{
+		Date:    time.Now(),
+		Actor:   actor,
+		Action:  action,
+		Target:  target,
+		IP:      peerIP(ctx),
+		Success: err == nil,
+	}
+	if err != nil {
+		event.Message = status.Convert(err).Message()
+	}
+
+	recordErr := srv.AuditRepo.Append(event)
+	if recordErr != nil {
+		log.Error().Err(recordErr).Str("action", string(action)).Str("actor", actor).Msg("unable to record audit event")
+	}
+}
+
+// peerIP returns the IP address of the client, or an empty string if unknown
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
+}
+
+func transformToProtoAuditEvent(event manager.AuditEvent) proto.AuditEvent {
+	return proto.AuditEvent{
+		Id:      event.ID,
+		Date:    event.Date.Unix(),
+		Actor:   event.Actor,
+		Action:  string(event.Action),
+		Target:  event.Target,
+		Ip:      event.IP,
+		Success: event.Success,
+		Message: event.Message,
+	}
+}
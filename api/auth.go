@@ -2,9 +2,11 @@ package api
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
 	"strings"
 
+	"github.com/agence-webup/backr/manager"
 	"github.com/dgrijalva/jwt-go"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc/codes"
@@ -12,35 +14,65 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-func (srv *server) authenticateRequest(ctx context.Context) error {
+// identity represents the authenticated user of a request
+type identity struct {
+	Username string
+	Role     manager.Role
+	// Projects restricts the identity to these projects (all the projects if empty)
+	Projects []string
+}
 
-	// fetch all user accounts
-	accounts, err := srv.AccountRepo.List()
+// hasRole returns true if the identity has one of the roles
+func (id identity) hasRole(roles ...manager.Role) bool {
+	for _, r := range roles {
+		if id.Role == r {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsProject returns true if the identity is allowed to access the project
+func (id identity) allowsProject(name string) bool {
+	return manager.Account{Projects: id.Projects}.AllowsProject(name)
+}
+
+// authenticateRequest checks the token of the request, and ensures
+// that the user has one of the allowed roles.
+// The token is either a JWT token signed by the manager (see AuthenticateAccount),
+// or an ID token issued by the configured OpenID Connect provider.
+func (srv *server) authenticateRequest(ctx context.Context, allowedRoles ...manager.Role) (identity, error) {
+
+	// extract the token
+	token, err := extractBearerToken(ctx)
 	if err != nil {
-		log.Info().Err(err).Msg("unable to check for accounts count")
-	} else if len(accounts) == 0 {
-		// if no account is available, consider authentication is successful
-		log.Warn().Msg("API is not secured: an account must be created")
-		return nil
+		return identity{}, err
 	}
 
-	// extract Authorization header
-	auth, err := extractHeader(ctx, "authorization")
+	var id identity
+	if srv.oidc != nil && isSignedWithKeyPair(token) {
+		id, err = srv.oidc.authenticate(token)
+	} else {
+		id, err = srv.authenticateLocalToken(token)
+	}
 	if err != nil {
-		return status.Error(codes.Unauthenticated, `missing "Authorization" header`)
+		return identity{}, err
 	}
 
-	// check for Bearer prefix
-	const prefix = "Bearer "
-	if !strings.HasPrefix(auth, prefix) {
-		return status.Error(codes.Unauthenticated, `missing "Bearer " prefix in "Authorization" header`)
+	if !id.hasRole(allowedRoles...) {
+		return identity{}, status.Errorf(codes.PermissionDenied, "the role '%v' is not allowed to perform this action", id.Role)
 	}
+	srv.setMaintenanceHeader(ctx)
 
-	// extract the token
-	token := strings.TrimPrefix(auth, prefix)
+	return id, nil
+}
+
+// authenticateLocalToken checks a JWT token created by AuthenticateAccount
+func (srv *server) authenticateLocalToken(token string) (identity, error) {
 
 	// parse the JWT token
-	parsedToken, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
+	claims := jwt.StandardClaims{}
+	parsedToken, err := jwt.ParseWithClaims(token, &claims, func(token *jwt.Token) (interface{}, error) {
 		// validate the alg
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
@@ -49,15 +81,115 @@ func (srv *server) authenticateRequest(ctx context.Context) error {
 		return []byte(srv.Config.JWTSecret), nil
 	})
 	if err != nil {
-		return status.Errorf(codes.Unauthenticated, "unable to parse token: %v", err)
+		return identity{}, status.Errorf(codes.Unauthenticated, "unable to parse token: %v", err)
 	}
 
 	// check if the token is valid
-	if _, ok := parsedToken.Claims.(jwt.MapClaims); ok && parsedToken.Valid {
-		return nil
+	if !parsedToken.Valid || claims.Subject == "" {
+		return identity{}, status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	// the account may have been deleted since the token was created
+	account, err := srv.AccountRepo.Get(claims.Subject)
+	if err != nil {
+		return identity{}, repositoryError(err, "unable to fetch account")
+	}
+	if account == nil {
+		return identity{}, status.Error(codes.Unauthenticated, "invalid token: unknown account")
+	}
+
+	return identity{Username: account.Username, Role: account.GetRole(), Projects: account.Projects}, nil
+}
+
+// isSignedWithKeyPair returns true if the token is signed using an asymmetric algorithm (RSA or ECDSA),
+// as the ID tokens issued by OpenID Connect providers
+func isSignedWithKeyPair(token string) bool {
+	parsedToken, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+	if err != nil {
+		return false
+	}
+
+	switch parsedToken.Method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		return true
+	}
+	return false
+}
+
+// authenticateBootstrapRequest authenticates a request allowed to create the first account.
+// While no account exists, the request must provide the setup token generated at daemon startup.
+// As soon as an account exists, the regular authentication is required.
+// It returns the authenticated identity and whether the request uses the setup token.
+func (srv *server) authenticateBootstrapRequest(ctx context.Context) (identity, bool, error) {
+
+	// fetch all user accounts
+	accounts, err := srv.AccountRepo.List()
+	if err != nil {
+		// fail closed: without knowing if an account exists, the bootstrap cannot be allowed
+		log.Error().Err(err).Msg("unable to check for accounts count")
+		return identity{}, false, status.Error(codes.Unavailable, "unable to check for existing accounts")
+	}
+	if len(accounts) > 0 {
+		id, err := srv.authenticateRequest(ctx, manager.RoleAdmin)
+		return id, false, err
+	}
+
+	srv.setupTokenMutex.Lock()
+	setupToken := srv.setupToken
+	srv.setupTokenMutex.Unlock()
+
+	if setupToken == "" {
+		return identity{}, false, status.Error(codes.PermissionDenied, "no account exists and bootstrap is disabled: use `backr-manager account bootstrap` to create the first account")
+	}
+
+	token, err := extractBearerToken(ctx)
+	if err != nil {
+		return identity{}, false, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(token), []byte(setupToken)) != 1 {
+		log.Warn().Msg("bootstrap: invalid setup token")
+		return identity{}, false, status.Error(codes.Unauthenticated, "invalid setup token")
+	}
+
+	return identity{Username: setupTokenActor, Role: manager.RoleAdmin}, true, nil
+}
+
+// claimSetupToken disables the setup token, so it can be used only once.
+// It returns the claimed token, or an empty string if it has already been claimed.
+func (srv *server) claimSetupToken() string {
+	srv.setupTokenMutex.Lock()
+	defer srv.setupTokenMutex.Unlock()
+
+	token := srv.setupToken
+	srv.setupToken = ""
+	return token
+}
+
+// restoreSetupToken re-enables a claimed setup token (e.g. when the account creation failed)
+func (srv *server) restoreSetupToken(token string) {
+	srv.setupTokenMutex.Lock()
+	srv.setupToken = token
+	srv.setupTokenMutex.Unlock()
+}
+
+// setupTokenActor is the actor recorded in the audit trail when the setup token is used
+const setupTokenActor = "setup-token"
+
+func extractBearerToken(ctx context.Context) (string, error) {
+	// extract Authorization header
+	auth, err := extractHeader(ctx, "authorization")
+	if err != nil {
+		return "", status.Error(codes.Unauthenticated, `missing "Authorization" header`)
+	}
+
+	// check for Bearer prefix
+	const prefix = "Bearer "
+	if !strings.HasPrefix(auth, prefix) {
+		return "", status.Error(codes.Unauthenticated, `missing "Bearer " prefix in "Authorization" header`)
 	}
 
-	return status.Error(codes.Unauthenticated, "invalid token")
+	return strings.TrimPrefix(auth, prefix), nil
 }
 
 func extractHeader(ctx context.Context, header string) (string, error) {
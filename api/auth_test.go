@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// failingAccountRepository fails to list the accounts
+type failingAccountRepository struct {
+	manager.AccountRepository
+}
+
+func (repo failingAccountRepository) List() ([]manager.Account, error) {
+	return nil, manager.NewStorageError("list accounts", errors.New("disk failure"))
+}
+
+func contextWithToken(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func TestBootstrapWithSetupToken(t *testing.T) {
+	srv, cleanup := newTestServer(t)
+	defer cleanup()
+	srv.setupToken = "setup-token"
+
+	// a wrong token is rejected, without disabling the setup token
+	_, err := srv.CreateAccount(contextWithToken("wrong-token"), &proto.CreateAccountRequest{Username: "admin"})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected the wrong token to be rejected, got %v", err)
+	}
+	_, err = srv.CreateAccount(context.Background(), &proto.CreateAccountRequest{Username: "admin"})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected the request without token to be rejected, got %v", err)
+	}
+
+	// the setup token creates the first account, always an admin
+	resp, err := srv.CreateAccount(contextWithToken("setup-token"), &proto.CreateAccountRequest{Username: "admin", Role: string(manager.RoleReader)})
+	if err != nil {
+		t.Fatalf("expected the first account to be created, got %v", err)
+	}
+	if resp.Account.Role != string(manager.RoleAdmin) || resp.Password == "" {
+		t.Errorf("expected an admin account with a password, got %v", resp)
+	}
+
+	// once the first account exists, the setup token is not accepted anymore
+	_, err = srv.CreateAccount(contextWithToken("setup-token"), &proto.CreateAccountRequest{Username: "intruder"})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected the setup token to be rejected once an account exists, got %v", err)
+	}
+	if account, _ := srv.AccountRepo.Get("intruder"); account != nil {
+		t.Errorf("expected no account to be created with the setup token, got %v", account)
+	}
+}
+
+func TestBootstrapIsDisabledWithoutSetupToken(t *testing.T) {
+	srv, cleanup := newTestServer(t)
+	defer cleanup()
+
+	_, err := srv.CreateAccount(contextWithToken(""), &proto.CreateAccountRequest{Username: "admin"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected the bootstrap to be disabled, got %v", err)
+	}
+}
+
+func TestBootstrapFailsClosed(t *testing.T) {
+	srv, cleanup := newTestServer(t)
+	defer cleanup()
+	srv.setupToken = "setup-token"
+	accountRepo := srv.AccountRepo
+	srv.AccountRepo = failingAccountRepository{AccountRepository: accountRepo}
+
+	// without knowing if an account exists, the setup token is not accepted
+	_, err := srv.CreateAccount(contextWithToken("setup-token"), &proto.CreateAccountRequest{Username: "admin"})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("expected the bootstrap to fail closed, got %v", err)
+	}
+	if accounts, _ := accountRepo.List(); len(accounts) != 0 {
+		t.Errorf("expected no account to be created, got %v", accounts)
+	}
+
+	// the setup token is still usable once the accounts can be listed
+	srv.AccountRepo = accountRepo
+	_, err = srv.CreateAccount(contextWithToken("setup-token"), &proto.CreateAccountRequest{Username: "admin"})
+	if err != nil {
+		t.Errorf("expected the first account to be created, got %v", err)
+	}
+}
+
+func TestSetupTokenIsRejectedOnceAnAccountExists(t *testing.T) {
+	srv, cleanup := newTestServer(t)
+	defer cleanup()
+	srv.setupToken = "setup-token"
+
+	// e.g. created by `backr-manager account bootstrap`
+	_, err := srv.AccountRepo.Create("admin", manager.RoleAdmin, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = srv.CreateAccount(contextWithToken("setup-token"), &proto.CreateAccountRequest{Username: "intruder"})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected the setup token to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (srv *server) ApproveDeletionPlan(ctx context.Context, req *proto.ApproveDeletionPlanRequest) (_ *proto.ProjectResponse, err error) {
+	id, err := srv.authenticateRequest(ctx, manager.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		srv.recordAuditEvent(ctx, id.Username, manager.AuditActionDeletionPlanApprove, req.ProjectName+" "+req.PlanId, err)
+	}()
+
+	if req.ProjectName == "" || req.PlanId == "" {
+		return nil, status.Error(codes.InvalidArgument, "'project_name' and 'plan_id' are required")
+	}
+
+	err = checkProjectAccess(id, req.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	// the approval is saved atomically: it must not be overwritten by a running process,
+	// and it must be given to the plan which is stored right now
+	var project manager.Project
+	err = srv.ProjectRepo.Update(req.ProjectName, func(stored *manager.Project) error {
+		// the approval is bound to the files of the plan
+		if stored.DeletionPlan == nil || stored.DeletionPlan.ID != req.PlanId {
+			return status.Errorf(codes.FailedPrecondition, "the plan '%v' is not blocked, the files to remove may have changed", req.PlanId)
+		}
+		if stored.DeletionPlan.IsApproved() {
+			return status.Errorf(codes.FailedPrecondition, "the plan '%v' has already been approved by '%v'", req.PlanId, stored.DeletionPlan.ApprovedBy)
+		}
+
+		plan := *stored.DeletionPlan
+		plan.ApprovedBy = id.Username
+		plan.ApprovedAt = time.Now()
+		stored.DeletionPlan = &plan
+
+		project = *stored
+		return nil
+	})
+	if status.Code(err) == codes.FailedPrecondition {
+		return nil, err
+	}
+	if err != nil {
+		return nil, repositoryError(err, "unable to save project")
+	}
+	srv.publish(manager.Event{Type: manager.EventDeletionPlanApproved, ProjectName: project.Name, Message: "plan " + req.PlanId + " approved by " + id.Username})
+
+	p := transformToProtoProject(project)
+
+	return &proto.ProjectResponse{Project: &p}, nil
+}
+
+func transformToProtoDeletionPlan(plan *manager.DeletionPlan) *proto.DeletionPlan {
+	if plan == nil {
+		return nil
+	}
+
+	files := []*proto.File{}
+	for _, f := range plan.Files {
+		file := transformToProtoFile(f)
+		files = append(files, &file)
+	}
+
+	p := proto.DeletionPlan{
+		Id:         plan.ID,
+		Files:      files,
+		Reasons:    plan.Reasons,
+		CreatedAt:  plan.CreatedAt.Unix(),
+		ApprovedBy: plan.ApprovedBy,
+	}
+	if plan.IsApproved() {
+		p.ApprovedAt = plan.ApprovedAt.Unix()
+	}
+
+	return &p
+}
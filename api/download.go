@@ -0,0 +1,79 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// size of the data sent in each chunk of a download
+const downloadChunkSize = 64 * 1024
+
+func (srv *server) DownloadFile(req *proto.DownloadFileRequest, stream proto.BackrApi_DownloadFileServer) error {
+	id, err := srv.authenticateRequest(stream.Context(), manager.RoleAdmin, manager.RoleReader)
+	if err != nil {
+		return err
+	}
+
+	if req.Offset < 0 {
+		return status.Error(codes.InvalidArgument, "offset must be positive")
+	}
+
+	file, err := srv.resolveFile(id, req.Filepath)
+	if err != nil {
+		return err
+	}
+
+	reader, size, err := srv.FileRepo.Open(*file)
+	if err != nil {
+		return repositoryError(err, "unable to open file")
+	}
+	defer reader.Close()
+
+	if req.Offset > size {
+		return status.Errorf(codes.OutOfRange, "offset %d is beyond the size of the file (%d bytes)", req.Offset, size)
+	}
+
+	// the checksum covers the whole file, including the part skipped when resuming
+	hash := sha256.New()
+	if _, err := io.CopyN(hash, reader, req.Offset); err != nil {
+		return status.Errorf(codes.Internal, "unable to read file: %v", err)
+	}
+
+	offset := req.Offset
+	buffer := make([]byte, downloadChunkSize)
+	first := true
+	for {
+		n, readErr := io.ReadFull(reader, buffer)
+		if n > 0 || first {
+			hash.Write(buffer[:n])
+
+			chunk := &proto.FileChunk{Offset: offset, Data: buffer[:n]}
+			if first {
+				chunk.Size = size
+				first = false
+			}
+			if err := stream.Send(chunk); err != nil {
+				return err
+			}
+			offset += int64(n)
+		}
+
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			break
+		}
+		if readErr != nil {
+			return status.Errorf(codes.Internal, "unable to read file: %v", readErr)
+		}
+	}
+
+	return stream.Send(&proto.FileChunk{
+		Offset: offset,
+		Sha256: hex.EncodeToString(hash.Sum(nil)),
+	})
+}
@@ -0,0 +1,118 @@
+// Package download serves the one-time URLs issued by the API:
+// the file is proxied by the daemon, and the URL is invalidated by the first download.
+package download
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/rs/zerolog/log"
+)
+
+// PathPrefix is the path of the one-time URLs, followed by the token
+const PathPrefix = "/download/"
+
+// NewHandler returns the HTTP handler serving the one-time URLs, to mount on PathPrefix
+func NewHandler(tokenRepo manager.DownloadTokenRepository, fileRepo manager.FileRepository, auditRepo manager.AuditRepository) http.Handler {
+	return &handler{
+		tokenRepo: tokenRepo,
+		fileRepo:  fileRepo,
+		auditRepo: auditRepo,
+	}
+}
+
+type handler struct {
+	tokenRepo manager.DownloadTokenRepository
+	fileRepo  manager.FileRepository
+	auditRepo manager.AuditRepository
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// only GET requests use the token: a HEAD request must not invalidate it
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	token := strings.TrimPrefix(r.URL.Path, PathPrefix)
+	if token == "" || strings.Contains(token, "/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	info, err := h.tokenRepo.Claim(token)
+	if err != nil {
+		log.Error().Err(err).Msg("download: unable to claim token")
+		http.Error(w, "unable to check the link", http.StatusInternalServerError)
+		return
+	}
+	if info == nil || info.ExpiresAt.Before(time.Now()) {
+		if info != nil {
+			h.recordAuditEvent(r, *info, fmt.Errorf("the link has expired"))
+		}
+		http.Error(w, "this link is invalid, expired or has already been used", http.StatusNotFound)
+		return
+	}
+
+	reader, size, err := h.fileRepo.Open(manager.File{Path: info.FilePath})
+	if err != nil {
+		log.Error().Err(err).Str("path", info.FilePath).Msg("download: unable to open file")
+		h.recordAuditEvent(r, *info, err)
+		http.Error(w, "unable to open the file", http.StatusInternalServerError)
+		return
+	}
+	defer reader.Close()
+
+	h.recordAuditEvent(r, *info, nil)
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+	w.Header().Set("Cache-Control", "no-store")
+	if info.Filename != "" {
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%v\"", info.Filename))
+	}
+
+	_, err = io.Copy(w, reader)
+	if err != nil {
+		log.Warn().Err(err).Str("path", info.FilePath).Msg("download: transfer interrupted")
+	}
+}
+
+// recordAuditEvent records the use of a one-time URL, on behalf of the user who issued it
+func (h *handler) recordAuditEvent(r *http.Request, info manager.DownloadToken, err error) {
+	if h.auditRepo == nil {
+		return
+	}
+
+	event := manager.AuditEvent{
+		Date:    time.Now(),
+		Actor:   info.CreatedBy,
+		Action:  manager.AuditActionFileOneTimeDownload,
+		Target:  info.FilePath,
+		IP:      remoteIP(r),
+		Success: err == nil,
+	}
+	if err != nil {
+		event.Message = err.Error()
+	}
+
+	recordErr := h.auditRepo.Append(event)
+	if recordErr != nil {
+		log.Error().Err(recordErr).Str("path", info.FilePath).Msg("download: unable to record audit event")
+	}
+}
+
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
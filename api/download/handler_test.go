@@ -0,0 +1,74 @@
+package download
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/repositories/bolt"
+	"github.com/agence-webup/backr/manager/repositories/inmem"
+	bbolt "go.etcd.io/bbolt"
+)
+
+func TestOneTimeDownload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backr-download")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := bbolt.Open(filepath.Join(dir, "test.db"), 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	tokenRepo := bolt.NewDownloadTokenRepository(db)
+	auditRepo := bolt.NewAuditRepository(db)
+	fileRepo := inmem.NewFileRepository()
+	inmem.CreateFakeFileWithContent(fileRepo, manager.File{Path: "project1/file1.tar.gz"}, []byte("backup"))
+
+	now := time.Now()
+	tokenRepo.Create("valid", manager.DownloadToken{FilePath: "project1/file1.tar.gz", Filename: "backup.tar.gz", CreatedBy: "john", ExpiresAt: now.Add(time.Hour)})
+	tokenRepo.Create("expired", manager.DownloadToken{FilePath: "project1/file1.tar.gz", CreatedBy: "john", ExpiresAt: now.Add(-time.Hour)})
+
+	handler := NewHandler(tokenRepo, fileRepo, auditRepo)
+	get := func(method, token string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(method, PathPrefix+token, nil))
+		return w
+	}
+
+	// a HEAD request must not invalidate the token
+	if w := get("HEAD", "valid"); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("HEAD: expected status %v, got %v", http.StatusMethodNotAllowed, w.Code)
+	}
+
+	w := get("GET", "valid")
+	if w.Code != http.StatusOK || w.Body.String() != "backup" {
+		t.Fatalf("expected the file to be downloaded, got %v: %v", w.Code, w.Body.String())
+	}
+	if w.Header().Get("Content-Disposition") != `attachment; filename="backup.tar.gz"` {
+		t.Errorf("unexpected Content-Disposition: %v", w.Header().Get("Content-Disposition"))
+	}
+
+	for _, token := range []string{"valid", "expired", "unknown"} {
+		if w := get("GET", token); w.Code != http.StatusNotFound {
+			t.Errorf("%v: expected status %v, got %v", token, http.StatusNotFound, w.Code)
+		}
+	}
+
+	events, err := auditRepo.List(manager.AuditFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the download, then the use of the expired link, are recorded on behalf of the issuer
+	if len(events) != 2 || events[0].Success || !events[1].Success || events[1].Actor != "john" || events[1].Action != manager.AuditActionFileOneTimeDownload {
+		t.Errorf("unexpected audit trail: %+v", events)
+	}
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/proto"
+	"github.com/agence-webup/backr/manager/repositories/inmem"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeDownloadStream struct {
+	grpc.ServerStream
+
+	ctx    context.Context
+	chunks []*proto.FileChunk
+}
+
+func (s *fakeDownloadStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeDownloadStream) Send(chunk *proto.FileChunk) error {
+	// the buffer of the data is reused by the server
+	copied := *chunk
+	copied.Data = append([]byte{}, chunk.Data...)
+	s.chunks = append(s.chunks, &copied)
+	return nil
+}
+
+func TestDownloadFile(t *testing.T) {
+	srv, cleanup := newTestServer(t)
+	defer cleanup()
+
+	ctx := contextForAccount(t, srv, "reader", manager.RoleReader, []string{"project1"})
+
+	// the content spans several chunks
+	content := bytes.Repeat([]byte("some backup data "), 10000)
+	hash := sha256.Sum256(content)
+	checksum := hex.EncodeToString(hash[:])
+	inmem.CreateFakeFileWithContent(srv.FileRepo, manager.File{Path: "project1/file.tar.gz", Size: int64(len(content))}, content)
+
+	download := func(offset int64) ([]byte, *fakeDownloadStream, error) {
+		stream := &fakeDownloadStream{ctx: ctx}
+		err := srv.DownloadFile(&proto.DownloadFileRequest{Filepath: "project1/file.tar.gz", Offset: offset}, stream)
+
+		data := []byte{}
+		for _, chunk := range stream.chunks {
+			data = append(data, chunk.Data...)
+		}
+		return data, stream, err
+	}
+
+	tests := []struct {
+		name   string
+		offset int64
+	}{
+		{"whole file", 0},
+		{"resumed download", 100000},
+		{"offset at the end", int64(len(content))},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, stream, err := download(test.offset)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(data, content[test.offset:]) {
+				t.Errorf("expected the content from offset %d (%d bytes), got %d bytes", test.offset, len(content)-int(test.offset), len(data))
+			}
+
+			first, last := stream.chunks[0], stream.chunks[len(stream.chunks)-1]
+			if first.Offset != test.offset || first.Size != int64(len(content)) {
+				t.Errorf("expected the first chunk to start at %d with the size of the file, got %d (%d bytes)", test.offset, first.Offset, first.Size)
+			}
+			// the checksum covers the whole file, including the skipped part
+			if last.Sha256 != checksum || last.Offset != int64(len(content)) {
+				t.Errorf("expected the checksum %v at the end of the file, got %v at %d", checksum, last.Sha256, last.Offset)
+			}
+		})
+	}
+
+	_, _, err := download(int64(len(content)) + 1)
+	if status.Code(err) != codes.OutOfRange {
+		t.Errorf("expected an offset past the end of the file to be rejected, got %v", err)
+	}
+
+	_, _, err = download(-1)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected a negative offset to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// repositoryError converts an error returned by a repository into a gRPC status,
+// according to its kind: a missing record is NotFound, a corrupted record is DataLoss,
+// and a storage failure is Unavailable (the request may be retried later).
+func repositoryError(err error, msg string) error {
+	code := codes.Internal
+	switch {
+	case errors.Is(err, manager.ErrNotFound):
+		code = codes.NotFound
+	case errors.Is(err, manager.ErrCorruptedRecord):
+		code = codes.DataLoss
+	case errors.Is(err, manager.ErrStorage):
+		code = codes.Unavailable
+	}
+
+	if code != codes.NotFound {
+		log.Error().Err(err).Msg(msg)
+	}
+
+	return status.Errorf(code, "%v: %v", msg, err)
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/events"
+	"github.com/agence-webup/backr/manager/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (srv *server) WatchEvents(req *proto.WatchEventsRequest, stream proto.BackrApi_WatchEventsServer) error {
+	ctx := stream.Context()
+
+	id, err := srv.authenticateRequest(ctx, manager.RoleAdmin, manager.RoleReader)
+	if err != nil {
+		return err
+	}
+
+	if srv.Events == nil {
+		return status.Error(codes.Unavailable, "the event stream is not enabled")
+	}
+
+	// when resuming, replay every missed event still in the backlog
+	replay := int(req.Replay)
+	if req.SinceId > 0 {
+		replay = events.DefaultBacklogSize
+	}
+
+	backlog, sub := srv.Events.Subscribe(req.SinceId, replay)
+	defer sub.Close()
+
+	filter := newEventFilter(id, req)
+
+	for _, e := range backlog {
+		if !filter.match(e) {
+			continue
+		}
+		err := stream.Send(transformToProtoEvent(e))
+		if err != nil {
+			return err
+		}
+	}
+
+	if !req.Follow {
+		return nil
+	}
+
+	for {
+		select {
+		case e, ok := <-sub.Events:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "the client is too slow to receive the events, resume using 'since_id'")
+			}
+			if !filter.match(e) {
+				continue
+			}
+			err := stream.Send(transformToProtoEvent(e))
+			if err != nil {
+				return err
+			}
+
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// publish publishes the event, if the event bus is enabled
+func (srv *server) publish(event manager.Event) {
+	if srv.Events != nil {
+		srv.Events.Publish(event)
+	}
+}
+
+type eventFilter struct {
+	id          identity
+	projectName string
+	types       map[manager.EventType]bool
+}
+
+func newEventFilter(id identity, req *proto.WatchEventsRequest) eventFilter {
+	filter := eventFilter{id: id, projectName: req.ProjectName}
+	if len(req.Types) > 0 {
+		filter.types = map[manager.EventType]bool{}
+		for _, t := range req.Types {
+			filter.types[manager.EventType(t)] = true
+		}
+	}
+	return filter
+}
+
+func (f eventFilter) match(e manager.Event) bool {
+	if f.projectName != "" && e.ProjectName != f.projectName {
+		return false
+	}
+	if !f.id.allowsProject(e.ProjectName) {
+		return false
+	}
+	if f.types != nil && !f.types[e.Type] {
+		return false
+	}
+	return true
+}
+
+func transformToProtoEvent(e manager.Event) *proto.Event {
+	return &proto.Event{
+		Id:          e.ID,
+		Type:        string(e.Type),
+		Date:        e.Date.Unix(),
+		ProjectName: e.ProjectName,
+		RuleId:      string(e.RuleID),
+		FilePath:    e.FilePath,
+		Message:     e.Message,
+	}
+}
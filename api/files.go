@@ -0,0 +1,52 @@
+package api
+
+import (
+	"github.com/agence-webup/backr/manager"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkProjectAccess ensures that the identity is allowed to access the project
+func checkProjectAccess(id identity, projectName string) error {
+	if !id.allowsProject(projectName) {
+		return status.Errorf(codes.PermissionDenied, "the account is not allowed to access the project '%v'", projectName)
+	}
+	return nil
+}
+
+// resolveFile resolves the path to a configured project, checks that the identity is allowed
+// to access this project, and returns the stored file
+func (srv *server) resolveFile(id identity, path string) (*manager.File, error) {
+	if path == "" {
+		return nil, status.Error(codes.InvalidArgument, "'filepath' is required")
+	}
+
+	folder, err := srv.FileRepo.GetFolderForFile(manager.File{Path: path})
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid filepath: %v", err)
+	}
+
+	// the permission is checked first, so the existence of the file is not leaked
+	err = checkProjectAccess(id, folder)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := srv.ProjectRepo.GetByName(folder)
+	if err != nil {
+		return nil, repositoryError(err, "unable to fetch project")
+	}
+	if project == nil {
+		return nil, status.Errorf(codes.NotFound, "no project is configured for the folder '%v'", folder)
+	}
+
+	file, err := srv.FileRepo.Stat(manager.File{Path: path})
+	if err != nil {
+		return nil, repositoryError(err, "unable to fetch file")
+	}
+	if file == nil {
+		return nil, status.Error(codes.NotFound, "file not found")
+	}
+
+	return file, nil
+}
@@ -0,0 +1,116 @@
+// Package health serves the health of the daemon, for load balancers and monitoring tools:
+// the result of the last run of the process is reported.
+// The health check is not authenticated: only the status and the dates are served,
+// the errors (naming the projects, the bucket and the paths) are logged by the process.
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/agence-webup/backr/manager/process"
+)
+
+// Path is the path of the health check
+const Path = "/healthz"
+
+const (
+	// statusStarting is reported until the first tick
+	statusStarting = "starting"
+	// statusOK is reported when the last tick succeeded
+	statusOK = "ok"
+	// statusDegraded is reported when some projects can't be processed, the other ones being processed
+	statusDegraded = "degraded"
+	// statusFailing is reported when the last tick failed, e.g. the storage is unreachable
+	statusFailing = "failing"
+	// statusStalled is reported when no tick has been run for maxAge
+	statusStalled = "stalled"
+	// statusMaintenance is reported when the last tick has been skipped because of the maintenance mode
+	statusMaintenance = "maintenance"
+)
+
+// NewHandler returns the HTTP handler serving the health, to mount on Path.
+// The daemon is considered stalled if the last tick is older than maxAge.
+func NewHandler(health *process.Health, maxAge time.Duration) http.Handler {
+	return &handler{
+		health:    health,
+		maxAge:    maxAge,
+		startedAt: time.Now(),
+	}
+}
+
+type handler struct {
+	health    *process.Health
+	maxAge    time.Duration
+	startedAt time.Time
+}
+
+type response struct {
+	Status           string     `json:"status"`
+	LastTick         *time.Time `json:"last_tick,omitempty"`
+	LastTickDuration string     `json:"last_tick_duration,omitempty"`
+	LastSuccess      *time.Time `json:"last_success,omitempty"`
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	resp, code := h.status(time.Now())
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// status returns the health at the specified date, and the matching HTTP status code
+func (h *handler) status(now time.Time) (response, int) {
+	last, lastSuccess := h.health.Last()
+
+	resp := response{Status: statusOK}
+	if !lastSuccess.IsZero() {
+		resp.LastSuccess = &lastSuccess
+	}
+
+	if last == nil {
+		if h.maxAge > 0 && now.Sub(h.startedAt) > h.maxAge {
+			resp.Status = statusStalled
+			return resp, http.StatusServiceUnavailable
+		}
+		resp.Status = statusStarting
+		return resp, http.StatusOK
+	}
+
+	resp.LastTick = &last.Date
+	resp.LastTickDuration = last.Duration.String()
+
+	if h.maxAge > 0 && now.Sub(last.Date) > h.maxAge {
+		resp.Status = statusStalled
+		return resp, http.StatusServiceUnavailable
+	}
+
+	if last.Maintenance != nil {
+		resp.Status = statusMaintenance
+		return resp, http.StatusOK
+	}
+
+	if last.Err != nil {
+		// a failing project does not prevent the other ones to be processed
+		var projectErrors process.ProjectErrors
+		if errors.As(last.Err, &projectErrors) {
+			resp.Status = statusDegraded
+			return resp, http.StatusOK
+		}
+
+		resp.Status = statusFailing
+		return resp, http.StatusServiceUnavailable
+	}
+
+	return resp, http.StatusOK
+}
@@ -0,0 +1,66 @@
+package health
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/process"
+)
+
+func TestStatus(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name           string
+		result         *process.TickResult
+		expectedStatus string
+		expectedCode   int
+	}{
+		{"no tick yet", nil, statusStarting, http.StatusOK},
+		{"successful tick", &process.TickResult{Date: now}, statusOK, http.StatusOK},
+		{"failing project", &process.TickResult{Date: now, Err: process.ProjectErrors{"p": errors.New("failure")}}, statusDegraded, http.StatusOK},
+		{"unreachable storage", &process.TickResult{Date: now, Err: process.ErrStorageUnreachable}, statusFailing, http.StatusServiceUnavailable},
+		{"old tick", &process.TickResult{Date: now.Add(-time.Hour)}, statusStalled, http.StatusServiceUnavailable},
+		{"maintenance", &process.TickResult{Date: now, Maintenance: &manager.Maintenance{Reason: "migration"}}, statusMaintenance, http.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			health := &process.Health{}
+			if test.result != nil {
+				health.Record(*test.result)
+			}
+			h := NewHandler(health, 5*time.Minute).(*handler)
+
+			resp, code := h.status(now)
+			if resp.Status != test.expectedStatus || code != test.expectedCode {
+				t.Errorf("expected %v (%d), got %v (%d)", test.expectedStatus, test.expectedCode, resp.Status, code)
+			}
+		})
+	}
+}
+
+func TestErrorsAreNotServed(t *testing.T) {
+	health := &process.Health{}
+	health.Record(process.TickResult{
+		Date:           time.Now(),
+		Err:            process.ProjectErrors{"secret-project": errors.New("unable to list bucket 'secret-bucket'")},
+		FailedProjects: []string{"secret-project"},
+	})
+
+	recorder := httptest.NewRecorder()
+	NewHandler(health, 5*time.Minute).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, Path, nil))
+
+	body := recorder.Body.String()
+	if !strings.Contains(body, statusDegraded) {
+		t.Errorf("expected the status to be served, got %v", body)
+	}
+	if strings.Contains(body, "secret") {
+		t.Errorf("expected the errors not to be served, got %v", body)
+	}
+}
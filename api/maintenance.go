@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// MaintenanceHeader is the response header containing the reason of the maintenance mode, set while it is active
+	MaintenanceHeader = "backr-maintenance-bin"
+	// MaintenanceUntilHeader is the response header containing the end of the maintenance mode (unix timestamp, 0 if none)
+	MaintenanceUntilHeader = "backr-maintenance-until"
+)
+
+func (srv *server) GetMaintenance(ctx context.Context, req *proto.GetMaintenanceRequest) (*proto.MaintenanceResponse, error) {
+	_, err := srv.authenticateRequest(ctx, manager.RoleAdmin, manager.RoleReader)
+	if err != nil {
+		return nil, err
+	}
+
+	maintenance, err := srv.MaintenanceRepo.Get()
+	if err != nil {
+		return nil, repositoryError(err, "unable to fetch the maintenance mode")
+	}
+	if !maintenance.IsActive(time.Now()) {
+		return &proto.MaintenanceResponse{}, nil
+	}
+
+	return &proto.MaintenanceResponse{Maintenance: transformToProtoMaintenance(*maintenance)}, nil
+}
+
+func (srv *server) SetMaintenance(ctx context.Context, req *proto.SetMaintenanceRequest) (_ *proto.MaintenanceResponse, err error) {
+	id, err := srv.authenticateRequest(ctx, manager.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	target := "off"
+	if req.Enabled {
+		target = "on"
+	}
+	defer func() { srv.recordAuditEvent(ctx, id.Username, manager.AuditActionMaintenanceSet, target, err) }()
+
+	if !req.Enabled {
+		err = srv.MaintenanceRepo.Set(nil)
+		if err != nil {
+			return nil, repositoryError(err, "unable to disable the maintenance mode")
+		}
+		srv.publish(manager.Event{Type: manager.EventMaintenanceChanged, Message: "maintenance mode disabled by " + id.Username})
+
+		return &proto.MaintenanceResponse{}, nil
+	}
+
+	if req.Reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "'reason' is required to enable the maintenance mode")
+	}
+
+	now := time.Now()
+	maintenance := manager.Maintenance{
+		Reason:    req.Reason,
+		Author:    id.Username,
+		StartedAt: now,
+	}
+	if req.Until != 0 {
+		maintenance.Until = time.Unix(req.Until, 0)
+		if !maintenance.Until.After(now) {
+			return nil, status.Error(codes.InvalidArgument, "'until' must be in the future")
+		}
+	}
+
+	err = srv.MaintenanceRepo.Set(&maintenance)
+	if err != nil {
+		return nil, repositoryError(err, "unable to enable the maintenance mode")
+	}
+	srv.publish(manager.Event{Type: manager.EventMaintenanceChanged, Message: "maintenance mode enabled by " + id.Username + ": " + maintenance.Reason})
+
+	return &proto.MaintenanceResponse{Maintenance: transformToProtoMaintenance(maintenance)}, nil
+}
+
+// setMaintenanceHeader adds the maintenance mode to the headers of the response,
+// so the clients can display it while it is active.
+// It must be called once the request is authenticated: the reason is not disclosed to anonymous callers.
+func (srv *server) setMaintenanceHeader(ctx context.Context) {
+	if srv.MaintenanceRepo == nil {
+		return
+	}
+
+	maintenance, err := srv.MaintenanceRepo.Get()
+	if err != nil {
+		log.Error().Err(err).Msg("unable to fetch the maintenance mode")
+	}
+	if maintenance.IsActive(time.Now()) {
+		until := int64(0)
+		if !maintenance.Until.IsZero() {
+			until = maintenance.Until.Unix()
+		}
+		grpc.SetHeader(ctx, metadata.Pairs(MaintenanceHeader, maintenance.Reason, MaintenanceUntilHeader, strconv.FormatInt(until, 10)))
+	}
+}
+
+func transformToProtoMaintenance(maintenance manager.Maintenance) *proto.Maintenance {
+	m := proto.Maintenance{
+		Reason:    maintenance.Reason,
+		Author:    maintenance.Author,
+		StartedAt: maintenance.StartedAt.Unix(),
+	}
+	if !maintenance.Until.IsZero() {
+		m.Until = maintenance.Until.Unix()
+	}
+	return &m
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// fakeTransportStream records the headers of the response
+type fakeTransportStream struct {
+	header metadata.MD
+}
+
+func (s *fakeTransportStream) Method() string { return "" }
+
+func (s *fakeTransportStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *fakeTransportStream) SendHeader(md metadata.MD) error { return s.SetHeader(md) }
+
+func (s *fakeTransportStream) SetTrailer(md metadata.MD) error { return nil }
+
+func TestMaintenanceHeaderRequiresAuthentication(t *testing.T) {
+	srv, cleanup := newTestServer(t)
+	defer cleanup()
+
+	err := srv.MaintenanceRepo.Set(&manager.Maintenance{Reason: "migration to the new bucket"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected bool
+	}{
+		{"anonymous", context.Background(), false},
+		{"invalid token", contextWithToken("invalid"), false},
+		{"authenticated", contextForAccount(t, srv, "reader", manager.RoleReader, nil), true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stream := &fakeTransportStream{}
+			ctx := grpc.NewContextWithServerTransportStream(test.ctx, stream)
+
+			srv.GetProjects(ctx, &proto.GetProjectsRequest{})
+
+			reasons := stream.header.Get(MaintenanceHeader)
+			if test.expected && (len(reasons) != 1 || reasons[0] != "migration to the new bucket") {
+				t.Errorf("expected the reason of the maintenance, got %v", reasons)
+			}
+			if !test.expected && len(stream.header) != 0 {
+				t.Errorf("expected no maintenance header, got %v", stream.header)
+			}
+		})
+	}
+}
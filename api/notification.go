@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/proto"
+)
+
+func (srv *server) ListNotifications(ctx context.Context, req *proto.ListNotificationsRequest) (*proto.NotificationsListResponse, error) {
+	id, err := srv.authenticateRequest(ctx, manager.RoleAdmin, manager.RoleReader)
+	if err != nil {
+		return nil, err
+	}
+
+	if srv.NotificationRepo == nil {
+		return &proto.NotificationsListResponse{Notifications: []*proto.Notification{}}, nil
+	}
+
+	// the records of the other projects are filtered out before applying the limit
+	limit := int(req.Limit)
+	if len(id.Projects) > 0 {
+		limit = 0
+	}
+
+	records, err := srv.NotificationRepo.List(limit)
+	if err != nil {
+		return nil, repositoryError(err, "unable to fetch notifications")
+	}
+
+	notifications := []*proto.Notification{}
+	for _, r := range records {
+		if !id.allowsProject(r.ProjectName) {
+			continue
+		}
+		if req.Limit > 0 && len(notifications) >= int(req.Limit) {
+			break
+		}
+		n := transformToProtoNotification(r)
+		notifications = append(notifications, &n)
+	}
+
+	return &proto.NotificationsListResponse{Notifications: notifications}, nil
+}
+
+func transformToProtoNotification(record manager.NotificationRecord) proto.Notification {
+	return proto.Notification{
+		Id:          record.ID,
+		ProjectName: record.ProjectName,
+		Level:       record.Level.String(),
+		Count:       int32(record.Count),
+		Reasons:     record.Reasons,
+		SentAt:      record.SentAt.Unix(),
+	}
+}
@@ -0,0 +1,385 @@
+package api
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// delay before fetching the keys again, when a token is signed with an unknown key
+const jwksMinRefreshInterval = 1 * time.Minute
+
+// oidcVerifier validates ID tokens issued by an OpenID Connect provider
+// and maps them to an identity
+type oidcVerifier struct {
+	config      manager.OIDCConfig
+	accountRepo manager.AccountRepository
+
+	keys      map[string]interface{}
+	fetchedAt time.Time
+	mutex     sync.Mutex
+}
+
+func newOIDCVerifier(config manager.OIDCConfig, accountRepo manager.AccountRepository) *oidcVerifier {
+	if config.Issuer == "" {
+		return nil
+	}
+
+	return &oidcVerifier{
+		config:      config,
+		accountRepo: accountRepo,
+	}
+}
+
+// authenticate validates the ID token and returns the associated identity
+func (v *oidcVerifier) authenticate(token string) (identity, error) {
+	claims := jwt.MapClaims{}
+	parsedToken, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		// validate the alg
+		switch token.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		default:
+			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+		}
+
+		kid, _ := token.Header["kid"].(string)
+		return v.getKey(kid)
+	})
+	if err != nil {
+		return identity{}, status.Errorf(codes.Unauthenticated, "unable to parse ID token: %v", err)
+	}
+	if !parsedToken.Valid {
+		return identity{}, status.Error(codes.Unauthenticated, "invalid ID token")
+	}
+
+	if !claims.VerifyIssuer(v.config.Issuer, true) {
+		return identity{}, status.Error(codes.Unauthenticated, "invalid ID token: unexpected issuer")
+	}
+	if !verifyAudience(claims, v.audience()) {
+		return identity{}, status.Error(codes.Unauthenticated, "invalid ID token: unexpected audience")
+	}
+
+	username, err := v.username(claims)
+	if err != nil {
+		return identity{}, err
+	}
+	if username == "" {
+		return identity{}, status.Error(codes.Unauthenticated, "invalid ID token: no username claim")
+	}
+
+	role, projects, err := v.role(username, claims)
+	if err != nil {
+		return identity{}, err
+	}
+
+	return identity{Username: username, Role: role, Projects: projects}, nil
+}
+
+func (v *oidcVerifier) audience() string {
+	if v.config.Audience != "" {
+		return v.config.Audience
+	}
+	return v.config.ClientID
+}
+
+// username returns the username according to the configured claim: the subject, unique at the provider,
+// or the email once verified by the provider. The other claims can be chosen by the users.
+func (v *oidcVerifier) username(claims jwt.MapClaims) (string, error) {
+	switch v.config.UsernameClaim {
+	case "", "sub":
+		sub, _ := claims["sub"].(string)
+		return sub, nil
+
+	case "email":
+		email, _ := claims["email"].(string)
+		if email == "" {
+			return "", nil
+		}
+		verified := false
+		switch value := claims["email_verified"].(type) {
+		case bool:
+			verified = value
+		case string:
+			verified = value == "true"
+		}
+		if !verified {
+			return "", status.Error(codes.Unauthenticated, "invalid ID token: the email is not verified")
+		}
+		return email, nil
+	}
+
+	return "", status.Errorf(codes.Unauthenticated, "unsupported username claim '%v': only 'sub' and 'email' are accepted", v.config.UsernameClaim)
+}
+
+// role maps the roles claim to a Role. When several values are mapped, the most privileged role is kept.
+// If no value is mapped, the role (and the projects) of a local account with the same username is used,
+// only if LinkLocalAccounts is enabled.
+func (v *oidcVerifier) role(username string, claims jwt.MapClaims) (manager.Role, []string, error) {
+	rolesClaim := v.config.RolesClaim
+	if rolesClaim == "" {
+		rolesClaim = "groups"
+	}
+
+	values := []string{}
+	switch c := claims[rolesClaim].(type) {
+	case string:
+		values = strings.Fields(c)
+	case []interface{}:
+		for _, value := range c {
+			if str, ok := value.(string); ok {
+				values = append(values, str)
+			}
+		}
+	}
+
+	var role manager.Role
+	for _, value := range values {
+		mapped := manager.Role(v.config.RoleMapping[strings.ToLower(value)])
+		if !mapped.IsValid() {
+			continue
+		}
+		if role == "" || mapped == manager.RoleAdmin || (mapped == manager.RoleReader && role == manager.RoleUploader) {
+			role = mapped
+		}
+	}
+	if role != "" {
+		return role, nil, nil
+	}
+
+	if v.config.LinkLocalAccounts {
+		account, err := v.accountRepo.Get(username)
+		if err == nil && account != nil {
+			return account.GetRole(), account.Projects, nil
+		}
+	}
+
+	log.Warn().Str("username", username).Strs("roles", values).Msg("oidc: no role mapped for user")
+	return "", nil, status.Error(codes.PermissionDenied, "no role is granted to this user")
+}
+
+// getKey returns the public key identified by kid, fetching the keys if needed
+func (v *oidcVerifier) getKey(kid string) (interface{}, error) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	if key, ok := v.findKey(kid); ok {
+		return key, nil
+	}
+
+	// the provider may have rotated its keys
+	if time.Since(v.fetchedAt) < jwksMinRefreshInterval {
+		return nil, fmt.Errorf("unknown key '%v'", kid)
+	}
+
+	keys, err := v.fetchKeys()
+	v.fetchedAt = time.Now()
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch keys: %w", err)
+	}
+	v.keys = keys
+
+	if key, ok := v.findKey(kid); ok {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("unknown key '%v'", kid)
+}
+
+func (v *oidcVerifier) findKey(kid string) (interface{}, bool) {
+	// when the token does not specify a key, only a single key can be used
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, true
+		}
+	}
+
+	key, ok := v.keys[kid]
+	return key, ok
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// fetchKeys loads the key set from the configured file or URL
+func (v *oidcVerifier) fetchKeys() (map[string]interface{}, error) {
+	var data []byte
+	var err error
+
+	if v.config.JWKSFile != "" {
+		data, err = ioutil.ReadFile(v.config.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read JWKS file: %w", err)
+		}
+	} else {
+		jwksURL := v.config.JWKSURL
+		if jwksURL == "" {
+			discovery, err := discoverOIDCProvider(v.config.Issuer)
+			if err != nil {
+				return nil, err
+			}
+			jwksURL = discovery.JWKSURI
+		}
+
+		data, err = httpGet(jwksURL)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch JWKS: %w", err)
+		}
+	}
+
+	return parseJWKS(data)
+}
+
+func parseJWKS(data []byte) (map[string]interface{}, error) {
+	set := jsonWebKeySet{}
+	err := json.Unmarshal(data, &set)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode JWKS: %w", err)
+	}
+
+	keys := map[string]interface{}{}
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := k.publicKey()
+		if err != nil {
+			log.Warn().Err(err).Str("kid", k.Kid).Msg("oidc: ignoring key")
+			continue
+		}
+		keys[k.Kid] = key
+	}
+
+	return keys, nil
+}
+
+func (k jsonWebKey) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBase64URLInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus: %w", err)
+		}
+		e, err := decodeBase64URLInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent: %w", err)
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve '%v'", k.Crv)
+		}
+		x, err := decodeBase64URLInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid x coordinate: %w", err)
+		}
+		y, err := decodeBase64URLInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid y coordinate: %w", err)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported key type '%v'", k.Kty)
+}
+
+func decodeBase64URLInt(value string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// verifyAudience checks the "aud" claim, which can be a string or an array
+func verifyAudience(claims jwt.MapClaims, expected string) bool {
+	switch aud := claims["aud"].(type) {
+	case string:
+		return aud == expected
+	case []interface{}:
+		for _, a := range aud {
+			if str, ok := a.(string); ok && str == expected {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type oidcDiscovery struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+func discoverOIDCProvider(issuer string) (oidcDiscovery, error) {
+	discovery := oidcDiscovery{}
+
+	data, err := httpGet(strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration")
+	if err != nil {
+		return discovery, fmt.Errorf("unable to fetch OIDC discovery document: %w", err)
+	}
+
+	err = json.Unmarshal(data, &discovery)
+	if err != nil {
+		return discovery, fmt.Errorf("unable to decode OIDC discovery document: %w", err)
+	}
+	if discovery.JWKSURI == "" {
+		return discovery, fmt.Errorf("no jwks_uri in OIDC discovery document")
+	}
+
+	return discovery, nil
+}
+
+func httpGet(url string) ([]byte, error) {
+	client := http.Client{
+		Timeout: 10 * time.Second,
+	}
+
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("unexpected status: %v", resp.Status)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/dgrijalva/jwt-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testIssuer = "https://sso.example.com"
+
+// newTestOIDCVerifier returns a verifier reading its keys from a JWKS file, and the key signing the tokens
+func newTestOIDCVerifier(t *testing.T, accountRepo manager.AccountRepository, config manager.OIDCConfig) (*oidcVerifier, *rsa.PrivateKey, func()) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "backr-oidc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwks, _ := json.Marshal(jsonWebKeySet{Keys: []jsonWebKey{{
+		Kid: "key1",
+		Kty: "RSA",
+		Use: "sig",
+		N:   base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.PublicKey.E)).Bytes()),
+	}}})
+	jwksFile := filepath.Join(dir, "jwks.json")
+	err = ioutil.WriteFile(jwksFile, jwks, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config.Issuer = testIssuer
+	config.ClientID = "backrctl"
+	config.JWKSFile = jwksFile
+	config.RoleMapping = map[string]string{"backup-admins": "admin"}
+
+	return newOIDCVerifier(config, accountRepo), key, func() { os.RemoveAll(dir) }
+}
+
+func signTestIDToken(t *testing.T, key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "key1"
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestOIDCAuthenticate(t *testing.T) {
+	srv, cleanup := newTestServer(t)
+	defer cleanup()
+
+	// a local account named as a username which can be chosen at the provider
+	_, err := srv.AccountRepo.Create("admin", manager.RoleAdmin, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// even when the local accounts are linked, the username can't be chosen by the user
+	verifier, key, cleanupVerifier := newTestOIDCVerifier(t, srv.AccountRepo, manager.OIDCConfig{LinkLocalAccounts: true})
+	defer cleanupVerifier()
+
+	claims := func(changes jwt.MapClaims) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"iss":                testIssuer,
+			"aud":                "backrctl",
+			"sub":                "8f2a61c4",
+			"preferred_username": "jane",
+			"groups":             []string{"backup-admins"},
+			"exp":                time.Now().Add(time.Hour).Unix(),
+		}
+		for name, value := range changes {
+			if value == nil {
+				delete(c, name)
+				continue
+			}
+			c[name] = value
+		}
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		claims   jwt.MapClaims
+		code     codes.Code
+		username string
+	}{
+		{"valid token", claims(nil), codes.OK, "8f2a61c4"},
+		{"wrong audience", claims(jwt.MapClaims{"aud": "other-client"}), codes.Unauthenticated, ""},
+		{"wrong issuer", claims(jwt.MapClaims{"iss": "https://evil.example.com"}), codes.Unauthenticated, ""},
+		{"expired token", claims(jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()}), codes.Unauthenticated, ""},
+		{"no subject", claims(jwt.MapClaims{"sub": nil}), codes.Unauthenticated, ""},
+		{"unmapped role", claims(jwt.MapClaims{"groups": []string{"developers"}}), codes.PermissionDenied, ""},
+		{"username spoofing", claims(jwt.MapClaims{"preferred_username": "admin", "email": "admin", "groups": nil}), codes.PermissionDenied, ""},
+	}
+
+	for _, test := range tests {
+		id, err := verifier.authenticate(signTestIDToken(t, key, test.claims))
+		if status.Code(err) != test.code {
+			t.Errorf("%v: expected code %v, got %v", test.name, test.code, err)
+			continue
+		}
+		if err == nil && (id.Username != test.username || id.Role != manager.RoleAdmin) {
+			t.Errorf("%v: unexpected identity %+v", test.name, id)
+		}
+	}
+}
+
+func TestOIDCAuthenticateWithEmail(t *testing.T) {
+	srv, cleanup := newTestServer(t)
+	defer cleanup()
+
+	_, err := srv.AccountRepo.Create("jane@example.com", manager.RoleReader, []string{"project1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verifier, key, cleanupVerifier := newTestOIDCVerifier(t, srv.AccountRepo, manager.OIDCConfig{UsernameClaim: "email", LinkLocalAccounts: true})
+	defer cleanupVerifier()
+
+	claims := jwt.MapClaims{
+		"iss":   testIssuer,
+		"aud":   "backrctl",
+		"sub":   "8f2a61c4",
+		"email": "jane@example.com",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}
+
+	// an unverified email can be chosen by the user
+	_, err = verifier.authenticate(signTestIDToken(t, key, claims))
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected an unverified email to be rejected, got %v", err)
+	}
+
+	// the local account is linked explicitly
+	claims["email_verified"] = true
+	id, err := verifier.authenticate(signTestIDToken(t, key, claims))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id.Username != "jane@example.com" || id.Role != manager.RoleReader || len(id.Projects) != 1 {
+		t.Errorf("expected the role of the local account, got %+v", id)
+	}
+}
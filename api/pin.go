@@ -0,0 +1,156 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (srv *server) PinFile(ctx context.Context, req *proto.PinFileRequest) (_ *proto.PinFileResponse, err error) {
+	id, err := srv.authenticateRequest(ctx, manager.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { srv.recordAuditEvent(ctx, id.Username, manager.AuditActionFilePin, req.Filepath, err) }()
+
+	if req.Reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "'reason' is required")
+	}
+
+	now := time.Now()
+	pin := manager.Pin{
+		Path:      req.Filepath,
+		Reason:    req.Reason,
+		Author:    id.Username,
+		CreatedAt: now,
+	}
+	if req.ExpiresAt != 0 {
+		pin.ExpiresAt = time.Unix(req.ExpiresAt, 0)
+		if !pin.ExpiresAt.After(now) {
+			return nil, status.Error(codes.InvalidArgument, "'expires_at' must be in the future")
+		}
+	}
+
+	// only an existing file can be pinned
+	_, err = srv.resolveFile(id, req.Filepath)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := srv.getProjectForFile(req.Filepath)
+	if err != nil {
+		return nil, err
+	}
+
+	// the project is updated atomically, not to overwrite the state saved by a running process
+	err = srv.ProjectRepo.Update(project.Name, func(stored *manager.Project) error {
+		stored.AddPin(pin)
+		return nil
+	})
+	if err != nil {
+		return nil, repositoryError(err, "unable to save project")
+	}
+	srv.publish(manager.Event{Type: manager.EventFilePinned, ProjectName: project.Name, FilePath: pin.Path, Message: pin.Reason})
+
+	return &proto.PinFileResponse{Pin: transformToProtoPin(pin)}, nil
+}
+
+func (srv *server) UnpinFile(ctx context.Context, req *proto.UnpinFileRequest) (_ *proto.UnpinFileResponse, err error) {
+	id, err := srv.authenticateRequest(ctx, manager.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { srv.recordAuditEvent(ctx, id.Username, manager.AuditActionFileUnpin, req.Filepath, err) }()
+
+	if req.Filepath == "" {
+		return nil, status.Error(codes.InvalidArgument, "'filepath' is required")
+	}
+
+	// the file may not exist anymore, so only the project is resolved
+	folder, err := srv.FileRepo.GetFolderForFile(manager.File{Path: req.Filepath})
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid filepath: %v", err)
+	}
+	err = checkProjectAccess(id, folder)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := srv.getProjectForFile(req.Filepath)
+	if err != nil {
+		return nil, err
+	}
+
+	var pin *manager.Pin
+	err = srv.ProjectRepo.Update(project.Name, func(stored *manager.Project) error {
+		for _, p := range stored.Pins {
+			if p.Path == req.Filepath {
+				pin = &p
+				break
+			}
+		}
+		if pin == nil {
+			return status.Errorf(codes.NotFound, "the file '%v' is not pinned", req.Filepath)
+		}
+
+		stored.RemovePin(req.Filepath)
+		return nil
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil, err
+	}
+	if err != nil {
+		return nil, repositoryError(err, "unable to save project")
+	}
+	srv.publish(manager.Event{Type: manager.EventFileUnpinned, ProjectName: project.Name, FilePath: req.Filepath})
+
+	return &proto.UnpinFileResponse{Pin: transformToProtoPin(*pin)}, nil
+}
+
+// getProjectForFile returns the project of the folder of the file
+func (srv *server) getProjectForFile(path string) (*manager.Project, error) {
+	folder, err := srv.FileRepo.GetFolderForFile(manager.File{Path: path})
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid filepath: %v", err)
+	}
+
+	project, err := srv.ProjectRepo.GetByName(folder)
+	if err != nil {
+		return nil, repositoryError(err, "unable to fetch project")
+	}
+	if project == nil {
+		return nil, status.Errorf(codes.NotFound, "no project is configured for the folder '%v'", folder)
+	}
+
+	return project, nil
+}
+
+// activePins returns the pins of the projects which are active at the date, by file path
+func activePins(projects []manager.Project, date time.Time) map[string]manager.Pin {
+	pins := map[string]manager.Pin{}
+	for _, project := range projects {
+		for _, pin := range project.Pins {
+			if pin.IsActive(date) {
+				pins[pin.Path] = pin
+			}
+		}
+	}
+	return pins
+}
+
+func transformToProtoPin(pin manager.Pin) *proto.Pin {
+	p := proto.Pin{
+		Path:      pin.Path,
+		Reason:    pin.Reason,
+		Author:    pin.Author,
+		CreatedAt: pin.CreatedAt.Unix(),
+	}
+	if !pin.ExpiresAt.IsZero() {
+		p.ExpiresAt = pin.ExpiresAt.Unix()
+	}
+	return &p
+}
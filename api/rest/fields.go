@@ -0,0 +1,107 @@
+package rest
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	protobuf "github.com/golang/protobuf/proto"
+)
+
+// field describes a field of a generated proto message
+type field struct {
+	index    int
+	name     string // original name, as written in the .proto file
+	jsonName string // lowerCamelCase name
+	enum     string // full name of the enum type, if any
+}
+
+// messageFields returns the fields of a generated proto message struct, using the protobuf tags
+func messageFields(t reflect.Type) []field {
+	fields := []field{}
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("protobuf")
+		if tag == "" {
+			continue
+		}
+
+		f := field{index: i}
+		for _, part := range strings.Split(tag, ",") {
+			switch {
+			case strings.HasPrefix(part, "name="):
+				f.name = strings.TrimPrefix(part, "name=")
+			case strings.HasPrefix(part, "json="):
+				f.jsonName = strings.TrimPrefix(part, "json=")
+			case strings.HasPrefix(part, "enum="):
+				f.enum = strings.TrimPrefix(part, "enum=")
+			}
+		}
+		if f.jsonName == "" {
+			f.jsonName = f.name
+		}
+
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// setField sets the field of the message named name (original or JSON name) from a string value.
+// Only scalar fields can be set.
+func setField(msg protobuf.Message, name string, value string) error {
+	v := reflect.ValueOf(msg).Elem()
+
+	for _, f := range messageFields(v.Type()) {
+		if f.name != name && f.jsonName != name {
+			continue
+		}
+
+		fv := v.Field(f.index)
+		switch fv.Kind() {
+		case reflect.String:
+			fv.SetString(value)
+
+		case reflect.Bool:
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("'%v' must be a boolean", name)
+			}
+			fv.SetBool(b)
+
+		case reflect.Int32, reflect.Int64:
+			if f.enum != "" {
+				// enums are accepted by name or by number
+				if number, ok := protobuf.EnumValueMap(f.enum)[strings.ToUpper(value)]; ok {
+					fv.SetInt(int64(number))
+					return nil
+				}
+			}
+			i, err := strconv.ParseInt(value, 10, fv.Type().Bits())
+			if err != nil {
+				return fmt.Errorf("'%v' must be an integer", name)
+			}
+			fv.SetInt(i)
+
+		case reflect.Uint32, reflect.Uint64:
+			u, err := strconv.ParseUint(value, 10, fv.Type().Bits())
+			if err != nil {
+				return fmt.Errorf("'%v' must be a positive integer", name)
+			}
+			fv.SetUint(u)
+
+		case reflect.Float32, reflect.Float64:
+			fl, err := strconv.ParseFloat(value, fv.Type().Bits())
+			if err != nil {
+				return fmt.Errorf("'%v' must be a number", name)
+			}
+			fv.SetFloat(fl)
+
+		default:
+			return fmt.Errorf("'%v' cannot be set from a parameter", name)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("unknown parameter '%v'", name)
+}
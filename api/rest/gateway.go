@@ -0,0 +1,206 @@
+// Package rest exposes the gRPC API as REST routes, encoding the proto messages in JSON.
+// Requests are forwarded to the gRPC server implementation, so authentication and
+// validation behave the same way as with the gRPC API.
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/agence-webup/backr/manager/proto"
+	"github.com/golang/protobuf/jsonpb"
+	protobuf "github.com/golang/protobuf/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// max size of a request body
+const maxBodySize = 1 << 20
+
+// NewHandler returns an HTTP handler forwarding the REST routes to the gRPC server implementation.
+// The OpenAPI document describing the routes is served at /openapi.json.
+func NewHandler(srv proto.BackrApiServer) http.Handler {
+	return &gateway{
+		srv:     srv,
+		openAPI: buildOpenAPIDocument(routes),
+	}
+}
+
+type gateway struct {
+	srv     proto.BackrApiServer
+	openAPI map[string]interface{}
+}
+
+func (gw *gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/openapi.json" && r.Method == http.MethodGet {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(gw.openAPI)
+		return
+	}
+
+	pathMatched := false
+	for _, rt := range routes {
+		params, ok := matchPath(rt.path, r.URL.Path)
+		if !ok {
+			continue
+		}
+		pathMatched = true
+
+		if rt.method != r.Method {
+			continue
+		}
+
+		gw.handle(w, r, rt, params)
+		return
+	}
+
+	if pathMatched {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "not found")
+}
+
+// handle decodes the request message, calls the RPC and encodes its response
+func (gw *gateway) handle(w http.ResponseWriter, r *http.Request, rt route, params map[string]string) {
+	req := reflect.New(reflect.TypeOf(rt.request).Elem()).Interface().(protobuf.Message)
+
+	if rt.body {
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "unable to read body: "+err.Error())
+			return
+		}
+		if len(bytes.TrimSpace(body)) > 0 {
+			err = jsonpb.Unmarshal(bytes.NewReader(body), req)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "unable to decode body: "+err.Error())
+				return
+			}
+		}
+	} else {
+		for name, values := range r.URL.Query() {
+			err := setField(req, name, values[len(values)-1])
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+	}
+
+	// path parameters take precedence over the body and the query string
+	for name, value := range params {
+		err := setField(req, name, value)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	resp, err := rt.call(incomingContext(r), gw.srv, req)
+	if err != nil {
+		st := status.Convert(err)
+		writeError(w, httpStatusFromCode(st.Code()), st.Message())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	marshaler := jsonpb.Marshaler{OrigName: true, EmitDefaults: true}
+	err = marshaler.Marshal(w, resp)
+	if err != nil {
+		log.Error().Err(err).Str("rpc", rt.rpc).Msg("rest: unable to encode response")
+	}
+}
+
+// incomingContext returns a context similar to the one of a gRPC request,
+// containing the Authorization header and the address of the client
+func incomingContext(r *http.Request) context.Context {
+	md := metadata.MD{}
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		md.Set("authorization", auth)
+	}
+	ctx := metadata.NewIncomingContext(r.Context(), md)
+
+	host, port, err := net.SplitHostPort(r.RemoteAddr)
+	if err == nil {
+		p, _ := strconv.Atoi(port)
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(host), Port: p}})
+	}
+
+	return ctx
+}
+
+// matchPath checks if the path matches the pattern, and returns the values of the path parameters
+func matchPath(pattern string, path string) (map[string]string, bool) {
+	patternParts := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(patternParts) != len(pathParts) {
+		return nil, false
+	}
+
+	params := map[string]string{}
+	for i, part := range patternParts {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			if pathParts[i] == "" {
+				return nil, false
+			}
+			params[strings.Trim(part, "{}")] = pathParts[i]
+			continue
+		}
+		if part != pathParts[i] {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+	Code  int    `json:"code"`
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(errorResponse{Error: message, Code: statusCode})
+}
+
+// httpStatusFromCode maps a gRPC code to an HTTP status
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.OutOfRange, codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,115 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/agence-webup/backr/manager/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type fakeServer struct {
+	proto.UnimplementedBackrApiServer
+
+	lastAuth string
+}
+
+func (srv *fakeServer) GetProject(ctx context.Context, req *proto.GetProjectRequest) (*proto.ProjectResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	srv.lastAuth = strings.Join(md["authorization"], "")
+
+	if req.Name != "project1" {
+		return nil, status.Error(codes.NotFound, "project not found")
+	}
+	return &proto.ProjectResponse{Project: &proto.Project{Name: req.Name, CreatedAt: 1565000000}}, nil
+}
+
+func (srv *fakeServer) GetProjects(ctx context.Context, req *proto.GetProjectsRequest) (*proto.ProjectsListResponse, error) {
+	if req.OrderBy != proto.GetProjectsRequest_ISSUES_COUNT || req.OrderDir != proto.GetProjectsRequest_DESC {
+		return nil, status.Error(codes.InvalidArgument, "unexpected order")
+	}
+	return &proto.ProjectsListResponse{}, nil
+}
+
+func TestGateway(t *testing.T) {
+	srv := &fakeServer{}
+	handler := NewHandler(srv)
+
+	tests := []struct {
+		method string
+		path   string
+		status int
+	}{
+		{"GET", "/v1/projects/project1", http.StatusOK},
+		{"GET", "/v1/projects/unknown", http.StatusNotFound},
+		{"GET", "/v1/projects?order_by=issues_count&order_dir=1", http.StatusOK},
+		{"GET", "/v1/projects?order_by=foo", http.StatusBadRequest},
+		{"GET", "/v1/projects?foo=bar", http.StatusBadRequest},
+		{"DELETE", "/v1/projects/project1", http.StatusMethodNotAllowed},
+		{"GET", "/v1/unknown", http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, test.path, nil)
+		req.Header.Set("Authorization", "Bearer token")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		if w.Code != test.status {
+			t.Errorf("%v %v: expected status %v, got %v (%v)", test.method, test.path, test.status, w.Code, w.Body.String())
+		}
+	}
+
+	if srv.lastAuth != "Bearer token" {
+		t.Errorf("expected the Authorization header to be forwarded, got '%v'", srv.lastAuth)
+	}
+
+	// the response is encoded using the field names of the proto file
+	req := httptest.NewRequest("GET", "/v1/projects/project1", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	resp := map[string]map[string]interface{}{}
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	if err != nil {
+		t.Fatalf("unable to decode response: %v", err)
+	}
+	if resp["project"]["name"] != "project1" || resp["project"]["created_at"] != "1565000000" {
+		t.Errorf("unexpected response: %v", w.Body.String())
+	}
+}
+
+func TestOpenAPIDocument(t *testing.T) {
+	req := httptest.NewRequest("GET", "/openapi.json", nil)
+	w := httptest.NewRecorder()
+	NewHandler(&fakeServer{}).ServeHTTP(w, req)
+
+	doc := struct {
+		Paths      map[string]map[string]interface{} `json:"paths"`
+		Components struct {
+			Schemas map[string]interface{} `json:"schemas"`
+		} `json:"components"`
+	}{}
+	err := json.Unmarshal(w.Body.Bytes(), &doc)
+	if err != nil {
+		t.Fatalf("unable to decode OpenAPI document: %v", err)
+	}
+
+	for _, rt := range routes {
+		if _, ok := doc.Paths[rt.path][strings.ToLower(rt.method)]; !ok {
+			t.Errorf("missing operation %v %v", rt.method, rt.path)
+		}
+	}
+	for _, name := range []string{"Project", "Rule", "File", "Error"} {
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			t.Errorf("missing schema %v", name)
+		}
+	}
+}
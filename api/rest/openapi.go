@@ -0,0 +1,205 @@
+package rest
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	protobuf "github.com/golang/protobuf/proto"
+)
+
+// buildOpenAPIDocument generates an OpenAPI 3 document describing the routes.
+// The schemas are deduced from the generated proto messages.
+func buildOpenAPIDocument(routes []route) map[string]interface{} {
+	schemas := map[string]interface{}{
+		"Error": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"error": map[string]interface{}{"type": "string"},
+				"code":  map[string]interface{}{"type": "integer"},
+			},
+		},
+	}
+
+	paths := map[string]interface{}{}
+	for _, rt := range routes {
+		requestType := reflect.TypeOf(rt.request).Elem()
+		responseType := reflect.TypeOf(rt.response).Elem()
+
+		pathParams, _ := matchPath(rt.path, rt.path)
+
+		operationID := rt.rpc
+		if rt.operationID != "" {
+			operationID = rt.operationID
+		}
+
+		operation := map[string]interface{}{
+			"operationId": operationID,
+			"summary":     rt.summary,
+			"tags":        []string{rt.tag},
+			"responses": map[string]interface{}{
+				"200": jsonContent("OK", schemaRef(responseType, schemas)),
+				"default": jsonContent("Error", map[string]interface{}{
+					"$ref": "#/components/schemas/Error",
+				}),
+			},
+		}
+		if rt.public {
+			operation["security"] = []interface{}{}
+		}
+
+		parameters := []interface{}{}
+		for _, f := range messageFields(requestType) {
+			fieldType := requestType.Field(f.index).Type
+			in := "query"
+			if _, ok := pathParams[f.name]; ok {
+				in = "path"
+			} else if rt.body || !isScalar(fieldType) {
+				continue
+			}
+
+			parameters = append(parameters, map[string]interface{}{
+				"name":     f.name,
+				"in":       in,
+				"required": in == "path",
+				"schema":   fieldSchema(fieldType, f, schemas),
+			})
+		}
+		if len(parameters) > 0 {
+			operation["parameters"] = parameters
+		}
+
+		if rt.body {
+			operation["requestBody"] = map[string]interface{}{
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{
+						"schema": schemaRef(requestType, schemas),
+					},
+				},
+			}
+		}
+
+		path, ok := paths[rt.path].(map[string]interface{})
+		if !ok {
+			path = map[string]interface{}{}
+			paths[rt.path] = path
+		}
+		path[strings.ToLower(rt.method)] = operation
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.0",
+		"info": map[string]interface{}{
+			"title":   "Backr manager API",
+			"version": "v1",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+			"securitySchemes": map[string]interface{}{
+				"bearerAuth": map[string]interface{}{
+					"type":         "http",
+					"scheme":       "bearer",
+					"bearerFormat": "JWT",
+				},
+			},
+		},
+		"security": []interface{}{
+			map[string]interface{}{"bearerAuth": []string{}},
+		},
+	}
+}
+
+func jsonContent(description string, schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"description": description,
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{
+				"schema": schema,
+			},
+		},
+	}
+}
+
+// schemaRef registers the schema of the message in schemas, and returns a reference to it
+func schemaRef(t reflect.Type, schemas map[string]interface{}) map[string]interface{} {
+	name := t.Name()
+	ref := map[string]interface{}{"$ref": "#/components/schemas/" + name}
+	if _, ok := schemas[name]; ok {
+		return ref
+	}
+
+	properties := map[string]interface{}{}
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	// register it before walking the fields, to handle recursive messages
+	schemas[name] = schema
+
+	for _, f := range messageFields(t) {
+		properties[f.name] = fieldSchema(t.Field(f.index).Type, f, schemas)
+	}
+
+	return ref
+}
+
+// fieldSchema returns the schema of a field, according to the JSON mapping of proto3
+func fieldSchema(t reflect.Type, f field, schemas map[string]interface{}) map[string]interface{} {
+	if f.enum != "" {
+		return enumSchema(f.enum)
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int32:
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case reflect.Uint32:
+		return map[string]interface{}{"type": "integer", "format": "uint32"}
+	case reflect.Int64:
+		// 64-bit integers are encoded as strings
+		return map[string]interface{}{"type": "string", "format": "int64"}
+	case reflect.Uint64:
+		return map[string]interface{}{"type": "string", "format": "uint64"}
+	case reflect.Float32:
+		return map[string]interface{}{"type": "number", "format": "float"}
+	case reflect.Float64:
+		return map[string]interface{}{"type": "number", "format": "double"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": fieldSchema(t.Elem(), field{}, schemas)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": fieldSchema(t.Elem(), field{}, schemas)}
+	case reflect.Ptr:
+		if t.Elem().Kind() == reflect.Struct {
+			return schemaRef(t.Elem(), schemas)
+		}
+	}
+
+	return map[string]interface{}{}
+}
+
+// enumSchema returns the schema of an enum, encoded using the names of its values
+func enumSchema(enum string) map[string]interface{} {
+	values := protobuf.EnumValueMap(enum)
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return values[names[i]] < values[names[j]] })
+
+	return map[string]interface{}{"type": "string", "enum": names}
+}
+
+func isScalar(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Ptr, reflect.Struct:
+		return false
+	}
+	return true
+}
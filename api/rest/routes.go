@@ -0,0 +1,252 @@
+package rest
+
+import (
+	"context"
+
+	"github.com/agence-webup/backr/manager/proto"
+	protobuf "github.com/golang/protobuf/proto"
+)
+
+// route maps an HTTP method and path to a RPC of the API.
+// Path parameters are written as {field}, field being the name of a field of the request message.
+type route struct {
+	method  string
+	path    string
+	rpc     string
+	summary string
+	// operationID identifies the route in the OpenAPI document (rpc if empty),
+	// it must be set when a RPC is exposed by several routes
+	operationID string
+	tag         string
+
+	// public routes don't require an Authorization header
+	public bool
+	// body is true when the request message is decoded from the JSON body,
+	// otherwise it is decoded from the query string
+	body bool
+
+	request  protobuf.Message
+	response protobuf.Message
+	call     func(ctx context.Context, srv proto.BackrApiServer, req protobuf.Message) (protobuf.Message, error)
+}
+
+// routes lists every RPC exposed by the gateway
+var routes = []route{
+	{
+		method: "GET", path: "/v1/projects", rpc: "GetProjects", tag: "projects",
+		summary:  "List projects",
+		request:  &proto.GetProjectsRequest{},
+		response: &proto.ProjectsListResponse{},
+		call: func(ctx context.Context, srv proto.BackrApiServer, req protobuf.Message) (protobuf.Message, error) {
+			return srv.GetProjects(ctx, req.(*proto.GetProjectsRequest))
+		},
+	},
+	{
+		method: "POST", path: "/v1/projects", rpc: "CreateProject", tag: "projects", body: true,
+		summary:  "Create a project",
+		request:  &proto.CreateProjectRequest{},
+		response: &proto.CreateProjectResponse{},
+		call: func(ctx context.Context, srv proto.BackrApiServer, req protobuf.Message) (protobuf.Message, error) {
+			return srv.CreateProject(ctx, req.(*proto.CreateProjectRequest))
+		},
+	},
+	{
+		method: "GET", path: "/v1/projects/{name}", rpc: "GetProject", tag: "projects",
+		summary:  "Get a project, with the state of its rules",
+		request:  &proto.GetProjectRequest{},
+		response: &proto.ProjectResponse{},
+		call: func(ctx context.Context, srv proto.BackrApiServer, req protobuf.Message) (protobuf.Message, error) {
+			return srv.GetProject(ctx, req.(*proto.GetProjectRequest))
+		},
+	},
+	{
+		method: "PUT", path: "/v1/projects/{name}", rpc: "UpdateProject", tag: "projects", body: true,
+		summary:  "Replace the rules of a project, the state of the removed rules is dropped",
+		request:  &proto.UpdateProjectRequest{},
+		response: &proto.UpdateProjectResponse{},
+		call: func(ctx context.Context, srv proto.BackrApiServer, req protobuf.Message) (protobuf.Message, error) {
+			return srv.UpdateProject(ctx, req.(*proto.UpdateProjectRequest))
+		},
+	},
+	{
+		method: "POST", path: "/v1/projects/{project_name}/deletion-plan/approve", rpc: "ApproveDeletionPlan", tag: "projects", body: true,
+		summary:  "Approve the deletion plan of a project, blocked by the deletion guard",
+		request:  &proto.ApproveDeletionPlanRequest{},
+		response: &proto.ProjectResponse{},
+		call: func(ctx context.Context, srv proto.BackrApiServer, req protobuf.Message) (protobuf.Message, error) {
+			return srv.ApproveDeletionPlan(ctx, req.(*proto.ApproveDeletionPlanRequest))
+		},
+	},
+	{
+		method: "POST", path: "/v1/projects/{project_name}/status", rpc: "SetProjectStatus", tag: "projects", body: true,
+		summary:  "Pause or resume the processing of a project",
+		request:  &proto.SetProjectStatusRequest{},
+		response: &proto.ProjectResponse{},
+		call: func(ctx context.Context, srv proto.BackrApiServer, req protobuf.Message) (protobuf.Message, error) {
+			return srv.SetProjectStatus(ctx, req.(*proto.SetProjectStatusRequest))
+		},
+	},
+	{
+		method: "POST", path: "/v1/rules/simulate", rpc: "SimulateRules", tag: "rules", body: true,
+		summary:  "Replay the process day by day, to see what rules do over a long period",
+		request:  &proto.SimulateRulesRequest{},
+		response: &proto.SimulateRulesResponse{},
+		call: func(ctx context.Context, srv proto.BackrApiServer, req protobuf.Message) (protobuf.Message, error) {
+			return srv.SimulateRules(ctx, req.(*proto.SimulateRulesRequest))
+		},
+	},
+	{
+		method: "GET", path: "/v1/projects/{project_name}/files", rpc: "GetFiles", operationID: "GetProjectFiles", tag: "files",
+		summary:  "List the files of a project",
+		request:  &proto.GetFilesRequest{},
+		response: &proto.GetFilesResponse{},
+		call: func(ctx context.Context, srv proto.BackrApiServer, req protobuf.Message) (protobuf.Message, error) {
+			return srv.GetFiles(ctx, req.(*proto.GetFilesRequest))
+		},
+	},
+	{
+		method: "GET", path: "/v1/files", rpc: "GetFiles", tag: "files",
+		summary:  "List all files",
+		request:  &proto.GetFilesRequest{},
+		response: &proto.GetFilesResponse{},
+		call: func(ctx context.Context, srv proto.BackrApiServer, req protobuf.Message) (protobuf.Message, error) {
+			return srv.GetFiles(ctx, req.(*proto.GetFilesRequest))
+		},
+	},
+	{
+		// the file path contains slashes, so it is passed in the query string
+		method: "GET", path: "/v1/files/url", rpc: "GetFileURL", tag: "files",
+		summary:  "Get a temporary URL to download a file",
+		request:  &proto.GetFileURLRequest{},
+		response: &proto.GetFileURLResponse{},
+		call: func(ctx context.Context, srv proto.BackrApiServer, req protobuf.Message) (protobuf.Message, error) {
+			return srv.GetFileURL(ctx, req.(*proto.GetFileURLRequest))
+		},
+	},
+	{
+		method: "POST", path: "/v1/files/pin", rpc: "PinFile", tag: "files", body: true,
+		summary:  "Pin a file, kept regardless of the rules",
+		request:  &proto.PinFileRequest{},
+		response: &proto.PinFileResponse{},
+		call: func(ctx context.Context, srv proto.BackrApiServer, req protobuf.Message) (protobuf.Message, error) {
+			return srv.PinFile(ctx, req.(*proto.PinFileRequest))
+		},
+	},
+	{
+		method: "POST", path: "/v1/files/unpin", rpc: "UnpinFile", tag: "files", body: true,
+		summary:  "Unpin a file",
+		request:  &proto.UnpinFileRequest{},
+		response: &proto.UnpinFileResponse{},
+		call: func(ctx context.Context, srv proto.BackrApiServer, req protobuf.Message) (protobuf.Message, error) {
+			return srv.UnpinFile(ctx, req.(*proto.UnpinFileRequest))
+		},
+	},
+	{
+		method: "GET", path: "/v1/trash", rpc: "ListTrash", tag: "trash",
+		summary:  "List the files of the trash",
+		request:  &proto.ListTrashRequest{},
+		response: &proto.ListTrashResponse{},
+		call: func(ctx context.Context, srv proto.BackrApiServer, req protobuf.Message) (protobuf.Message, error) {
+			return srv.ListTrash(ctx, req.(*proto.ListTrashRequest))
+		},
+	},
+	{
+		method: "POST", path: "/v1/trash/restore", rpc: "RestoreFile", tag: "trash", body: true,
+		summary:  "Restore a file from the trash",
+		request:  &proto.RestoreFileRequest{},
+		response: &proto.RestoreFileResponse{},
+		call: func(ctx context.Context, srv proto.BackrApiServer, req protobuf.Message) (protobuf.Message, error) {
+			return srv.RestoreFile(ctx, req.(*proto.RestoreFileRequest))
+		},
+	},
+	{
+		method: "POST", path: "/v1/trash/purge", rpc: "PurgeTrash", tag: "trash", body: true,
+		summary:  "Permanently delete a file of the trash, or the files whose grace period is over",
+		request:  &proto.PurgeTrashRequest{},
+		response: &proto.PurgeTrashResponse{},
+		call: func(ctx context.Context, srv proto.BackrApiServer, req protobuf.Message) (protobuf.Message, error) {
+			return srv.PurgeTrash(ctx, req.(*proto.PurgeTrashRequest))
+		},
+	},
+	{
+		method: "GET", path: "/v1/maintenance", rpc: "GetMaintenance", tag: "maintenance",
+		summary:  "Get the maintenance mode, freezing all the deletions and alerts",
+		request:  &proto.GetMaintenanceRequest{},
+		response: &proto.MaintenanceResponse{},
+		call: func(ctx context.Context, srv proto.BackrApiServer, req protobuf.Message) (protobuf.Message, error) {
+			return srv.GetMaintenance(ctx, req.(*proto.GetMaintenanceRequest))
+		},
+	},
+	{
+		method: "POST", path: "/v1/maintenance", rpc: "SetMaintenance", tag: "maintenance", body: true,
+		summary:  "Enable or disable the maintenance mode",
+		request:  &proto.SetMaintenanceRequest{},
+		response: &proto.MaintenanceResponse{},
+		call: func(ctx context.Context, srv proto.BackrApiServer, req protobuf.Message) (protobuf.Message, error) {
+			return srv.SetMaintenance(ctx, req.(*proto.SetMaintenanceRequest))
+		},
+	},
+	{
+		method: "GET", path: "/v1/accounts", rpc: "ListAccounts", tag: "accounts",
+		summary:  "List accounts",
+		request:  &proto.ListAccountsRequest{},
+		response: &proto.AccountsListResponse{},
+		call: func(ctx context.Context, srv proto.BackrApiServer, req protobuf.Message) (protobuf.Message, error) {
+			return srv.ListAccounts(ctx, req.(*proto.ListAccountsRequest))
+		},
+	},
+	{
+		method: "POST", path: "/v1/accounts", rpc: "CreateAccount", tag: "accounts", body: true,
+		summary:  "Create an account, and return its generated password",
+		request:  &proto.CreateAccountRequest{},
+		response: &proto.AccountResponse{},
+		call: func(ctx context.Context, srv proto.BackrApiServer, req protobuf.Message) (protobuf.Message, error) {
+			return srv.CreateAccount(ctx, req.(*proto.CreateAccountRequest))
+		},
+	},
+	{
+		method: "POST", path: "/v1/accounts/{username}/password", rpc: "ChangeAccountPassword", tag: "accounts", body: true,
+		summary:  "Generate a new password for an account",
+		request:  &proto.ChangeAccountPasswordRequest{},
+		response: &proto.AccountResponse{},
+		call: func(ctx context.Context, srv proto.BackrApiServer, req protobuf.Message) (protobuf.Message, error) {
+			return srv.ChangeAccountPassword(ctx, req.(*proto.ChangeAccountPasswordRequest))
+		},
+	},
+	{
+		method: "POST", path: "/v1/auth/login", rpc: "AuthenticateAccount", tag: "auth", body: true, public: true,
+		summary:  "Authenticate with username and password, and return a token",
+		request:  &proto.AuthenticateAccountRequest{},
+		response: &proto.AuthenticateAccountResponse{},
+		call: func(ctx context.Context, srv proto.BackrApiServer, req protobuf.Message) (protobuf.Message, error) {
+			return srv.AuthenticateAccount(ctx, req.(*proto.AuthenticateAccountRequest))
+		},
+	},
+	{
+		method: "GET", path: "/v1/auth/config", rpc: "GetAuthConfig", tag: "auth", public: true,
+		summary:  "Get the authentication settings (OpenID Connect provider)",
+		request:  &proto.GetAuthConfigRequest{},
+		response: &proto.AuthConfigResponse{},
+		call: func(ctx context.Context, srv proto.BackrApiServer, req protobuf.Message) (protobuf.Message, error) {
+			return srv.GetAuthConfig(ctx, req.(*proto.GetAuthConfigRequest))
+		},
+	},
+	{
+		method: "GET", path: "/v1/audit", rpc: "ListAuditEvents", tag: "audit",
+		summary:  "List audit events, from the most recent",
+		request:  &proto.ListAuditEventsRequest{},
+		response: &proto.AuditEventsListResponse{},
+		call: func(ctx context.Context, srv proto.BackrApiServer, req protobuf.Message) (protobuf.Message, error) {
+			return srv.ListAuditEvents(ctx, req.(*proto.ListAuditEventsRequest))
+		},
+	},
+	{
+		method: "GET", path: "/v1/notifications", rpc: "ListNotifications", tag: "notifications",
+		summary:  "List sent notifications, from the most recent",
+		request:  &proto.ListNotificationsRequest{},
+		response: &proto.NotificationsListResponse{},
+		call: func(ctx context.Context, srv proto.BackrApiServer, req protobuf.Message) (protobuf.Message, error) {
+			return srv.ListNotifications(ctx, req.(*proto.ListNotificationsRequest))
+		},
+	},
+}
@@ -2,46 +2,80 @@ package api
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/events"
 	"github.com/agence-webup/backr/manager/proto"
+	"github.com/agence-webup/backr/manager/trash"
 	"github.com/dgrijalva/jwt-go"
+	"github.com/rs/zerolog/log"
 )
 
-func NewServer(projectRepo manager.ProjectRepository, fileRepo manager.FileRepository, accountRepo manager.AccountRepository, authConfig manager.APIConfig) proto.BackrApiServer {
+// NewServer returns an implementation of the gRPC API.
+// fileRepo should hide the files of the trash (see trash.Trash.FileRepository),
+// the trash RPCs are disabled if trash is nil.
+// setupToken is the one-time token allowing to create the first account,
+// an empty token disables the bootstrap through the API.
+func NewServer(projectRepo manager.ProjectRepository, fileRepo manager.FileRepository, accountRepo manager.AccountRepository, auditRepo manager.AuditRepository, notificationRepo manager.NotificationRepository, downloadTokenRepo manager.DownloadTokenRepository, maintenanceRepo manager.MaintenanceRepository, trash *trash.Trash, eventBus *events.Bus, authConfig manager.APIConfig, setupToken string) proto.BackrApiServer {
 	srv := server{
-		ProjectRepo: projectRepo,
-		FileRepo:    fileRepo,
-		AccountRepo: accountRepo,
-		Config:      authConfig,
+		ProjectRepo:       projectRepo,
+		FileRepo:          fileRepo,
+		AccountRepo:       accountRepo,
+		AuditRepo:         auditRepo,
+		NotificationRepo:  notificationRepo,
+		DownloadTokenRepo: downloadTokenRepo,
+		MaintenanceRepo:   maintenanceRepo,
+		Trash:             trash,
+		Events:            eventBus,
+		Config:            authConfig,
+		setupToken:        setupToken,
+		throttler:         newLoginThrottler(authConfig.LoginAttemptsWindow, authConfig.LoginLockoutDuration),
+		oidc:              newOIDCVerifier(authConfig.OIDC, accountRepo),
 	}
 	return &srv
 }
 
 type server struct {
-	ProjectRepo manager.ProjectRepository
-	FileRepo    manager.FileRepository
-	AccountRepo manager.AccountRepository
-	Config      manager.APIConfig
+	ProjectRepo       manager.ProjectRepository
+	FileRepo          manager.FileRepository
+	AccountRepo       manager.AccountRepository
+	AuditRepo         manager.AuditRepository
+	NotificationRepo  manager.NotificationRepository
+	DownloadTokenRepo manager.DownloadTokenRepository
+	MaintenanceRepo   manager.MaintenanceRepository
+	Trash             *trash.Trash
+	Events            *events.Bus
+	Config            manager.APIConfig
+
+	setupToken      string
+	setupTokenMutex sync.Mutex
+
+	throttler *loginThrottler
+	oidc      *oidcVerifier
 }
 
 func (srv *server) GetProjects(ctx context.Context, req *proto.GetProjectsRequest) (*proto.ProjectsListResponse, error) {
-	err := srv.authenticateRequest(ctx)
+	id, err := srv.authenticateRequest(ctx, manager.RoleAdmin, manager.RoleReader)
 	if err != nil {
 		return nil, err
 	}
 
 	rawProjects, err := srv.ProjectRepo.GetAll()
 	if err != nil {
-		return nil, err
+		return nil, repositoryError(err, "unable to fetch projects")
 	}
 
 	projects := []*proto.Project{}
 	for _, rawP := range rawProjects {
+		if !id.allowsProject(rawP.Name) {
+			continue
+		}
 		p := transformToProtoProject(rawP)
 		projects = append(projects, &p)
 	}
@@ -53,14 +87,19 @@ func (srv *server) GetProjects(ctx context.Context, req *proto.GetProjectsReques
 }
 
 func (srv *server) GetProject(ctx context.Context, req *proto.GetProjectRequest) (*proto.ProjectResponse, error) {
-	err := srv.authenticateRequest(ctx)
+	id, err := srv.authenticateRequest(ctx, manager.RoleAdmin, manager.RoleReader)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkProjectAccess(id, req.Name)
 	if err != nil {
 		return nil, err
 	}
 
 	rawProject, err := srv.ProjectRepo.GetByName(req.Name)
 	if err != nil {
-		return nil, status.Error(codes.Internal, "unable to fetch project from repo")
+		return nil, repositoryError(err, "unable to fetch project")
 	}
 	if rawProject == nil {
 		return nil, status.Error(codes.NotFound, "project not found")
@@ -71,39 +110,44 @@ func (srv *server) GetProject(ctx context.Context, req *proto.GetProjectRequest)
 	return &proto.ProjectResponse{Project: &p}, nil
 }
 
-func (srv *server) CreateProject(ctx context.Context, req *proto.CreateProjectRequest) (*proto.CreateProjectResponse, error) {
-	err := srv.authenticateRequest(ctx)
+func (srv *server) CreateProject(ctx context.Context, req *proto.CreateProjectRequest) (_ *proto.CreateProjectResponse, err error) {
+	id, err := srv.authenticateRequest(ctx, manager.RoleAdmin)
 	if err != nil {
 		return nil, err
 	}
+	defer func() { srv.recordAuditEvent(ctx, id.Username, manager.AuditActionProjectCreate, req.Name, err) }()
 
 	if req.Name == "" {
 		return nil, status.Error(codes.InvalidArgument, "'name' is required")
 	}
+	if req.Name == manager.SelfBackupFolder {
+		return nil, status.Errorf(codes.InvalidArgument, "the name '%v' is reserved", req.Name)
+	}
 	if len(req.Rules) == 0 {
 		return nil, status.Error(codes.InvalidArgument, "'rules' is required and must not be empty")
 	}
 
 	existingProject, err := srv.ProjectRepo.GetByName(req.Name)
 	if err != nil {
-		return nil, status.Error(codes.Internal, "unable to get project by name")
+		return nil, repositoryError(err, "unable to get project by name")
 	}
 	if existingProject != nil {
 		return nil, status.Error(codes.FailedPrecondition, "a project with this name already exists")
 	}
 
-	rules := []manager.Rule{}
-	for _, r := range req.Rules {
-		minAge := 1
-		if r.MinAge > 0 {
-			minAge = int(r.MinAge)
-		}
-		count := 3
-		if r.Count > 0 {
-			count = int(r.Count)
-		}
-		rule := manager.Rule{MinAge: minAge, Count: count}
-		rules = append(rules, rule)
+	rules := transformFromProtoRules(req.Rules)
+	uploadInterval := time.Duration(req.UploadInterval) * time.Second
+	caps, err := transformFromProtoCaps(req.Caps)
+	if err != nil {
+		return nil, err
+	}
+	dateSource, err := transformFromProtoDateSource(req.DateSource)
+	if err != nil {
+		return nil, err
+	}
+	report, err := lintRules(rules, uploadInterval, caps)
+	if err != nil {
+		return nil, err
 	}
 
 	// setup the state if the project must be processed immediately
@@ -120,38 +164,140 @@ func (srv *server) CreateProject(ctx context.Context, req *proto.CreateProjectRe
 	}
 
 	project := manager.Project{
-		Name:      req.Name,
-		Rules:     rules,
-		CreatedAt: time.Now(),
-		State:     state,
+		Name:           req.Name,
+		Rules:          rules,
+		CreatedAt:      time.Now(),
+		State:          state,
+		UploadInterval: uploadInterval,
+		Caps:           caps,
+		DateSource:     dateSource,
 	}
 
-	srv.ProjectRepo.Save(project)
+	err = srv.ProjectRepo.Save(project)
+	if err != nil {
+		return nil, repositoryError(err, "unable to save project")
+	}
+	srv.publish(manager.Event{Type: manager.EventProjectCreated, ProjectName: project.Name})
 
 	protoProject := transformToProtoProject(project)
 	resp := proto.CreateProjectResponse{
 		Project: &protoProject,
+		Report:  report,
 	}
 
 	return &resp, nil
 }
 
+func (srv *server) UpdateProject(ctx context.Context, req *proto.UpdateProjectRequest) (_ *proto.UpdateProjectResponse, err error) {
+	id, err := srv.authenticateRequest(ctx, manager.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { srv.recordAuditEvent(ctx, id.Username, manager.AuditActionProjectUpdate, req.Name, err) }()
+
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "'name' is required")
+	}
+	if len(req.Rules) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "'rules' is required and must not be empty")
+	}
+
+	err = checkProjectAccess(id, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// the project is updated atomically, not to overwrite the pins, the pause, the deletion plan
+	// or the state saved meanwhile by the API or by a running process
+	var project manager.Project
+	var report *proto.RulesReport
+	err = srv.ProjectRepo.Update(req.Name, func(stored *manager.Project) error {
+		rules := transformFromProtoRules(req.Rules)
+		uploadInterval := time.Duration(req.UploadInterval) * time.Second
+		caps, err := transformFromProtoCaps(req.Caps)
+		if err != nil {
+			return err
+		}
+		dateSource, err := transformFromProtoDateSource(req.DateSource)
+		if err != nil {
+			return err
+		}
+		report, err = lintRules(rules, uploadInterval, caps)
+		if err != nil {
+			return err
+		}
+
+		// drop the state of the removed rules, their files must not be kept anymore
+		state := manager.ProjectState{}
+		for _, r := range rules {
+			if ruleState, ok := stored.State[r.GetID()]; ok {
+				state[r.GetID()] = ruleState
+			}
+		}
+
+		stored.Rules = rules
+		stored.State = state
+		stored.UploadInterval = uploadInterval
+		stored.Caps = caps
+		stored.DateSource = dateSource
+
+		project = *stored
+		return nil
+	})
+	if err != nil {
+		// the validation errors are already gRPC errors
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, repositoryError(err, "unable to save project")
+	}
+	srv.publish(manager.Event{Type: manager.EventProjectUpdated, ProjectName: project.Name, Message: "rules changed"})
+
+	protoProject := transformToProtoProject(project)
+
+	return &proto.UpdateProjectResponse{Project: &protoProject, Report: report}, nil
+}
+
 func (srv *server) GetFiles(ctx context.Context, req *proto.GetFilesRequest) (*proto.GetFilesResponse, error) {
-	err := srv.authenticateRequest(ctx)
+	id, err := srv.authenticateRequest(ctx, manager.RoleAdmin, manager.RoleReader)
 	if err != nil {
 		return nil, err
 	}
 
+	now := time.Now()
+
 	if req.ProjectName != "" {
+		err = checkProjectAccess(id, req.ProjectName)
+		if err != nil {
+			return nil, err
+		}
+
 		filesByFolder, err := srv.FileRepo.GetAllByFolder()
 		if err != nil {
-			return nil, status.Error(codes.Internal, "unable to fetch files:"+err.Error())
+			return nil, repositoryError(err, "unable to fetch files")
+		}
+
+		project, err := srv.ProjectRepo.GetByName(req.ProjectName)
+		if err != nil {
+			return nil, repositoryError(err, "unable to fetch project")
+		}
+		projects := []manager.Project{}
+		if project != nil {
+			projects = append(projects, *project)
+		}
+		pins := activePins(projects, now)
+		dateReader := srv.newDateReader(manager.DateSource{})
+		if project != nil {
+			dateReader = srv.newDateReader(project.DateSource)
 		}
 
 		rawFiles := filesByFolder[req.ProjectName]
 		files := []*proto.File{}
 		for _, rf := range rawFiles {
-			f := transformToProtoFile(rf)
+			f := transformToProtoFileWithDate(rf, dateReader)
+			if pin, ok := pins[rf.Path]; ok {
+				f.Pin = transformToProtoPin(pin)
+			}
 			files = append(files, &f)
 		}
 
@@ -163,11 +309,35 @@ func (srv *server) GetFiles(ctx context.Context, req *proto.GetFilesRequest) (*p
 	// all files
 	rawFiles, err := srv.FileRepo.GetAll()
 	if err != nil {
-		return nil, status.Error(codes.Internal, "unable to fetch files:"+err.Error())
+		return nil, repositoryError(err, "unable to fetch files")
+	}
+
+	// the corrupted projects are ignored, their files are just not displayed as pinned
+	projects, err := srv.ProjectRepo.GetAll()
+	if err != nil && !errors.Is(err, manager.ErrCorruptedRecord) {
+		return nil, repositoryError(err, "unable to fetch projects")
 	}
+	pins := activePins(projects, now)
+	dateReaders := map[string]*manager.DateReader{}
+	for _, p := range projects {
+		dateReaders[p.Name] = srv.newDateReader(p.DateSource)
+	}
+	defaultDateReader := srv.newDateReader(manager.DateSource{})
+
 	files := []*proto.File{}
 	for _, rf := range rawFiles {
-		f := transformToProtoFile(rf)
+		folder, err := srv.FileRepo.GetFolderForFile(rf)
+		if len(id.Projects) > 0 && (err != nil || !id.allowsProject(folder)) {
+			continue
+		}
+		dateReader, ok := dateReaders[folder]
+		if !ok {
+			dateReader = defaultDateReader
+		}
+		f := transformToProtoFileWithDate(rf, dateReader)
+		if pin, ok := pins[rf.Path]; ok {
+			f.Pin = transformToProtoPin(pin)
+		}
 		files = append(files, &f)
 	}
 
@@ -176,60 +346,80 @@ func (srv *server) GetFiles(ctx context.Context, req *proto.GetFilesRequest) (*p
 	}, nil
 }
 
-func (srv *server) GetFileURL(ctx context.Context, req *proto.GetFileURLRequest) (*proto.GetFileURLResponse, error) {
-	err := srv.authenticateRequest(ctx)
+func (srv *server) CreateAccount(ctx context.Context, req *proto.CreateAccountRequest) (_ *proto.AccountResponse, err error) {
+	id, isBootstrap, err := srv.authenticateBootstrapRequest(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer func() { srv.recordAuditEvent(ctx, id.Username, manager.AuditActionAccountCreate, req.Username, err) }()
 
-	file, err := srv.FileRepo.GetURL(manager.File{Path: req.Filepath})
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "unable to get url: %v", err)
+	if req.Username == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "username is required")
 	}
 
-	return &proto.GetFileURLResponse{Url: file.String()}, nil
-}
-
-func (srv *server) CreateAccount(ctx context.Context, req *proto.CreateAccountRequest) (*proto.AccountResponse, error) {
-	err := srv.authenticateRequest(ctx)
-	if err != nil {
-		return nil, err
+	role := manager.RoleAdmin
+	if req.Role != "" {
+		role = manager.Role(req.Role)
+	}
+	if !role.IsValid() {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown role '%v'", req.Role)
 	}
 
-	if req.Username == "" {
-		return nil, status.Errorf(codes.InvalidArgument, "username is required")
+	// the setup token can be used only once
+	if isBootstrap {
+		token := srv.claimSetupToken()
+		if token == "" {
+			return nil, status.Error(codes.Unauthenticated, "setup token has already been used")
+		}
+
+		// the first account must be able to manage the other ones
+		password, err := srv.AccountRepo.Create(req.Username, manager.RoleAdmin, nil)
+		if err != nil {
+			srv.restoreSetupToken(token)
+			return nil, repositoryError(err, "unable to create account")
+		}
+
+		log.Info().Str("username", req.Username).Msg("bootstrap: first account created, setup token is now disabled")
+
+		return &proto.AccountResponse{
+			Account:  &proto.Account{Username: req.Username, Role: string(manager.RoleAdmin)},
+			Password: password,
+		}, nil
 	}
 
-	existingAccount, _ := srv.AccountRepo.Get(req.Username)
+	existingAccount, err := srv.AccountRepo.Get(req.Username)
+	if err != nil {
+		return nil, repositoryError(err, "unable to get account")
+	}
 	if existingAccount != nil {
 		return nil, status.Errorf(codes.FailedPrecondition, "an account already exists with this username")
 	}
 
-	password, err := srv.AccountRepo.Create(req.Username)
+	password, err := srv.AccountRepo.Create(req.Username, role, req.Projects)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "unable to create account: %v", err)
+		return nil, repositoryError(err, "unable to create account")
 	}
 
 	return &proto.AccountResponse{
-		Account:  &proto.Account{Username: req.Username},
+		Account:  &proto.Account{Username: req.Username, Role: string(role), Projects: req.Projects},
 		Password: password,
 	}, nil
 }
 
 func (srv *server) ListAccounts(ctx context.Context, req *proto.ListAccountsRequest) (*proto.AccountsListResponse, error) {
-	err := srv.authenticateRequest(ctx)
+	_, err := srv.authenticateRequest(ctx, manager.RoleAdmin)
 	if err != nil {
 		return nil, err
 	}
 
 	rawAccounts, err := srv.AccountRepo.List()
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "unable to fetch account list: %v", err)
+		return nil, repositoryError(err, "unable to fetch account list")
 	}
 
 	accounts := []*proto.Account{}
 	for _, a := range rawAccounts {
-		accounts = append(accounts, &proto.Account{Username: a.Username})
+		accounts = append(accounts, &proto.Account{Username: a.Username, Role: string(a.GetRole()), Projects: a.Projects})
 	}
 
 	return &proto.AccountsListResponse{Accounts: accounts}, nil
@@ -237,11 +427,34 @@ func (srv *server) ListAccounts(ctx context.Context, req *proto.ListAccountsRequ
 
 func (srv *server) AuthenticateAccount(ctx context.Context, req *proto.AuthenticateAccountRequest) (*proto.AuthenticateAccountResponse, error) {
 
+	// the same errors are returned whatever the cause, to avoid leaking which usernames exist
+	userKey := "user:" + req.Username
+	ipKey := "ip:" + peerIP(ctx)
+
+	for _, key := range []string{userKey, ipKey} {
+		if locked, remaining := srv.throttler.isLocked(key); locked {
+			log.Warn().Str("username", req.Username).Str("key", key).Dur("remaining", remaining).Msg("login: locked out")
+			err := status.Error(codes.ResourceExhausted, "too many failed attempts, retry later")
+			srv.recordAuditEvent(ctx, req.Username, manager.AuditActionLogin, req.Username, err)
+			return nil, err
+		}
+	}
+
 	err := srv.AccountRepo.Authenticate(req.Username, req.Password)
 	if err != nil {
-		return nil, status.Errorf(codes.Unauthenticated, "unable to authenticate: %v", err)
+		log.Info().Err(err).Str("username", req.Username).Msg("login: authentication failed")
+
+		srv.throttler.fail(userKey, srv.loginMaxAttempts())
+		srv.throttler.fail(ipKey, srv.loginMaxAttemptsPerIP())
+
+		err = status.Error(codes.Unauthenticated, "invalid credentials")
+		srv.recordAuditEvent(ctx, req.Username, manager.AuditActionLogin, req.Username, err)
+		return nil, err
 	}
 
+	srv.throttler.reset(userKey)
+	srv.recordAuditEvent(ctx, req.Username, manager.AuditActionLogin, req.Username, nil)
+
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{
 		Issuer:    "backr-manager",
 		ExpiresAt: time.Now().Add(7 * 24 * time.Hour).Unix(),
@@ -260,15 +473,18 @@ func (srv *server) AuthenticateAccount(ctx context.Context, req *proto.Authentic
 	return &proto.AuthenticateAccountResponse{Token: tokenString}, nil
 }
 
-func (srv *server) ChangeAccountPassword(ctx context.Context, req *proto.ChangeAccountPasswordRequest) (*proto.AccountResponse, error) {
-	err := srv.authenticateRequest(ctx)
+func (srv *server) ChangeAccountPassword(ctx context.Context, req *proto.ChangeAccountPasswordRequest) (_ *proto.AccountResponse, err error) {
+	id, err := srv.authenticateRequest(ctx, manager.RoleAdmin)
 	if err != nil {
 		return nil, err
 	}
+	defer func() {
+		srv.recordAuditEvent(ctx, id.Username, manager.AuditActionAccountChangePassword, req.Username, err)
+	}()
 
 	password, err := srv.AccountRepo.ChangePassword(req.Username)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "unable to update password: %v", err)
+		return nil, repositoryError(err, "unable to update password")
 	}
 
 	return &proto.AccountResponse{
@@ -278,10 +494,34 @@ func (srv *server) ChangeAccountPassword(ctx context.Context, req *proto.ChangeA
 
 }
 
+func (srv *server) GetAuthConfig(ctx context.Context, req *proto.GetAuthConfigRequest) (*proto.AuthConfigResponse, error) {
+	// no authentication: the config is required to authenticate
+	return &proto.AuthConfigResponse{
+		OidcIssuer:   srv.Config.OIDC.Issuer,
+		OidcClientId: srv.Config.OIDC.ClientID,
+	}, nil
+}
+
+func (srv *server) loginMaxAttempts() int {
+	if srv.Config.LoginMaxAttempts > 0 {
+		return srv.Config.LoginMaxAttempts
+	}
+	return defaultLoginMaxAttempts
+}
+
+func (srv *server) loginMaxAttemptsPerIP() int {
+	if srv.Config.LoginMaxAttemptsPerIP > 0 {
+		return srv.Config.LoginMaxAttemptsPerIP
+	}
+	return defaultLoginMaxAttemptsPerIP
+}
+
 func transformToProtoProject(project manager.Project) proto.Project {
+	now := time.Now()
+
 	rules := []*proto.Rule{}
 	for _, r := range project.Rules {
-		rule := proto.Rule{MinAge: int32(r.MinAge), Count: int32(r.Count)}
+		rule := transformToProtoRule(r)
 
 		if state, ok := project.State[r.GetID()]; ok {
 			rule.Error = transformToProtoError(state.Error)
@@ -293,6 +533,9 @@ func transformToProtoProject(project manager.Project) proto.Project {
 			for _, f := range state.Files {
 				file := proto.File{Path: f.Path, Date: f.Date.Unix(), Size: f.Size, Expiration: f.Expiration.Unix()}
 				file.Error = transformToProtoError(f.Error)
+				if pin := project.GetPin(f.Path, now); pin != nil {
+					file.Pin = transformToProtoPin(*pin)
+				}
 				files = append(files, &file)
 			}
 			rule.Files = files
@@ -302,15 +545,156 @@ func transformToProtoProject(project manager.Project) proto.Project {
 	}
 
 	p := proto.Project{
-		Name:        project.Name,
-		Rules:       rules,
-		CreatedAt:   project.CreatedAt.UTC().Unix(),
-		IssuesCount: 0,
+		Name:           project.Name,
+		Rules:          rules,
+		CreatedAt:      project.CreatedAt.UTC().Unix(),
+		IssuesCount:    0,
+		DeletionPlan:   transformToProtoDeletionPlan(project.DeletionPlan),
+		Status:         transformToProtoProjectStatus(project, now),
+		UploadInterval: int64(project.UploadInterval / time.Second),
+		Caps: &proto.ProjectCaps{
+			MaxFiles:   int32(project.Caps.MaxFiles),
+			MaxBytes:   project.Caps.MaxBytes,
+			MaxAge:     int64(project.Caps.MaxAge / time.Second),
+			KeepNewest: int32(project.Caps.KeepNewest),
+		},
+		CapConflicts: project.CapConflicts,
+		DateSource: &proto.DateSource{
+			Type:    string(project.DateSource.Type),
+			Pattern: project.DateSource.Pattern,
+			Header:  project.DateSource.Header,
+			Layout:  project.DateSource.Layout,
+		},
+	}
+	if project.DateSource.IsLastModified() {
+		p.DateSource.Type = string(manager.DateSourceLastModified)
+	}
+
+	for _, pin := range project.Pins {
+		p.Pins = append(p.Pins, transformToProtoPin(pin))
 	}
 
 	return p
 }
 
+// transformFromProtoRules returns the rules. The min age is read from 'min_age_seconds' if set, from 'min_age' (in days) otherwise.
+// The rules are not validated: see lintRules.
+func transformFromProtoRules(protoRules []*proto.Rule) []manager.Rule {
+	rules := []manager.Rule{}
+	for _, r := range protoRules {
+		minAge := time.Duration(r.MinAge) * manager.Day
+		if r.MinAgeSeconds != 0 {
+			minAge = time.Duration(r.MinAgeSeconds) * time.Second
+		}
+		rule := manager.Rule{MinAge: minAge, Count: int(r.Count), Tolerance: time.Duration(r.Tolerance) * time.Second}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// transformToProtoRule returns the spec of the rule, without its state
+func transformToProtoRule(rule manager.Rule) proto.Rule {
+	return proto.Rule{
+		MinAge:        int32(rule.MinAge / manager.Day),
+		Count:         int32(rule.Count),
+		MinAgeSeconds: int64(rule.MinAge / time.Second),
+		Tolerance:     int64(rule.Tolerance / time.Second),
+	}
+}
+
+// lintRules checks the rules and the caps, and returns an InvalidArgument error if the rules are rejected
+func lintRules(rules []manager.Rule, uploadInterval time.Duration, caps manager.Caps) (*proto.RulesReport, error) {
+	report, err := manager.LintRules(rules, uploadInterval)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	report.Warnings = append(report.Warnings, manager.LintCaps(caps, report)...)
+
+	r := proto.RulesReport{
+		Warnings:          []*proto.RuleWarning{},
+		MaxRetention:      int64(report.MaxRetention / time.Second),
+		ExpectedFileCount: int32(report.ExpectedFileCount),
+	}
+	for _, w := range report.Warnings {
+		warning := proto.RuleWarning{Type: string(w.Type), Message: w.Message}
+		if w.Rule != (manager.Rule{}) {
+			rule := transformToProtoRule(w.Rule)
+			warning.Rule = &rule
+		}
+		r.Warnings = append(r.Warnings, &warning)
+	}
+
+	return &r, nil
+}
+
+// transformFromProtoCaps returns the caps (none if not set), and an InvalidArgument error if a cap is negative
+func transformFromProtoCaps(protoCaps *proto.ProjectCaps) (manager.Caps, error) {
+	if protoCaps == nil {
+		return manager.Caps{}, nil
+	}
+	if protoCaps.MaxFiles < 0 || protoCaps.MaxBytes < 0 || protoCaps.MaxAge < 0 || protoCaps.KeepNewest < 0 {
+		return manager.Caps{}, status.Error(codes.InvalidArgument, "the caps must not be negative")
+	}
+
+	return manager.Caps{
+		MaxFiles:   int(protoCaps.MaxFiles),
+		MaxBytes:   protoCaps.MaxBytes,
+		MaxAge:     time.Duration(protoCaps.MaxAge) * time.Second,
+		KeepNewest: int(protoCaps.KeepNewest),
+	}, nil
+}
+
+// transformFromProtoDateSource returns the date source (last modification date if not set),
+// and an InvalidArgument error if it's invalid
+func transformFromProtoDateSource(protoSource *proto.DateSource) (manager.DateSource, error) {
+	if protoSource == nil {
+		return manager.DateSource{}, nil
+	}
+
+	source := manager.DateSource{
+		Type:    manager.DateSourceType(protoSource.Type),
+		Pattern: protoSource.Pattern,
+		Header:  protoSource.Header,
+		Layout:  protoSource.Layout,
+	}
+	err := source.Validate()
+	if err != nil {
+		return manager.DateSource{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if source.IsLastModified() {
+		return manager.DateSource{}, nil
+	}
+
+	return source, nil
+}
+
+// newDateReader returns the reader of the dates of the files of a project,
+// or the reader of the last modification dates if the date source is invalid
+func (srv *server) newDateReader(source manager.DateSource) *manager.DateReader {
+	reader, err := source.Reader(srv.FileRepo)
+	if err != nil {
+		log.Warn().Err(err).Str("date_source", source.String()).Msg("invalid date source, the last modification dates are used")
+		reader, _ = manager.DateSource{}.Reader(srv.FileRepo)
+	}
+	return reader
+}
+
+// transformToProtoFileWithDate returns the file with the date read by the reader,
+// the last modification date being returned too if they differ
+func transformToProtoFileWithDate(file manager.File, reader *manager.DateReader) proto.File {
+	date, err := reader.FileDate(file)
+	if err != nil {
+		log.Warn().Err(err).Str("path", file.Path).Msg("unable to read the date of the file")
+	}
+
+	f := transformToProtoFile(file)
+	if !date.Equal(file.Date) {
+		f.Date = date.Unix()
+		f.LastModified = file.Date.Unix()
+	}
+	return f
+}
+
 func transformToProtoFile(file manager.File) proto.File {
 	f := proto.File{
 		Path: file.Path,
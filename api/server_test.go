@@ -0,0 +1,131 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/proto"
+	"github.com/agence-webup/backr/manager/repositories/inmem"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestInvalidRulesAreRejected(t *testing.T) {
+	srv, cleanup := newTestServer(t)
+	defer cleanup()
+
+	ctx := contextForAccount(t, srv, "admin", manager.RoleAdmin, nil)
+
+	tests := []struct {
+		name  string
+		rules []*proto.Rule
+		code  codes.Code
+	}{
+		{"zero count", []*proto.Rule{{Count: 0, MinAge: 1}}, codes.InvalidArgument},
+		{"negative count", []*proto.Rule{{Count: -1, MinAge: 1}}, codes.InvalidArgument},
+		{"no min age", []*proto.Rule{{Count: 3}}, codes.InvalidArgument},
+		{"negative min age", []*proto.Rule{{Count: 3, MinAgeSeconds: -3600}}, codes.InvalidArgument},
+		{"valid", []*proto.Rule{{Count: 3, MinAge: 1}}, codes.OK},
+	}
+
+	for _, test := range tests {
+		_, err := srv.CreateProject(ctx, &proto.CreateProjectRequest{Name: "new-" + test.name, Rules: test.rules})
+		if status.Code(err) != test.code {
+			t.Errorf("create %v: expected code %v, got %v", test.name, test.code, err)
+		}
+
+		_, err = srv.UpdateProject(ctx, &proto.UpdateProjectRequest{Name: "project1", Rules: test.rules})
+		if status.Code(err) != test.code {
+			t.Errorf("update %v: expected code %v, got %v", test.name, test.code, err)
+		}
+
+		_, err = srv.SimulateRules(ctx, &proto.SimulateRulesRequest{Rules: test.rules, Days: 10, Schedule: &proto.UploadSchedule{Interval: 86400}})
+		if status.Code(err) != test.code {
+			t.Errorf("simulate %v: expected code %v, got %v", test.name, test.code, err)
+		}
+	}
+
+	project, _ := srv.ProjectRepo.GetByName("project1")
+	if len(project.Rules) != 1 || project.Rules[0].Count != 3 {
+		t.Errorf("expected only the valid rules to be saved, got %v", project.Rules)
+	}
+}
+
+func TestSimulationUploadsAreBounded(t *testing.T) {
+	srv, cleanup := newTestServer(t)
+	defer cleanup()
+
+	ctx := contextForAccount(t, srv, "reader", manager.RoleReader, nil)
+	rules := []*proto.Rule{{Count: 3, MinAge: 1}}
+
+	_, err := srv.SimulateRules(ctx, &proto.SimulateRulesRequest{Rules: rules, Days: 1095, Schedule: &proto.UploadSchedule{Interval: 1}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected a too short interval to be rejected, got %v", err)
+	}
+
+	_, err = srv.SimulateRules(ctx, &proto.SimulateRulesRequest{Rules: []*proto.Rule{{Count: 24, MinAgeSeconds: 3600}}, Days: 1095, Schedule: &proto.UploadSchedule{Interval: 3600}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected a too large simulation to be rejected, got %v", err)
+	}
+
+	_, err = srv.SimulateRules(ctx, &proto.SimulateRulesRequest{Rules: rules, Days: 10, Schedule: &proto.UploadSchedule{Interval: 3600}})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestUpdateProjectKeepsPins(t *testing.T) {
+	srv, cleanup := newTestServer(t)
+	defer cleanup()
+
+	inmem.CreateFakeFile(srv.FileRepo, manager.File{Path: "project1/file1.tar.gz"})
+	ctx := contextForAccount(t, srv, "admin", manager.RoleAdmin, nil)
+
+	_, err := srv.PinFile(ctx, &proto.PinFileRequest{Filepath: "project1/file1.tar.gz", Reason: "incident #42"})
+	if err != nil {
+		t.Fatalf("unable to pin file: %v", err)
+	}
+
+	resp, err := srv.UpdateProject(ctx, &proto.UpdateProjectRequest{Name: "project1", Rules: []*proto.Rule{{Count: 3, MinAge: 1}}})
+	if err != nil {
+		t.Fatalf("unable to update project: %v", err)
+	}
+	if len(resp.Project.Rules) != 1 {
+		t.Errorf("expected the rules to be updated, got %v", resp.Project.Rules)
+	}
+
+	project, _ := srv.ProjectRepo.GetByName("project1")
+	if len(project.Rules) != 1 || len(project.Pins) != 1 || project.Pins[0].Path != "project1/file1.tar.gz" {
+		t.Errorf("expected the pin to be kept, got %+v", project)
+	}
+}
+
+// unreachableFileRepository fails to list the files
+type unreachableFileRepository struct {
+	manager.FileRepository
+}
+
+func (repo unreachableFileRepository) GetAll() ([]manager.File, error) {
+	return nil, manager.NewStorageError("list S3 objects", errors.New("connection refused"))
+}
+
+func (repo unreachableFileRepository) GetAllByFolder() (manager.FilesByFolder, error) {
+	_, err := repo.GetAll()
+	return nil, err
+}
+
+func TestGetFilesReportsUnreachableStorage(t *testing.T) {
+	srv, cleanup := newTestServer(t)
+	defer cleanup()
+	srv.FileRepo = unreachableFileRepository{FileRepository: srv.FileRepo}
+
+	ctx := contextForAccount(t, srv, "reader", manager.RoleReader, nil)
+
+	for _, projectName := range []string{"", "project1"} {
+		_, err := srv.GetFiles(ctx, &proto.GetFilesRequest{ProjectName: projectName})
+		if status.Code(err) != codes.Unavailable {
+			t.Errorf("expected the storage failure to be reported as unavailable (project '%v'), got %v", projectName, err)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/proto"
+	"github.com/agence-webup/backr/manager/simulation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (srv *server) SimulateRules(ctx context.Context, req *proto.SimulateRulesRequest) (*proto.SimulateRulesResponse, error) {
+	id, err := srv.authenticateRequest(ctx, manager.RoleAdmin, manager.RoleReader)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Rules) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "'rules' is required and must not be empty")
+	}
+	if req.Days <= 0 || req.Days > simulation.MaxDays {
+		return nil, status.Errorf(codes.InvalidArgument, "'days' must be between 1 and %d", simulation.MaxDays)
+	}
+	if req.ProjectName == "" && req.Schedule == nil {
+		return nil, status.Error(codes.InvalidArgument, "a project or an upload schedule is required")
+	}
+
+	rules := transformFromProtoRules(req.Rules)
+	_, err = manager.LintRules(rules, 0)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	scenario := simulation.Scenario{
+		ProjectName: "simulation",
+		Rules:       rules,
+		Start:       time.Now().UTC(),
+		Days:        int(req.Days),
+	}
+
+	// the simulation starts with the current files of the project
+	if req.ProjectName != "" {
+		err = checkProjectAccess(id, req.ProjectName)
+		if err != nil {
+			return nil, err
+		}
+
+		project, err := srv.ProjectRepo.GetByName(req.ProjectName)
+		if err != nil {
+			return nil, repositoryError(err, "unable to fetch project")
+		}
+		filesByFolder, err := srv.FileRepo.GetAllByFolder()
+		if err != nil {
+			return nil, repositoryError(err, "unable to fetch files")
+		}
+		scenario.ProjectName = req.ProjectName
+		scenario.Files = filesByFolder[req.ProjectName]
+
+		// the settings of the project are simulated too. The dates of the current files are read once:
+		// the in-memory files of the simulation have no metadata.
+		if project != nil {
+			scenario.Caps = project.Caps
+			scenario.DateSource = project.DateSource
+			scenario.Files, _ = project.DateSource.ResolveFileDates(scenario.Files, srv.FileRepo)
+		}
+	}
+
+	if schedule := req.Schedule; schedule != nil {
+		if schedule.Interval < 0 || schedule.Size < 0 || schedule.ShrinkRatio < 0 {
+			return nil, status.Error(codes.InvalidArgument, "the schedule must not contain negative values")
+		}
+		// the files are generated in memory
+		if schedule.Interval != 0 && time.Duration(schedule.Interval)*time.Second < simulation.MinInterval {
+			return nil, status.Errorf(codes.InvalidArgument, "'interval' must be at least %v", simulation.MinInterval)
+		}
+		scenario.Schedule = &simulation.Schedule{
+			Interval:    time.Duration(schedule.Interval) * time.Second,
+			Size:        schedule.Size,
+			ShrinkFrom:  int(schedule.ShrinkFrom),
+			ShrinkRatio: schedule.ShrinkRatio,
+		}
+		for _, gap := range schedule.Gaps {
+			scenario.Schedule.Gaps = append(scenario.Schedule.Gaps, simulation.DayRange{From: int(gap.From), To: int(gap.To)})
+		}
+	}
+
+	result, err := simulation.Run(scenario)
+	if errors.Is(err, simulation.ErrTooLarge) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to simulate the rules: %v", err)
+	}
+
+	resp := proto.SimulateRulesResponse{}
+	for _, day := range result.Days {
+		d := proto.SimulatedDay{
+			Date:      day.Date.Unix(),
+			Uploaded:  transformToProtoFiles(day.Uploaded),
+			Removed:   transformToProtoFiles(day.Removed),
+			FileCount: int32(day.FileCount),
+			TotalSize: day.TotalSize,
+			Alerts:    day.Alerts,
+		}
+		if len(day.Alerts) > 0 {
+			d.AlertLevel = day.Level.String()
+		}
+		resp.Days = append(resp.Days, &d)
+	}
+	resp.Survivors = transformToProtoFiles(result.Survivors)
+
+	return &resp, nil
+}
+
+func transformToProtoFiles(files []manager.File) []*proto.File {
+	protoFiles := []*proto.File{}
+	for _, f := range files {
+		file := transformToProtoFile(f)
+		protoFiles = append(protoFiles, &file)
+	}
+	return protoFiles
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (srv *server) SetProjectStatus(ctx context.Context, req *proto.SetProjectStatusRequest) (_ *proto.ProjectResponse, err error) {
+	id, err := srv.authenticateRequest(ctx, manager.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		srv.recordAuditEvent(ctx, id.Username, manager.AuditActionProjectSetStatus, req.ProjectName+" "+req.Status, err)
+	}()
+
+	projectStatus := manager.ProjectStatus(req.Status)
+	if !projectStatus.IsValid() {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown status '%v'", req.Status)
+	}
+
+	now := time.Now()
+	var pause *manager.Pause
+	if projectStatus != manager.ProjectStatusActive {
+		if req.Reason == "" {
+			return nil, status.Error(codes.InvalidArgument, "'reason' is required to pause a project")
+		}
+		pause = &manager.Pause{
+			Status:   projectStatus,
+			Reason:   req.Reason,
+			Author:   id.Username,
+			PausedAt: now,
+		}
+		if req.ResumeAt != 0 {
+			pause.ResumeAt = time.Unix(req.ResumeAt, 0)
+			if !pause.ResumeAt.After(now) {
+				return nil, status.Error(codes.InvalidArgument, "'resume_at' must be in the future")
+			}
+		}
+	}
+
+	err = checkProjectAccess(id, req.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	// the project is updated atomically, not to be overwritten by the state saved by a running process
+	var project manager.Project
+	err = srv.ProjectRepo.Update(req.ProjectName, func(stored *manager.Project) error {
+		stored.Pause = pause
+		project = *stored
+		return nil
+	})
+	if err != nil {
+		return nil, repositoryError(err, "unable to save project")
+	}
+
+	message := string(projectStatus)
+	if pause != nil {
+		message += ": " + pause.Reason
+	}
+	srv.publish(manager.Event{Type: manager.EventProjectStatusChanged, ProjectName: project.Name, Message: message})
+
+	p := transformToProtoProject(project)
+
+	return &proto.ProjectResponse{Project: &p}, nil
+}
+
+func transformToProtoProjectStatus(project manager.Project, date time.Time) *proto.ProjectStatus {
+	s := proto.ProjectStatus{Status: string(project.GetStatus(date))}
+	if s.Status == string(manager.ProjectStatusActive) {
+		return &s
+	}
+
+	s.Reason = project.Pause.Reason
+	s.Author = project.Pause.Author
+	s.PausedAt = project.Pause.PausedAt.Unix()
+	if !project.Pause.ResumeAt.IsZero() {
+		s.ResumeAt = project.Pause.ResumeAt.Unix()
+	}
+
+	return &s
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	defaultLoginMaxAttempts      = 5
+	defaultLoginMaxAttemptsPerIP = 20
+	defaultLoginAttemptsWindow   = 15 * time.Minute
+	defaultLoginLockoutDuration  = 15 * time.Minute
+)
+
+// loginThrottler tracks failed login attempts and locks a key (username or IP)
+// for a while when too many attempts failed during the window
+type loginThrottler struct {
+	window   time.Duration
+	lockout  time.Duration
+	attempts map[string]*loginAttempts
+	mutex    sync.Mutex
+
+	// now is used to get the current time, allowing to change it in tests
+	now func() time.Time
+}
+
+type loginAttempts struct {
+	failures    int
+	firstFailed time.Time
+	lockedUntil time.Time
+}
+
+func newLoginThrottler(window time.Duration, lockout time.Duration) *loginThrottler {
+	if window <= 0 {
+		window = defaultLoginAttemptsWindow
+	}
+	if lockout <= 0 {
+		lockout = defaultLoginLockoutDuration
+	}
+
+	return &loginThrottler{
+		window:   window,
+		lockout:  lockout,
+		attempts: map[string]*loginAttempts{},
+		now:      time.Now,
+	}
+}
+
+// isLocked returns true if the key is locked, with the remaining lockout duration
+func (t *loginThrottler) isLocked(key string) (bool, time.Duration) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	a, ok := t.attempts[key]
+	if !ok {
+		return false, 0
+	}
+
+	now := t.now()
+	if now.Before(a.lockedUntil) {
+		return true, a.lockedUntil.Sub(now)
+	}
+
+	return false, 0
+}
+
+// fail records a failed attempt for the key, locking it when maxAttempts is reached
+func (t *loginThrottler) fail(key string, maxAttempts int) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	now := t.now()
+
+	a, ok := t.attempts[key]
+	if !ok || now.Sub(a.firstFailed) > t.window {
+		// start a new window
+		a = &loginAttempts{firstFailed: now}
+		t.attempts[key] = a
+	}
+
+	a.failures++
+	if a.failures >= maxAttempts {
+		a.lockedUntil = now.Add(t.lockout)
+		// the next window starts after the lockout
+		a.failures = 0
+		a.firstFailed = a.lockedUntil
+	}
+
+	t.cleanup(now)
+}
+
+// reset forgets the failed attempts of the key
+func (t *loginThrottler) reset(key string) {
+	t.mutex.Lock()
+	delete(t.attempts, key)
+	t.mutex.Unlock()
+}
+
+// cleanup removes the expired entries, to avoid growing the map indefinitely
+func (t *loginThrottler) cleanup(now time.Time) {
+	for key, a := range t.attempts {
+		if now.After(a.lockedUntil) && now.Sub(a.firstFailed) > t.window {
+			delete(t.attempts, key)
+		}
+	}
+}
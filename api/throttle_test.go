@@ -0,0 +1,52 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoginThrottler(t *testing.T) {
+	now := time.Date(2019, 03, 26, 8, 0, 0, 0, time.UTC)
+	throttler := newLoginThrottler(10*time.Minute, 15*time.Minute)
+	throttler.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		throttler.fail("user:john", 3)
+	}
+	if locked, _ := throttler.isLocked("user:john"); locked {
+		t.Fatal("key should not be locked before reaching max attempts")
+	}
+
+	throttler.fail("user:john", 3)
+	locked, remaining := throttler.isLocked("user:john")
+	if !locked {
+		t.Fatal("key should be locked when reaching max attempts")
+	}
+	if remaining != 15*time.Minute {
+		t.Errorf("wrong remaining lockout duration: expected=%v got=%v", 15*time.Minute, remaining)
+	}
+	if locked, _ := throttler.isLocked("user:jane"); locked {
+		t.Fatal("other keys should not be locked")
+	}
+
+	now = now.Add(16 * time.Minute)
+	if locked, _ := throttler.isLocked("user:john"); locked {
+		t.Fatal("key should be unlocked after the lockout duration")
+	}
+
+	// failures outside of the window are forgotten
+	throttler.fail("user:jane", 3)
+	throttler.fail("user:jane", 3)
+	now = now.Add(11 * time.Minute)
+	throttler.fail("user:jane", 3)
+	if locked, _ := throttler.isLocked("user:jane"); locked {
+		t.Fatal("failures outside of the window should not lock the key")
+	}
+
+	throttler.fail("user:jane", 3)
+	throttler.reset("user:jane")
+	throttler.fail("user:jane", 3)
+	if locked, _ := throttler.isLocked("user:jane"); locked {
+		t.Fatal("reset should forget previous failures")
+	}
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (srv *server) ListTrash(ctx context.Context, req *proto.ListTrashRequest) (*proto.ListTrashResponse, error) {
+	id, err := srv.authenticateRequest(ctx, manager.RoleAdmin, manager.RoleReader)
+	if err != nil {
+		return nil, err
+	}
+	if srv.Trash == nil {
+		return nil, status.Error(codes.FailedPrecondition, "the trash is not enabled")
+	}
+
+	if req.ProjectName != "" {
+		err = checkProjectAccess(id, req.ProjectName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	trashedFiles, err := srv.Trash.List()
+	if err != nil {
+		return nil, repositoryError(err, "unable to fetch the trash")
+	}
+
+	files := []*proto.TrashedFile{}
+	for _, f := range trashedFiles {
+		folder, err := srv.FileRepo.GetFolderForFile(f.File)
+		if err != nil || !id.allowsProject(folder) {
+			continue
+		}
+		if req.ProjectName != "" && folder != req.ProjectName {
+			continue
+		}
+
+		file := transformToProtoTrashedFile(f)
+		files = append(files, &file)
+	}
+
+	return &proto.ListTrashResponse{Files: files}, nil
+}
+
+func (srv *server) RestoreFile(ctx context.Context, req *proto.RestoreFileRequest) (_ *proto.RestoreFileResponse, err error) {
+	id, err := srv.authenticateRequest(ctx, manager.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { srv.recordAuditEvent(ctx, id.Username, manager.AuditActionFileRestore, req.Filepath, err) }()
+
+	if srv.Trash == nil {
+		return nil, status.Error(codes.FailedPrecondition, "the trash is not enabled")
+	}
+
+	projectName, err := srv.checkTrashedFileAccess(id, req.Filepath)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := srv.Trash.Restore(req.Filepath)
+	if err != nil {
+		return nil, repositoryError(err, "unable to restore the file")
+	}
+	if file == nil {
+		return nil, status.Errorf(codes.NotFound, "the file '%v' is not in the trash", req.Filepath)
+	}
+	srv.publish(manager.Event{Type: manager.EventFileRestored, ProjectName: projectName, FilePath: file.Path})
+
+	// the restored file is pinned, otherwise the next process would remove it again.
+	// The project is updated atomically, not to overwrite the state saved by a running process.
+	pin := manager.Pin{
+		Path:      file.Path,
+		Reason:    "restored from the trash",
+		Author:    id.Username,
+		CreatedAt: time.Now(),
+	}
+	err = srv.ProjectRepo.Update(projectName, func(project *manager.Project) error {
+		project.AddPin(pin)
+		return nil
+	})
+	if err != nil && !errors.Is(err, manager.ErrNotFound) {
+		return nil, repositoryError(err, "unable to pin the restored file")
+	}
+	if err == nil {
+		srv.publish(manager.Event{Type: manager.EventFilePinned, ProjectName: projectName, FilePath: pin.Path, Message: pin.Reason})
+	}
+
+	protoFile := transformToProtoFile(*file)
+
+	return &proto.RestoreFileResponse{File: &protoFile}, nil
+}
+
+func (srv *server) PurgeTrash(ctx context.Context, req *proto.PurgeTrashRequest) (_ *proto.PurgeTrashResponse, err error) {
+	id, err := srv.authenticateRequest(ctx, manager.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	target := req.Filepath
+	if target == "" {
+		target = "expired files"
+	}
+	defer func() { srv.recordAuditEvent(ctx, id.Username, manager.AuditActionFilePurge, target, err) }()
+
+	if srv.Trash == nil {
+		return nil, status.Error(codes.FailedPrecondition, "the trash is not enabled")
+	}
+
+	purged := []manager.TrashedFile{}
+	if req.Filepath != "" {
+		_, err = srv.checkTrashedFileAccess(id, req.Filepath)
+		if err != nil {
+			return nil, err
+		}
+
+		file, err := srv.Trash.Purge(req.Filepath)
+		if err != nil {
+			return nil, repositoryError(err, "unable to purge the file")
+		}
+		if file == nil {
+			return nil, status.Errorf(codes.NotFound, "the file '%v' is not in the trash", req.Filepath)
+		}
+		purged = append(purged, *file)
+		srv.publishPurgedFiles(purged)
+	} else {
+		purged, err = srv.Trash.PurgeExpired(time.Now())
+		srv.publishPurgedFiles(purged)
+		if err != nil {
+			return nil, repositoryError(err, "unable to purge the trash")
+		}
+	}
+
+	paths := []string{}
+	for _, f := range purged {
+		paths = append(paths, f.File.Path)
+	}
+
+	return &proto.PurgeTrashResponse{Filepaths: paths}, nil
+}
+
+// checkTrashedFileAccess ensures that the path is valid, and that the identity is allowed to access its project.
+// It returns the name of the project.
+func (srv *server) checkTrashedFileAccess(id identity, path string) (string, error) {
+	if path == "" {
+		return "", status.Error(codes.InvalidArgument, "'filepath' is required")
+	}
+
+	folder, err := srv.FileRepo.GetFolderForFile(manager.File{Path: path})
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "invalid filepath: %v", err)
+	}
+
+	return folder, checkProjectAccess(id, folder)
+}
+
+func (srv *server) publishPurgedFiles(files []manager.TrashedFile) {
+	for _, f := range files {
+		folder, _ := srv.FileRepo.GetFolderForFile(f.File)
+		srv.publish(manager.Event{Type: manager.EventFilePurged, ProjectName: folder, FilePath: f.File.Path})
+	}
+}
+
+func transformToProtoTrashedFile(file manager.TrashedFile) proto.TrashedFile {
+	f := transformToProtoFile(file.File)
+	return proto.TrashedFile{
+		File:      &f,
+		TrashedAt: file.TrashedAt.Unix(),
+		PurgeAt:   file.PurgeAt.Unix(),
+	}
+}
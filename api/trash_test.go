@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/process"
+	"github.com/agence-webup/backr/manager/proto"
+	"github.com/agence-webup/backr/manager/repositories/inmem"
+)
+
+func TestRestoredFileIsKeptByTheProcess(t *testing.T) {
+	srv, cleanup := newTestServer(t)
+	defer cleanup()
+
+	ctx := contextForAccount(t, srv, "admin", manager.RoleAdmin, nil)
+
+	refDate := time.Date(2019, 03, 25, 8, 0, 0, 0, time.UTC)
+	rule := manager.Rule{Count: 1, MinAge: manager.Day}
+	initialNext := refDate.Add(-time.Hour)
+	srv.ProjectRepo.Save(manager.Project{
+		Name:       "project1",
+		Rules:      []manager.Rule{rule},
+		State:      manager.ProjectState{rule.GetID(): manager.RuleState{Rule: rule, Next: &initialNext}},
+		DateSource: manager.DateSource{Type: manager.DateSourceFilename, Pattern: `backup-(\d{8}-\d{4})`, Layout: "20060102-1504"},
+	})
+	for i := 1; i <= 5; i++ {
+		inmem.CreateFakeFileWithContent(srv.FileRepo, manager.File{
+			Path: fmt.Sprintf("project1/backup-201903%02d-0500.tar.gz", 20+i),
+			Date: refDate.Add(-time.Hour),
+			Size: 4,
+		}, []byte("data"))
+	}
+
+	// the process moves the old files to the trash
+	fileRepo := srv.Trash.FileRepository()
+	err := process.Execute(context.Background(), refDate, srv.ProjectRepo, fileRepo, process.Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if files, _ := fileRepo.GetAll(); len(files) != 1 {
+		t.Fatalf("expected the old files to be trashed, got %v", files)
+	}
+
+	restoredPath := "project1/backup-20190322-0500.tar.gz"
+	resp, err := srv.RestoreFile(ctx, &proto.RestoreFileRequest{Filepath: restoredPath})
+	if err != nil {
+		t.Fatalf("unable to restore the file: %v", err)
+	}
+	if resp.File.Path != restoredPath {
+		t.Errorf("expected '%v' to be restored, got %v", restoredPath, resp.File)
+	}
+
+	// the restored file is pinned: the next runs keep it
+	for _, date := range []time.Time{refDate.Add(time.Hour), refDate.Add(manager.Day)} {
+		err = process.Execute(context.Background(), date, srv.ProjectRepo, fileRepo, process.Options{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if stat, _ := fileRepo.Stat(manager.File{Path: restoredPath}); stat == nil {
+			t.Fatalf("expected the restored file to be kept by the run of %v", date)
+		}
+	}
+
+	project, _ := srv.ProjectRepo.GetByName("project1")
+	if pin := project.GetPin(restoredPath, time.Now()); pin == nil {
+		t.Errorf("expected the restored file to be pinned, got %v", project.Pins)
+	}
+	if trashed, _ := srv.Trash.List(); len(trashed) != 3 {
+		t.Errorf("expected the other files to stay in the trash, got %v", trashed)
+	}
+}
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (srv *server) UploadFile(stream proto.BackrApi_UploadFileServer) (err error) {
+	ctx := stream.Context()
+
+	id, err := srv.authenticateRequest(ctx, manager.RoleAdmin, manager.RoleUploader)
+	if err != nil {
+		return err
+	}
+
+	// the first message describes the file
+	header, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "the description of the file is required")
+	}
+	if err != nil {
+		return err
+	}
+
+	file := manager.File{Path: header.ProjectName + "/" + header.Filename}
+	defer func() { srv.recordAuditEvent(ctx, id.Username, manager.AuditActionFileUpload, file.Path, err) }()
+
+	err = srv.validateUpload(id, header)
+	if err != nil {
+		return err
+	}
+
+	// the content is streamed to the repository while it is received
+	reader, writer := io.Pipe()
+	stored := make(chan error, 1)
+	go func() {
+		err := srv.FileRepo.PutFile(file, reader, header.Size)
+		reader.CloseWithError(err)
+		stored <- err
+	}()
+
+	hash := sha256.New()
+	received, err := receiveUpload(stream, header, io.MultiWriter(writer, hash))
+	if err != nil {
+		writer.CloseWithError(err)
+		if <-stored == nil {
+			// the whole content may have been stored before the error
+			srv.removeUploadedFile(file)
+		}
+		return err
+	}
+	writer.Close()
+
+	err = <-stored
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to store the file: %v", err)
+	}
+
+	checksum := hex.EncodeToString(hash.Sum(nil))
+	if header.Sha256 != "" && !strings.EqualFold(header.Sha256, checksum) {
+		srv.removeUploadedFile(file)
+		return status.Errorf(codes.DataLoss, "checksum mismatch: expected %v, got %v", header.Sha256, checksum)
+	}
+
+	file.Size = received
+	file.Date = time.Now()
+
+	log.Info().Str("username", id.Username).Str("path", file.Path).Int64("size", file.Size).Msg("file uploaded")
+	srv.publish(manager.Event{
+		Type:        manager.EventFileUploaded,
+		ProjectName: header.ProjectName,
+		FilePath:    file.Path,
+		Message:     "uploaded by " + id.Username,
+	})
+
+	protoFile := transformToProtoFile(file)
+	return stream.SendAndClose(&proto.UploadFileResponse{File: &protoFile, Sha256: checksum})
+}
+
+// validateUpload checks the description of the uploaded file
+func (srv *server) validateUpload(id identity, header *proto.UploadFileRequest) error {
+	if header.ProjectName == "" {
+		return status.Error(codes.InvalidArgument, "'project_name' is required")
+	}
+	if header.Size <= 0 {
+		return status.Error(codes.InvalidArgument, "'size' is required")
+	}
+
+	// the files are stored on 2 levels: project/filename
+	if header.Filename == "" || header.Filename == "." || header.Filename == ".." || strings.ContainsAny(header.Filename, "/\\") {
+		return status.Errorf(codes.InvalidArgument, "invalid filename '%v': it must not contain any folder", header.Filename)
+	}
+
+	err := checkProjectAccess(id, header.ProjectName)
+	if err != nil {
+		return err
+	}
+
+	project, err := srv.ProjectRepo.GetByName(header.ProjectName)
+	if err != nil {
+		return repositoryError(err, "unable to fetch project")
+	}
+	if project == nil {
+		return status.Error(codes.NotFound, "project not found")
+	}
+
+	// a backup must never be replaced by an upload
+	path := header.ProjectName + "/" + header.Filename
+	existingFile, err := srv.FileRepo.Stat(manager.File{Path: path})
+	if err != nil {
+		return repositoryError(err, "unable to fetch file")
+	}
+	if existingFile != nil {
+		return status.Errorf(codes.AlreadyExists, "the file '%v' already exists", path)
+	}
+
+	return nil
+}
+
+// receiveUpload writes the data of the stream into w, and returns the count of received bytes.
+// The data must match the size announced in the description of the file.
+func receiveUpload(stream proto.BackrApi_UploadFileServer, header *proto.UploadFileRequest, w io.Writer) (int64, error) {
+	received := int64(0)
+	data := header.Data
+	for {
+		if received+int64(len(data)) > header.Size {
+			return received, status.Errorf(codes.InvalidArgument, "the data exceeds the announced size (%d bytes)", header.Size)
+		}
+		if len(data) > 0 {
+			if _, err := w.Write(data); err != nil {
+				return received, status.Errorf(codes.Internal, "unable to store the file: %v", err)
+			}
+			received += int64(len(data))
+		}
+
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return received, err
+		}
+		data = req.Data
+	}
+
+	if received != header.Size {
+		return received, status.Errorf(codes.InvalidArgument, "incomplete upload: received %d bytes, expected %d", received, header.Size)
+	}
+
+	return received, nil
+}
+
+// removeUploadedFile removes a file whose upload has failed
+func (srv *server) removeUploadedFile(file manager.File) {
+	err := srv.FileRepo.RemoveFile(file)
+	if err != nil {
+		log.Error().Err(err).Str("path", file.Path).Msg("unable to remove the file of a failed upload")
+	}
+}
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/proto"
+	"github.com/agence-webup/backr/manager/repositories/bolt"
+	"github.com/agence-webup/backr/manager/repositories/inmem"
+	"github.com/agence-webup/backr/manager/trash"
+	"github.com/dgrijalva/jwt-go"
+	bbolt "go.etcd.io/bbolt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type fakeUploadStream struct {
+	grpc.ServerStream
+
+	ctx      context.Context
+	requests []*proto.UploadFileRequest
+	response *proto.UploadFileResponse
+}
+
+func (s *fakeUploadStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeUploadStream) Recv() (*proto.UploadFileRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+	return req, nil
+}
+
+func (s *fakeUploadStream) SendAndClose(resp *proto.UploadFileResponse) error {
+	s.response = resp
+	return nil
+}
+
+func newTestServer(t *testing.T) (*server, func()) {
+	dir, err := ioutil.TempDir("", "backr-api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := bbolt.Open(filepath.Join(dir, "test.db"), 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	projectRepo := inmem.NewProjectRepository()
+	projectRepo.Save(manager.Project{Name: "project1"})
+	projectRepo.Save(manager.Project{Name: "project2"})
+
+	// the files are created in the inmem repository by the tests, the trashed files are not hidden
+	fileRepo := inmem.NewFileRepository()
+	fileTrash := trash.New(fileRepo, bolt.NewTrashRepository(db), manager.TrashConfig{})
+
+	srv := NewServer(projectRepo, fileRepo, bolt.NewAccountRepository(db), bolt.NewAuditRepository(db), nil, bolt.NewDownloadTokenRepository(db), bolt.NewMaintenanceRepository(db), fileTrash, nil, manager.APIConfig{JWTSecret: "secret"}, "").(*server)
+
+	return srv, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+// contextForAccount creates the account and returns a context authenticated with its token
+func contextForAccount(t *testing.T, srv *server, username string, role manager.Role, projects []string) context.Context {
+	_, err := srv.AccountRepo.Create(username, role, projects)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{
+		Subject:   username,
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte(srv.Config.JWTSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func TestUploadFile(t *testing.T) {
+	srv, cleanup := newTestServer(t)
+	defer cleanup()
+
+	ctx := contextForAccount(t, srv, "host1", manager.RoleUploader, []string{"project1"})
+
+	content := []byte("some backup data")
+	hash := sha256.Sum256(content)
+	checksum := hex.EncodeToString(hash[:])
+
+	upload := func(header proto.UploadFileRequest, chunks ...[]byte) (*proto.UploadFileResponse, error) {
+		stream := &fakeUploadStream{ctx: ctx, requests: []*proto.UploadFileRequest{&header}}
+		for _, c := range chunks {
+			stream.requests = append(stream.requests, &proto.UploadFileRequest{Data: c})
+		}
+		err := srv.UploadFile(stream)
+		return stream.response, err
+	}
+
+	tests := []struct {
+		name   string
+		header proto.UploadFileRequest
+		chunks [][]byte
+		code   codes.Code
+	}{
+		{"project not allowed", proto.UploadFileRequest{ProjectName: "project2", Filename: "a.tar.gz", Size: 16}, [][]byte{content}, codes.PermissionDenied},
+		{"filename with folder", proto.UploadFileRequest{ProjectName: "project1", Filename: "../a.tar.gz", Size: 16}, [][]byte{content}, codes.InvalidArgument},
+		{"too much data", proto.UploadFileRequest{ProjectName: "project1", Filename: "b.tar.gz", Size: 10}, [][]byte{content}, codes.InvalidArgument},
+		{"incomplete", proto.UploadFileRequest{ProjectName: "project1", Filename: "c.tar.gz", Size: 20}, [][]byte{content}, codes.InvalidArgument},
+		{"checksum mismatch", proto.UploadFileRequest{ProjectName: "project1", Filename: "d.tar.gz", Size: 16, Sha256: "abcd"}, [][]byte{content}, codes.DataLoss},
+		{"valid", proto.UploadFileRequest{ProjectName: "project1", Filename: "e.tar.gz", Size: 16, Sha256: checksum}, [][]byte{content[:5], content[5:]}, codes.OK},
+		{"already exists", proto.UploadFileRequest{ProjectName: "project1", Filename: "e.tar.gz", Size: 16}, [][]byte{content}, codes.AlreadyExists},
+	}
+
+	for _, test := range tests {
+		resp, err := upload(test.header, test.chunks...)
+		if status.Code(err) != test.code {
+			t.Errorf("%v: expected code %v, got %v", test.name, test.code, err)
+			continue
+		}
+		if err == nil && (resp.Sha256 != checksum || resp.File.Path != "project1/e.tar.gz" || resp.File.Size != 16) {
+			t.Errorf("%v: unexpected response: %+v", test.name, resp)
+		}
+	}
+
+	// only the valid upload is stored
+	files, _ := srv.FileRepo.GetAll()
+	if len(files) != 1 || files[0].Path != "project1/e.tar.gz" {
+		t.Fatalf("unexpected files: %+v", files)
+	}
+	reader, size, err := srv.FileRepo.Open(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, _ := ioutil.ReadAll(reader)
+	if size != 16 || string(stored) != string(content) {
+		t.Errorf("unexpected content: %q", stored)
+	}
+}
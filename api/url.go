@@ -0,0 +1,115 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/api/download"
+	"github.com/agence-webup/backr/manager/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// the presigned URLs of S3 can't be valid longer than 7 days
+const maxPresignedURLExpiry = 7 * 24 * time.Hour
+
+// used when api.url_max_expiry is not configured
+const defaultURLMaxExpiry = 24 * time.Hour
+
+func (srv *server) GetFileURL(ctx context.Context, req *proto.GetFileURLRequest) (_ *proto.GetFileURLResponse, err error) {
+	id, err := srv.authenticateRequest(ctx, manager.RoleAdmin, manager.RoleReader)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Expiry < 0 {
+		return nil, status.Error(codes.InvalidArgument, "'expiry' must be positive")
+	}
+	if strings.ContainsAny(req.DownloadFilename, "\"\\/\r\n") {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid download filename '%v'", req.DownloadFilename)
+	}
+
+	file, err := srv.resolveFile(id, req.Filepath)
+	if err != nil {
+		return nil, err
+	}
+
+	options := manager.URLOptions{
+		Expiry:   srv.urlExpiry(time.Duration(req.Expiry) * time.Second),
+		Filename: req.DownloadFilename,
+	}
+	expiresAt := time.Now().Add(options.Expiry)
+
+	if req.OneTime {
+		defer func() { srv.recordAuditEvent(ctx, id.Username, manager.AuditActionFileOneTimeURL, req.Filepath, err) }()
+
+		url, err := srv.createOneTimeURL(id, *file, options, expiresAt)
+		if err != nil {
+			return nil, err
+		}
+		return &proto.GetFileURLResponse{Url: url, ExpiresAt: expiresAt.Unix()}, nil
+	}
+
+	url, err := srv.FileRepo.GetURL(*file, options)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to get url: %v", err)
+	}
+
+	return &proto.GetFileURLResponse{Url: url.String(), ExpiresAt: expiresAt.Unix()}, nil
+}
+
+// urlExpiry returns the lifetime of a URL, capped by the configuration
+func (srv *server) urlExpiry(requested time.Duration) time.Duration {
+	maxExpiry := srv.Config.URLMaxExpiry
+	if maxExpiry <= 0 {
+		maxExpiry = defaultURLMaxExpiry
+	}
+	if maxExpiry > maxPresignedURLExpiry {
+		maxExpiry = maxPresignedURLExpiry
+	}
+
+	if requested <= 0 {
+		requested = manager.DefaultURLExpiry
+	}
+	if requested > maxExpiry {
+		return maxExpiry
+	}
+	return requested
+}
+
+// createOneTimeURL stores a download token and returns the URL of the daemon serving the file
+func (srv *server) createOneTimeURL(id identity, file manager.File, options manager.URLOptions, expiresAt time.Time) (string, error) {
+	if srv.DownloadTokenRepo == nil || (srv.Config.PublicURL == "" && srv.Config.HTTPListenPort == "") {
+		return "", status.Error(codes.FailedPrecondition, "one-time URLs require the HTTP server of the daemon (api.http_listen_port)")
+	}
+
+	random := make([]byte, 32)
+	_, err := rand.Read(random)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "unable to generate token: %v", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(random)
+
+	err = srv.DownloadTokenRepo.Create(token, manager.DownloadToken{
+		FilePath:  file.Path,
+		Filename:  options.Filename,
+		CreatedBy: id.Username,
+		CreatedAt: time.Now(),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return "", repositoryError(err, "unable to store token")
+	}
+
+	baseURL := srv.Config.PublicURL
+	if baseURL == "" {
+		baseURL = fmt.Sprintf("http://%v:%v", srv.Config.ListenIP, srv.Config.HTTPListenPort)
+	}
+
+	return strings.TrimSuffix(baseURL, "/") + download.PathPrefix + token, nil
+}
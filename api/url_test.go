@@ -0,0 +1,62 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/proto"
+	"github.com/agence-webup/backr/manager/repositories/inmem"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetFileURLShouldCheckProjectsAndFiles(t *testing.T) {
+	srv, cleanup := newTestServer(t)
+	defer cleanup()
+
+	inmem.CreateFakeFile(srv.FileRepo, manager.File{Path: "project1/file1.tar.gz"})
+	inmem.CreateFakeFile(srv.FileRepo, manager.File{Path: "project2/file1.tar.gz"})
+	inmem.CreateFakeFile(srv.FileRepo, manager.File{Path: "unknown/file1.tar.gz"})
+
+	ctx := contextForAccount(t, srv, "jane", manager.RoleReader, []string{"project1", "unknown"})
+
+	tests := []struct {
+		path string
+		code codes.Code
+	}{
+		{"project1/file1.tar.gz", codes.OK},
+		{"project1/file2.tar.gz", codes.NotFound},
+		{"project2/file1.tar.gz", codes.PermissionDenied},
+		{"unknown/file1.tar.gz", codes.NotFound},
+		{"file1.tar.gz", codes.InvalidArgument},
+		{"", codes.InvalidArgument},
+	}
+
+	for _, test := range tests {
+		resp, err := srv.GetFileURL(ctx, &proto.GetFileURLRequest{Filepath: test.path})
+		if status.Code(err) != test.code {
+			t.Errorf("%v: expected code %v, got %v", test.path, test.code, err)
+			continue
+		}
+		if err == nil && resp.Url == "" {
+			t.Errorf("%v: expected a URL", test.path)
+		}
+	}
+}
+
+func TestURLExpiryShouldBeCapped(t *testing.T) {
+	srv := &server{Config: manager.APIConfig{URLMaxExpiry: 0}}
+
+	if expiry := srv.urlExpiry(0); expiry != manager.DefaultURLExpiry {
+		t.Errorf("expected the default expiry, got %v", expiry)
+	}
+	if expiry := srv.urlExpiry(48 * time.Hour); expiry != defaultURLMaxExpiry {
+		t.Errorf("expected the expiry to be capped to %v, got %v", defaultURLMaxExpiry, expiry)
+	}
+
+	srv.Config.URLMaxExpiry = 30 * 24 * time.Hour
+	if expiry := srv.urlExpiry(srv.Config.URLMaxExpiry); expiry != maxPresignedURLExpiry {
+		t.Errorf("expected the expiry to be capped to %v, got %v", maxPresignedURLExpiry, expiry)
+	}
+}
@@ -0,0 +1,42 @@
+package web
+
+const styleCSS = `
+* { box-sizing: border-box; }
+body { margin: 0; font-family: -apple-system, "Segoe UI", Roboto, Helvetica, Arial, sans-serif; font-size: 14px; color: #222; background: #f5f6f8; }
+a { color: #2f6fde; text-decoration: none; }
+a:hover { text-decoration: underline; }
+header { display: flex; align-items: center; gap: 24px; padding: 12px 24px; background: #1f2933; }
+header a { color: #e4e7eb; }
+header .brand { font-weight: bold; font-size: 16px; color: #fff; }
+header nav { display: flex; gap: 16px; flex: 1; }
+header form { margin: 0; }
+header button { background: none; border: 1px solid #52606d; color: #e4e7eb; border-radius: 4px; padding: 4px 10px; cursor: pointer; }
+main { max-width: 1100px; margin: 24px auto; padding: 0 24px; }
+h1 { font-size: 22px; }
+h2 { font-size: 16px; margin: 0 0 4px; }
+table { width: 100%; border-collapse: collapse; background: #fff; }
+th, td { text-align: left; padding: 8px 12px; border-bottom: 1px solid #e4e7eb; vertical-align: top; }
+th { font-weight: 600; background: #fafbfc; }
+.muted { color: #7b8794; margin: 0 0 12px; }
+.alert { padding: 8px 12px; background: #ffe3e3; color: #8a1c1c; border-radius: 4px; }
+.badge { display: inline-block; padding: 1px 8px; border-radius: 10px; font-size: 12px; font-weight: normal; background: #e4e7eb; }
+.badge.ok { background: #d3f9d8; color: #2b8a3e; }
+.badge.warning { background: #fff3bf; color: #8f6400; }
+.badge.critical, .badge.critic { background: #ffe3e3; color: #c92a2a; }
+.rule { background: #fff; padding: 16px; margin-bottom: 16px; border-radius: 4px; }
+.timeline .scale { display: flex; justify-content: space-between; color: #7b8794; font-size: 12px; margin-left: 220px; margin-right: 180px; }
+.timeline .row { display: flex; align-items: center; gap: 12px; margin: 6px 0; }
+.timeline .label { width: 208px; overflow: hidden; text-overflow: ellipsis; white-space: nowrap; }
+.timeline .track { position: relative; flex: 1; height: 14px; background: #f0f2f5; border-radius: 3px; }
+.timeline .bar { position: absolute; top: 0; bottom: 0; border-radius: 3px; background: #51cf66; }
+.timeline .bar.warning { background: #fcc419; }
+.timeline .bar.critical { background: #ff6b6b; }
+.timeline .now { position: absolute; top: -3px; bottom: -3px; width: 2px; background: #1f2933; }
+.timeline .expiration { width: 168px; color: #7b8794; font-size: 12px; }
+.folders { background: #fff; padding: 12px 32px; border-radius: 4px; }
+.folders li { margin: 6px 0; }
+.login { max-width: 320px; margin: 80px auto; display: flex; flex-direction: column; gap: 12px; background: #fff; padding: 24px; border-radius: 4px; }
+.login label { display: flex; flex-direction: column; gap: 4px; }
+.login input { padding: 6px 8px; border: 1px solid #cbd2d9; border-radius: 4px; }
+.login button { padding: 8px; background: #2f6fde; color: #fff; border: none; border-radius: 4px; cursor: pointer; }
+`
@@ -0,0 +1,193 @@
+package web
+
+import (
+	"fmt"
+	"html/template"
+	"path"
+	"time"
+)
+
+var templateFuncs = template.FuncMap{
+	"date": func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return t.Local().Format("2006-01-02 15:04")
+	},
+	"unix": func(timestamp int64) time.Time {
+		if timestamp == 0 {
+			return time.Time{}
+		}
+		return time.Unix(timestamp, 0)
+	},
+	"size":     formatSize,
+	"basename": path.Base,
+	"percent": func(p float64) string {
+		return fmt.Sprintf("%.2f%%", p)
+	},
+}
+
+// parseTemplates returns the template of each page, rendered within the layout
+func parseTemplates() map[string]*template.Template {
+	layout := template.Must(template.New("layout").Funcs(templateFuncs).Parse(layoutTemplate))
+
+	pages := map[string]string{
+		"login":         loginTemplate,
+		"projects":      projectsTemplate,
+		"project":       projectTemplate,
+		"folders":       foldersTemplate,
+		"files":         filesTemplate,
+		"notifications": notificationsTemplate,
+		"error":         errorTemplate,
+	}
+
+	templates := map[string]*template.Template{}
+	for name, content := range pages {
+		templates[name] = template.Must(template.Must(layout.Clone()).Parse(content))
+	}
+
+	return templates
+}
+
+const layoutTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8">
+	<meta name="viewport" content="width=device-width, initial-scale=1">
+	<title>Backr</title>
+	<link rel="stylesheet" href="/static/style.css">
+</head>
+<body>
+	<header>
+		<a class="brand" href="/">Backr</a>
+		<nav>
+			<a href="/">Projects</a>
+			<a href="/files">Files</a>
+			<a href="/notifications">Notifications</a>
+		</nav>
+		<form method="post" action="/logout"><button type="submit">Logout</button></form>
+	</header>
+	<main>
+		{{template "content" .}}
+	</main>
+</body>
+</html>`
+
+const loginTemplate = `{{define "content"}}
+<form class="login" method="post" action="/login">
+	<h1>Login</h1>
+	{{if .Error}}<p class="alert">{{.Error}}</p>{{end}}
+	<input type="hidden" name="next" value="{{.Next}}">
+	<label>Username <input type="text" name="username" value="{{.Username}}" autofocus required></label>
+	<label>Password <input type="password" name="password" required></label>
+	<button type="submit">Login</button>
+</form>
+{{end}}`
+
+const projectsTemplate = `{{define "content"}}
+<h1>Projects</h1>
+<table>
+	<thead>
+		<tr><th>Name</th><th>Health</th><th>Issues</th><th>Rules</th><th>Created at</th></tr>
+	</thead>
+	<tbody>
+	{{range .Projects}}
+		<tr>
+			<td><a href="/projects/{{.Name}}">{{.Name}}</a></td>
+			<td><span class="badge {{.Health}}">{{.Health}}</span></td>
+			<td>{{.Issues}}</td>
+			<td>{{.Rules}}</td>
+			<td>{{date .CreatedAt}}</td>
+		</tr>
+	{{else}}
+		<tr><td colspan="5">No project</td></tr>
+	{{end}}
+	</tbody>
+</table>
+{{end}}`
+
+const projectTemplate = `{{define "content"}}
+<h1>{{.Project.Name}} <span class="badge {{.Project.Health}}">{{.Project.Health}}</span></h1>
+<p><a href="/files?project={{.Project.Name}}">Browse files</a></p>
+{{range .Rules}}
+<section class="rule">
+	<h2>{{.Count}} files, min age {{.MinAge}} {{if .Error}}<span class="badge {{.Health}}">{{.Error}}</span>{{end}}</h2>
+	<p class="muted">next backup: {{date .NextDate}}</p>
+	<div class="timeline">
+		<div class="scale"><span>{{date .Start}}</span><span>{{date .End}}</span></div>
+		{{$now := .Now}}
+		{{range .Files}}
+		<div class="row">
+			<div class="label" title="{{.Path}}">{{basename .Path}}{{if .Error}} <span class="badge {{.Health}}">{{.Error}}</span>{{end}}</div>
+			<div class="track">
+				<div class="bar {{.Health}}" style="left: {{percent .Left}}; width: {{percent .Width}}" title="{{date .Date}} → {{date .Expiration}}"></div>
+				<div class="now" style="left: {{percent $now}}"></div>
+			</div>
+			<div class="expiration">expires {{date .Expiration}}</div>
+		</div>
+		{{else}}
+		<p class="muted">No file kept yet</p>
+		{{end}}
+	</div>
+</section>
+{{end}}
+{{end}}`
+
+const foldersTemplate = `{{define "content"}}
+<h1>Files</h1>
+<ul class="folders">
+{{range .Projects}}
+	<li><a href="/files?project={{.Name}}">{{.Name}}/</a></li>
+{{else}}
+	<li>No project</li>
+{{end}}
+</ul>
+{{end}}`
+
+const filesTemplate = `{{define "content"}}
+<h1><a href="/files">Files</a> / {{.ProjectName}}</h1>
+<table>
+	<thead>
+		<tr><th>Name</th><th>Date</th><th>Size</th><th></th></tr>
+	</thead>
+	<tbody>
+	{{range .Files}}
+		<tr>
+			<td title="{{.Path}}">{{basename .Path}}</td>
+			<td>{{date (unix .Date)}}</td>
+			<td>{{size .Size}}</td>
+			<td><a href="/files/download?path={{.Path}}">Download</a></td>
+		</tr>
+	{{else}}
+		<tr><td colspan="4">No file</td></tr>
+	{{end}}
+	</tbody>
+</table>
+{{end}}`
+
+const notificationsTemplate = `{{define "content"}}
+<h1>Notifications</h1>
+<table>
+	<thead>
+		<tr><th>Sent at</th><th>Project</th><th>Level</th><th>Issues</th><th>Reasons</th></tr>
+	</thead>
+	<tbody>
+	{{range .Notifications}}
+		<tr>
+			<td>{{date (unix .SentAt)}}</td>
+			<td><a href="/projects/{{.ProjectName}}">{{.ProjectName}}</a></td>
+			<td><span class="badge {{.Level}}">{{.Level}}</span></td>
+			<td>{{.Count}}</td>
+			<td>{{range .Reasons}}<div>{{.}}</div>{{end}}</td>
+		</tr>
+	{{else}}
+		<tr><td colspan="5">No notification sent</td></tr>
+	{{end}}
+	</tbody>
+</table>
+{{end}}`
+
+const errorTemplate = `{{define "content"}}
+<h1>Error {{.Status}}</h1>
+<p class="alert">{{.Message}}</p>
+{{end}}`
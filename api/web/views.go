@@ -0,0 +1,193 @@
+package web
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/proto"
+)
+
+// health of a project, deduced from the errors of its rules
+const (
+	healthOK       = "ok"
+	healthWarning  = "warning"
+	healthCritical = "critical"
+)
+
+type projectView struct {
+	Name      string
+	CreatedAt time.Time
+	Rules     int
+	Issues    int
+	Health    string
+}
+
+func newProjectView(p *proto.Project) projectView {
+	view := projectView{
+		Name:      p.Name,
+		CreatedAt: time.Unix(p.CreatedAt, 0),
+		Rules:     len(p.Rules),
+		Health:    healthOK,
+	}
+
+	addIssue := func(err proto.Error) {
+		if err == proto.Error_NO_ERROR {
+			return
+		}
+		view.Issues++
+		if health := errorHealth(err); health == healthCritical || view.Health == healthOK {
+			view.Health = health
+		}
+	}
+
+	for _, rule := range p.Rules {
+		addIssue(rule.Error)
+		for _, f := range rule.Files {
+			addIssue(f.Error)
+		}
+	}
+
+	return view
+}
+
+// errorHealth returns the health associated to an error:
+// missing or obsolete files require an action
+func errorHealth(err proto.Error) string {
+	switch err {
+	case proto.Error_NO_ERROR:
+		return healthOK
+	case proto.Error_OBSOLETE, proto.Error_NO_FILE:
+		return healthCritical
+	}
+	return healthWarning
+}
+
+// ruleView represents the timeline of the files kept by a rule.
+// Positions in the timeline are percentages, from the date of the oldest file to the latest expiration.
+type ruleView struct {
+	Count    int32
+	MinAge   string
+	NextDate time.Time
+	Error    string
+	Health   string
+
+	Start time.Time
+	End   time.Time
+	Now   float64
+	Files []timelineFile
+}
+
+type timelineFile struct {
+	Path       string
+	Date       time.Time
+	Expiration time.Time
+	Size       int64
+	Error      string
+	Health     string
+
+	Left  float64
+	Width float64
+}
+
+// formatMinAge returns a count of days (i.e "15 days") or a duration (i.e "6h")
+func formatMinAge(rule *proto.Rule) string {
+	minAge := time.Duration(rule.MinAge) * manager.Day
+	if rule.MinAgeSeconds > 0 {
+		minAge = time.Duration(rule.MinAgeSeconds) * time.Second
+	}
+	if minAge%manager.Day == 0 {
+		return manager.FormatRuleDuration(minAge) + " days"
+	}
+	return manager.FormatRuleDuration(minAge)
+}
+
+func newRuleView(rule *proto.Rule, now time.Time) ruleView {
+	view := ruleView{
+		Count:  rule.Count,
+		MinAge: formatMinAge(rule),
+		Error:  errorLabel(rule.Error),
+		Health: errorHealth(rule.Error),
+		Files:  []timelineFile{},
+	}
+	if rule.NextDate > 0 {
+		view.NextDate = time.Unix(rule.NextDate, 0)
+	}
+
+	// the timeline includes the dates of the files, their expiration and now
+	view.Start = now
+	view.End = now
+	for _, f := range rule.Files {
+		date := time.Unix(f.Date, 0)
+		expiration := time.Unix(f.Expiration, 0)
+		if date.Before(view.Start) {
+			view.Start = date
+		}
+		if expiration.After(view.End) {
+			view.End = expiration
+		}
+	}
+	if !view.End.After(view.Start) {
+		view.End = view.Start.Add(24 * time.Hour)
+	}
+
+	view.Now = position(view.Start, view.End, now)
+	for _, f := range rule.Files {
+		date := time.Unix(f.Date, 0)
+		expiration := time.Unix(f.Expiration, 0)
+
+		left := position(view.Start, view.End, date)
+		view.Files = append(view.Files, timelineFile{
+			Path:       f.Path,
+			Date:       date,
+			Expiration: expiration,
+			Size:       f.Size,
+			Error:      errorLabel(f.Error),
+			Health:     errorHealth(f.Error),
+			Left:       left,
+			Width:      position(view.Start, view.End, expiration) - left,
+		})
+	}
+
+	return view
+}
+
+// position returns the position of the date in the [start, end] interval, as a percentage
+func position(start time.Time, end time.Time, date time.Time) float64 {
+	p := float64(date.Sub(start)) / float64(end.Sub(start)) * 100
+	if p < 0 {
+		return 0
+	}
+	if p > 100 {
+		return 100
+	}
+	return p
+}
+
+func errorLabel(err proto.Error) string {
+	switch err {
+	case proto.Error_NO_ERROR:
+		return ""
+	case proto.Error_OBSOLETE:
+		return "outdated"
+	case proto.Error_TOO_SMALL:
+		return "file is too small"
+	case proto.Error_NO_FILE:
+		return "no available file"
+	}
+	return "unknown error"
+}
+
+// formatSize returns a human readable size
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
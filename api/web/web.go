@@ -0,0 +1,282 @@
+// Package web serves a read-mostly dashboard: projects health, rules timelines,
+// files and notifications history.
+// Data is fetched through the gRPC server implementation, so permissions are the same as with the API.
+// Templates and assets are embedded in the binary.
+package web
+
+import (
+	"context"
+	"html/template"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agence-webup/backr/manager/proto"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const tokenCookieName = "backr_token"
+
+// lifetime of the tokens issued by AuthenticateAccount
+const tokenLifetime = 7 * 24 * time.Hour
+
+// NewHandler returns an HTTP handler serving the dashboard
+func NewHandler(srv proto.BackrApiServer) http.Handler {
+	d := &dashboard{
+		srv:   srv,
+		pages: parseTemplates(),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", d.handleProjects)
+	mux.HandleFunc("/projects/", d.handleProject)
+	mux.HandleFunc("/files", d.handleFiles)
+	mux.HandleFunc("/files/download", d.handleDownload)
+	mux.HandleFunc("/notifications", d.handleNotifications)
+	mux.HandleFunc("/login", d.handleLogin)
+	mux.HandleFunc("/logout", d.handleLogout)
+	mux.HandleFunc("/static/style.css", handleStyle)
+
+	return mux
+}
+
+type dashboard struct {
+	srv   proto.BackrApiServer
+	pages map[string]*template.Template
+}
+
+func (d *dashboard) handleProjects(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		d.renderError(w, http.StatusNotFound, "page not found")
+		return
+	}
+
+	resp, err := d.srv.GetProjects(d.context(r), &proto.GetProjectsRequest{})
+	if err != nil {
+		d.handleError(w, r, err)
+		return
+	}
+
+	projects := []projectView{}
+	for _, p := range resp.Projects {
+		projects = append(projects, newProjectView(p))
+	}
+
+	d.render(w, http.StatusOK, "projects", map[string]interface{}{
+		"Projects": projects,
+	})
+}
+
+func (d *dashboard) handleProject(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/projects/")
+	if name == "" || strings.Contains(name, "/") {
+		d.renderError(w, http.StatusNotFound, "page not found")
+		return
+	}
+
+	resp, err := d.srv.GetProject(d.context(r), &proto.GetProjectRequest{Name: name})
+	if err != nil {
+		d.handleError(w, r, err)
+		return
+	}
+
+	now := time.Now()
+	rules := []ruleView{}
+	for _, rule := range resp.Project.Rules {
+		rules = append(rules, newRuleView(rule, now))
+	}
+
+	d.render(w, http.StatusOK, "project", map[string]interface{}{
+		"Project": newProjectView(resp.Project),
+		"Rules":   rules,
+	})
+}
+
+func (d *dashboard) handleFiles(w http.ResponseWriter, r *http.Request) {
+	ctx := d.context(r)
+	projectName := r.URL.Query().Get("project")
+
+	// without project, list the projects as folders
+	if projectName == "" {
+		resp, err := d.srv.GetProjects(ctx, &proto.GetProjectsRequest{})
+		if err != nil {
+			d.handleError(w, r, err)
+			return
+		}
+
+		d.render(w, http.StatusOK, "folders", map[string]interface{}{
+			"Projects": resp.Projects,
+		})
+		return
+	}
+
+	resp, err := d.srv.GetFiles(ctx, &proto.GetFilesRequest{ProjectName: projectName})
+	if err != nil {
+		d.handleError(w, r, err)
+		return
+	}
+
+	files := resp.Files
+	sort.Slice(files, func(i, j int) bool { return files[i].Date > files[j].Date })
+
+	d.render(w, http.StatusOK, "files", map[string]interface{}{
+		"ProjectName": projectName,
+		"Files":       files,
+	})
+}
+
+// handleDownload redirects to a temporary URL of the file
+func (d *dashboard) handleDownload(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Query().Get("path")
+	if path == "" {
+		d.renderError(w, http.StatusBadRequest, "'path' is required")
+		return
+	}
+
+	resp, err := d.srv.GetFileURL(d.context(r), &proto.GetFileURLRequest{Filepath: path, DownloadFilename: path[strings.LastIndex(path, "/")+1:]})
+	if err != nil {
+		d.handleError(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, resp.Url, http.StatusFound)
+}
+
+func (d *dashboard) handleNotifications(w http.ResponseWriter, r *http.Request) {
+	resp, err := d.srv.ListNotifications(d.context(r), &proto.ListNotificationsRequest{Limit: 200})
+	if err != nil {
+		d.handleError(w, r, err)
+		return
+	}
+
+	d.render(w, http.StatusOK, "notifications", map[string]interface{}{
+		"Notifications": resp.Notifications,
+	})
+}
+
+func (d *dashboard) handleLogin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		d.render(w, http.StatusOK, "login", map[string]interface{}{
+			"Next": r.URL.Query().Get("next"),
+		})
+		return
+	}
+
+	req := &proto.AuthenticateAccountRequest{
+		Username: r.PostFormValue("username"),
+		Password: r.PostFormValue("password"),
+	}
+	resp, err := d.srv.AuthenticateAccount(d.context(r), req)
+	if err != nil {
+		d.render(w, http.StatusUnauthorized, "login", map[string]interface{}{
+			"Next":     r.PostFormValue("next"),
+			"Username": req.Username,
+			"Error":    status.Convert(err).Message(),
+		})
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     tokenCookieName,
+		Value:    resp.Token,
+		Path:     "/",
+		Expires:  time.Now().Add(tokenLifetime),
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteStrictMode,
+	})
+	http.Redirect(w, r, safeRedirect(r.PostFormValue("next")), http.StatusSeeOther)
+}
+
+// safeRedirect returns next if it is a path of the dashboard, to avoid open redirects
+func safeRedirect(next string) string {
+	if !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") || strings.HasPrefix(next, "/\\") {
+		return "/"
+	}
+	return next
+}
+
+func (d *dashboard) handleLogout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		d.renderError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     tokenCookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteStrictMode,
+	})
+	http.Redirect(w, r, "/login", http.StatusSeeOther)
+}
+
+func handleStyle(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/css; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.Write([]byte(styleCSS))
+}
+
+// context returns a context similar to the one of a gRPC request,
+// using the token stored in the cookie
+func (d *dashboard) context(r *http.Request) context.Context {
+	md := metadata.MD{}
+	if cookie, err := r.Cookie(tokenCookieName); err == nil && cookie.Value != "" {
+		md.Set("authorization", "Bearer "+cookie.Value)
+	}
+	ctx := metadata.NewIncomingContext(r.Context(), md)
+
+	host, port, err := net.SplitHostPort(r.RemoteAddr)
+	if err == nil {
+		p, _ := strconv.Atoi(port)
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(host), Port: p}})
+	}
+
+	return ctx
+}
+
+// handleError renders the error returned by the API, redirecting to the login page when the user is not authenticated
+func (d *dashboard) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	st := status.Convert(err)
+
+	switch st.Code() {
+	case codes.Unauthenticated:
+		http.Redirect(w, r, "/login?next="+url.QueryEscape(r.URL.RequestURI()), http.StatusSeeOther)
+	case codes.PermissionDenied:
+		d.renderError(w, http.StatusForbidden, st.Message())
+	case codes.NotFound:
+		d.renderError(w, http.StatusNotFound, st.Message())
+	case codes.InvalidArgument, codes.FailedPrecondition:
+		d.renderError(w, http.StatusBadRequest, st.Message())
+	default:
+		d.renderError(w, http.StatusInternalServerError, st.Message())
+	}
+}
+
+func (d *dashboard) renderError(w http.ResponseWriter, statusCode int, message string) {
+	d.render(w, statusCode, "error", map[string]interface{}{
+		"Status":  statusCode,
+		"Message": message,
+	})
+}
+
+func (d *dashboard) render(w http.ResponseWriter, statusCode int, page string, data map[string]interface{}) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(statusCode)
+
+	err := d.pages[page].ExecuteTemplate(w, "layout", data)
+	if err != nil {
+		log.Error().Err(err).Str("page", page).Msg("web: unable to render page")
+	}
+}
@@ -0,0 +1,125 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agence-webup/backr/manager/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type fakeServer struct {
+	proto.UnimplementedBackrApiServer
+}
+
+func (srv *fakeServer) authenticate(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if strings.Join(md["authorization"], "") != "Bearer valid" {
+		return status.Error(codes.Unauthenticated, "invalid token")
+	}
+	return nil
+}
+
+func (srv *fakeServer) AuthenticateAccount(ctx context.Context, req *proto.AuthenticateAccountRequest) (*proto.AuthenticateAccountResponse, error) {
+	if req.Password != "secret" {
+		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+	}
+	return &proto.AuthenticateAccountResponse{Token: "valid"}, nil
+}
+
+func (srv *fakeServer) GetProject(ctx context.Context, req *proto.GetProjectRequest) (*proto.ProjectResponse, error) {
+	if err := srv.authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &proto.ProjectResponse{Project: &proto.Project{
+		Name: req.Name,
+		Rules: []*proto.Rule{{
+			Count:  2,
+			MinAge: 1,
+			Files: []*proto.File{
+				{Path: "project1/backup1.tar.gz", Date: now.Add(-48 * time.Hour).Unix(), Expiration: now.Add(-1 * time.Hour).Unix(), Error: proto.Error_OBSOLETE},
+				{Path: "project1/backup2.tar.gz", Date: now.Add(-24 * time.Hour).Unix(), Expiration: now.Add(24 * time.Hour).Unix()},
+			},
+		}},
+	}}, nil
+}
+
+func TestShouldRedirectToLoginWhenNotAuthenticated(t *testing.T) {
+	handler := NewHandler(&fakeServer{})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/projects/project1", nil))
+
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("expected a redirection, got %v", w.Code)
+	}
+	if location := w.Header().Get("Location"); location != "/login?next=%2Fprojects%2Fproject1" {
+		t.Errorf("unexpected location: %v", location)
+	}
+}
+
+func TestShouldLoginAndRenderProject(t *testing.T) {
+	handler := NewHandler(&fakeServer{})
+
+	// wrong password
+	form := url.Values{"username": {"john"}, "password": {"wrong"}, "next": {"/projects/project1"}}
+	req := httptest.NewRequest("POST", "/login", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized || !strings.Contains(w.Body.String(), "invalid credentials") {
+		t.Fatalf("expected the login to fail, got %v", w.Code)
+	}
+
+	// valid password
+	form.Set("password", "secret")
+	req = httptest.NewRequest("POST", "/login", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusSeeOther || w.Header().Get("Location") != "/projects/project1" {
+		t.Fatalf("expected a redirection to the project, got %v %v", w.Code, w.Header().Get("Location"))
+	}
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Value != "valid" || !cookies[0].HttpOnly {
+		t.Fatalf("unexpected cookies: %v", cookies)
+	}
+
+	// render the project with the cookie
+	req = httptest.NewRequest("GET", "/projects/project1", nil)
+	req.AddCookie(cookies[0])
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected the project to be rendered, got %v: %v", w.Code, w.Body.String())
+	}
+	body := w.Body.String()
+	for _, expected := range []string{"backup1.tar.gz", "backup2.tar.gz", "outdated", `class="badge critical"`} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("expected the page to contain '%v'", expected)
+		}
+	}
+}
+
+func TestSafeRedirect(t *testing.T) {
+	tests := map[string]string{
+		"":                         "/",
+		"/projects/project1":       "/projects/project1",
+		"//evil.example.com":       "/",
+		"https://evil.example.com": "/",
+	}
+	for next, expected := range tests {
+		if result := safeRedirect(next); result != expected {
+			t.Errorf("safeRedirect(%q): expected %q, got %q", next, expected, result)
+		}
+	}
+}
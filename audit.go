@@ -0,0 +1,62 @@
+package manager
+
+import "time"
+
+// AuditEvent represents an entry of the audit trail:
+// logins, login failures and every mutating action done through the API
+type AuditEvent struct {
+	ID      uint64
+	Date    time.Time
+	Actor   string
+	Action  AuditAction
+	Target  string
+	IP      string
+	Success bool
+	Message string
+}
+
+// AuditAction identifies the action recorded by an AuditEvent
+type AuditAction string
+
+const (
+	// AuditActionLogin is recorded for each authentication attempt
+	AuditActionLogin AuditAction = "login"
+	// AuditActionProjectCreate is recorded when a project is created
+	AuditActionProjectCreate AuditAction = "project.create"
+	// AuditActionProjectUpdate is recorded when the rules of a project are changed
+	AuditActionProjectUpdate AuditAction = "project.update"
+	// AuditActionProjectSetStatus is recorded when a project is paused or resumed
+	AuditActionProjectSetStatus AuditAction = "project.set_status"
+	// AuditActionDeletionPlanApprove is recorded when a blocked deletion plan is approved
+	AuditActionDeletionPlanApprove AuditAction = "deletion_plan.approve"
+	// AuditActionMaintenanceSet is recorded when the maintenance mode is enabled or disabled
+	AuditActionMaintenanceSet AuditAction = "maintenance.set"
+	// AuditActionAccountCreate is recorded when an account is created
+	AuditActionAccountCreate AuditAction = "account.create"
+	// AuditActionAccountChangePassword is recorded when the password of an account is changed
+	AuditActionAccountChangePassword AuditAction = "account.change_password"
+	// AuditActionFileUpload is recorded when a file is uploaded through the API
+	AuditActionFileUpload AuditAction = "file.upload"
+	// AuditActionFileRestore is recorded when a file is restored from the trash
+	AuditActionFileRestore AuditAction = "file.restore"
+	// AuditActionFilePurge is recorded when files are permanently deleted from the trash
+	AuditActionFilePurge AuditAction = "file.purge"
+	// AuditActionFilePin is recorded when a file is pinned
+	AuditActionFilePin AuditAction = "file.pin"
+	// AuditActionFileUnpin is recorded when a file is unpinned
+	AuditActionFileUnpin AuditAction = "file.unpin"
+	// AuditActionFileOneTimeURL is recorded when a one-time download URL is issued
+	AuditActionFileOneTimeURL AuditAction = "file.one_time_url"
+	// AuditActionFileOneTimeDownload is recorded when a one-time download URL is used
+	AuditActionFileOneTimeDownload AuditAction = "file.one_time_download"
+)
+
+// AuditFilter defines criteria used to query audit events
+type AuditFilter struct {
+	// Actor restricts the events to the specified actor (all actors if empty)
+	Actor string
+	// Since restricts the events to the ones recorded after this date (no restriction if zero)
+	Since time.Time
+	// Limit is the max count of returned events (no limit if zero)
+	Limit int
+}
@@ -55,3 +55,13 @@ func generateRandomString() []byte {
 
 	return buf
 }
+
+// dummyHash is a valid hash used to spend the same time comparing
+// a password when the account does not exist
+const dummyHash = "$2a$10$U9XDMKKAjy84HXcteOScCuYyxIb7DCNLKbCWNnjnxdmONiKS9HLyK"
+
+// SimulateComparison compares the password with a dummy hash.
+// It allows to answer in a uniform time, whether an account exists or not.
+func SimulateComparison(password string) {
+	bcrypt.CompareHashAndPassword([]byte(dummyHash), []byte(password))
+}
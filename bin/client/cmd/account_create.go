@@ -30,6 +30,7 @@ import (
 	"github.com/agence-webup/backr/manager/proto"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"google.golang.org/grpc"
 )
 
 // accountCreateCmd represents the create command for accounts
@@ -44,8 +45,30 @@ var accountCreateCmd = &cobra.Command{
 			fmt.Println("unable to get 'username' flag")
 		}
 
+		setupToken, err := cmd.Flags().GetString("setup-token")
+		if err != nil {
+			fmt.Println("unable to get 'setup-token' flag")
+		}
+
+		role, err := cmd.Flags().GetString("role")
+		if err != nil {
+			fmt.Println("unable to get 'role' flag")
+		}
+
+		projects, err := cmd.Flags().GetStringSlice("project")
+		if err != nil {
+			fmt.Println("unable to get 'project' flag")
+		}
+
 		addr := viper.GetString("endpoint")
-		conn, err := grpcConnect(addr)
+		connect := grpcConnect
+		if setupToken != "" {
+			// the first account is created using the setup token displayed by the daemon
+			connect = func(addr string) (*grpc.ClientConn, error) {
+				return grpcConnectWithToken(addr, setupToken)
+			}
+		}
+		conn, err := connect(addr)
 		if err != nil {
 			fmt.Printf("unable to dial to addr: %v\n", err)
 			os.Exit(1)
@@ -57,7 +80,7 @@ var accountCreateCmd = &cobra.Command{
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
-		req := &proto.CreateAccountRequest{Username: username}
+		req := &proto.CreateAccountRequest{Username: username, Role: role, Projects: projects}
 		resp, err := client.CreateAccount(ctx, req)
 		if err != nil {
 			fmt.Printf("error: %v\n", err)
@@ -83,6 +106,9 @@ func init() {
 	// accountCreateCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 
 	accountCreateCmd.Flags().StringP("username", "u", "", "Username of the user. Should be unique.")
+	accountCreateCmd.Flags().StringP("role", "r", "admin", "Role of the user: 'admin', 'reader' or 'uploader'")
+	accountCreateCmd.Flags().StringSliceP("project", "p", []string{}, "Restrict the account to these projects (e.g. upload-only credentials of a host)")
+	accountCreateCmd.Flags().String("setup-token", "", "One-time setup token displayed by the daemon, required to create the first account")
 
 	accountCreateCmd.MarkFlagRequired("username")
 }
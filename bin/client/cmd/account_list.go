@@ -25,6 +25,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/agence-webup/backr/manager/proto"
@@ -61,7 +62,7 @@ var accountListCmd = &cobra.Command{
 		}
 
 		for _, acc := range resp.Accounts {
-			fmt.Println(acc.Username)
+			fmt.Printf("%v\t%v\t%v\n", acc.Username, acc.Role, strings.Join(acc.Projects, ","))
 		}
 	},
 }
@@ -0,0 +1,106 @@
+/*
+Copyright © 2019 Matthieu MARTIN <matthieu@agence-webup.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/agence-webup/backr/manager/proto"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// auditCmd represents the audit command
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "List the audit trail: logins, failures and changes done through the API",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		limit, err := cmd.Flags().GetInt32("limit")
+		if err != nil {
+			fmt.Println("unable to get 'limit' flag")
+			os.Exit(1)
+		}
+		actor, err := cmd.Flags().GetString("actor")
+		if err != nil {
+			fmt.Println("unable to get 'actor' flag")
+			os.Exit(1)
+		}
+		since, err := cmd.Flags().GetDuration("since")
+		if err != nil {
+			fmt.Println("unable to get 'since' flag")
+			os.Exit(1)
+		}
+
+		addr := viper.GetString("endpoint")
+		conn, err := grpcConnect(addr)
+		if err != nil {
+			fmt.Printf("unable to dial to addr: %v\n", err)
+			os.Exit(1)
+		}
+		defer conn.Close()
+
+		client := proto.NewBackrApiClient(conn)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		req := &proto.ListAuditEventsRequest{Actor: actor, Limit: limit}
+		if since > 0 {
+			req.Since = time.Now().Add(-since).Unix()
+		}
+		resp, err := client.ListAuditEvents(ctx, req)
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if len(resp.Events) == 0 {
+			fmt.Println("empty list")
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 1, 1, 3, ' ', 0)
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t\n", "DATE", "ACTOR", "ACTION", "TARGET", "IP", "RESULT")
+		for _, e := range resp.Events {
+			result := "ok"
+			if !e.Success {
+				result = fmt.Sprintf(ErrorColor, "failed: "+e.Message)
+			}
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t\n", time.Unix(e.Date, 0), e.Actor, e.Action, e.Target, e.Ip, result)
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+
+	auditCmd.Flags().Int32P("limit", "l", 50, "Max count of events to display (0 to display all events)")
+	auditCmd.Flags().StringP("actor", "u", "", "Display only the events of this actor")
+	auditCmd.Flags().Duration("since", 0, "Display only the events recorded during this period (e.g. 24h)")
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// the headers set by the manager while the maintenance mode is active
+const (
+	maintenanceHeader      = "backr-maintenance-bin"
+	maintenanceUntilHeader = "backr-maintenance-until"
+)
+
+var maintenanceBannerOnce sync.Once
+
+// maintenanceBanner displays a banner (once) when the manager replies that the maintenance mode is active
+func maintenanceBanner(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	var header metadata.MD
+	err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Header(&header))...)
+
+	reasons := header.Get(maintenanceHeader)
+	if len(reasons) > 0 {
+		maintenanceBannerOnce.Do(func() {
+			banner := "MAINTENANCE MODE: deletions & alerts are frozen (" + reasons[0] + ")"
+			if until := header.Get(maintenanceUntilHeader); len(until) > 0 {
+				if timestamp, err := strconv.ParseInt(until[0], 10, 64); err == nil && timestamp > 0 {
+					banner += fmt.Sprintf(" until %v", time.Unix(timestamp, 0))
+				}
+			}
+			fmt.Fprintf(os.Stderr, ErrorColor+"\n\n", banner)
+		})
+	}
+
+	return err
+}
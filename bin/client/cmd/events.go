@@ -0,0 +1,170 @@
+/*
+Copyright © 2019 Matthieu MARTIN <matthieu@agence-webup.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/agence-webup/backr/manager/proto"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// eventsCmd represents the events command
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Display the events: files selected & deleted, rule errors, projects changes, notifications",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		follow, err := cmd.Flags().GetBool("follow")
+		if err != nil {
+			fmt.Println("unable to get 'follow' flag")
+			os.Exit(1)
+		}
+		projectName, err := cmd.Flags().GetString("project")
+		if err != nil {
+			fmt.Println("unable to get 'project' flag")
+			os.Exit(1)
+		}
+		types, err := cmd.Flags().GetStringSlice("type")
+		if err != nil {
+			fmt.Println("unable to get 'type' flag")
+			os.Exit(1)
+		}
+		replay, err := cmd.Flags().GetInt32("replay")
+		if err != nil {
+			fmt.Println("unable to get 'replay' flag")
+			os.Exit(1)
+		}
+
+		addr := viper.GetString("endpoint")
+		conn, err := grpcConnect(addr)
+		if err != nil {
+			fmt.Printf("unable to dial to addr: %v\n", err)
+			os.Exit(1)
+		}
+		defer conn.Close()
+
+		client := proto.NewBackrApiClient(conn)
+
+		// stop following on SIGINT
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		sigint := make(chan os.Signal, 1)
+		signal.Notify(sigint, syscall.SIGINT, syscall.SIGTERM)
+		go func() {
+			<-sigint
+			cancel()
+		}()
+
+		req := &proto.WatchEventsRequest{
+			ProjectName: projectName,
+			Types:       types,
+			Replay:      replay,
+			Follow:      follow,
+		}
+
+		for {
+			lastID, err := watchEvents(ctx, client, req)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil && (!follow || status.Code(err) != codes.ResourceExhausted) {
+				fmt.Printf("error: %v\n", err)
+				os.Exit(1)
+			}
+			if !follow {
+				return
+			}
+
+			// the stream has been interrupted: resume after the last received event
+			fmt.Printf(ErrorColor, "stream interrupted, resuming...\n")
+			req.SinceId = lastID
+			time.Sleep(1 * time.Second)
+		}
+	},
+}
+
+// watchEvents prints the events of the stream, and returns the ID of the last received event
+func watchEvents(ctx context.Context, client proto.BackrApiClient, req *proto.WatchEventsRequest) (uint64, error) {
+	lastID := req.SinceId
+
+	stream, err := client.WatchEvents(ctx, req)
+	if err != nil {
+		return lastID, err
+	}
+
+	for {
+		e, err := stream.Recv()
+		if err == io.EOF {
+			return lastID, nil
+		}
+		if err != nil {
+			return lastID, err
+		}
+		lastID = e.Id
+
+		printEvent(e)
+	}
+}
+
+func printEvent(e *proto.Event) {
+	eventType := e.Type
+	switch {
+	case e.Type == "rule.error_raised" || e.Type == "project.corrupted" || e.Type == "deletion_plan.blocked" || e.Type == "project.cap_conflict":
+		eventType = fmt.Sprintf(ErrorColor, e.Type)
+	case e.Type == "file.deleted" || e.Type == "notification.sent":
+		eventType = fmt.Sprintf(NoticeColor, e.Type)
+	}
+
+	parts := []string{time.Unix(e.Date, 0).Format("2006-01-02 15:04:05"), eventType, e.ProjectName}
+	if e.RuleId != "" {
+		parts = append(parts, e.RuleId)
+	}
+	if e.FilePath != "" {
+		parts = append(parts, e.FilePath)
+	}
+	if e.Message != "" {
+		parts = append(parts, e.Message)
+	}
+
+	fmt.Println(strings.Join(parts, "  "))
+}
+
+func init() {
+	rootCmd.AddCommand(eventsCmd)
+
+	eventsCmd.Flags().BoolP("follow", "f", false, "Keep waiting for new events")
+	eventsCmd.Flags().StringP("project", "p", "", "Display only the events of this project")
+	eventsCmd.Flags().StringSliceP("type", "t", []string{}, "Display only the events of these types (e.g. file.deleted,rule.error_raised)")
+	eventsCmd.Flags().Int32P("replay", "r", 20, "Count of recent events to display first")
+}
@@ -0,0 +1,201 @@
+/*
+Copyright © 2019 Matthieu MARTIN <matthieu@agence-webup.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path"
+	"syscall"
+
+	"github.com/agence-webup/backr/manager/proto"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// fileDownloadCmd downloads a file through the API
+var fileDownloadCmd = &cobra.Command{
+	Use:   "download FILEPATH",
+	Short: "Download the file based on the specified filepath, streamed through the API",
+	Long: `Download the file based on the specified filepath, streamed through the API.
+
+An interrupted download can be resumed using --resume: the download starts from
+the end of the local file. The checksum of the whole file is verified at the end.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		output, err := cmd.Flags().GetString("output")
+		if err != nil {
+			fmt.Println("unable to get 'output' flag")
+			os.Exit(1)
+		}
+		resume, err := cmd.Flags().GetBool("resume")
+		if err != nil {
+			fmt.Println("unable to get 'resume' flag")
+			os.Exit(1)
+		}
+
+		filepath := args[0]
+		if output == "" {
+			output = path.Base(filepath)
+		}
+
+		flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		if resume {
+			flags = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+		}
+		out, err := os.OpenFile(output, flags, 0644)
+		if err != nil {
+			fmt.Printf("unable to open output file: %v\n", err)
+			os.Exit(1)
+		}
+		defer out.Close()
+
+		offset := int64(0)
+		if resume {
+			info, err := out.Stat()
+			if err != nil {
+				fmt.Printf("unable to read output file: %v\n", err)
+				os.Exit(1)
+			}
+			offset = info.Size()
+		}
+
+		addr := viper.GetString("endpoint")
+		conn, err := grpcConnect(addr)
+		if err != nil {
+			fmt.Printf("unable to dial to addr: %v\n", err)
+			os.Exit(1)
+		}
+		defer conn.Close()
+
+		client := proto.NewBackrApiClient(conn)
+
+		// no timeout: the download may be long, stop it on SIGINT
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		sigint := make(chan os.Signal, 1)
+		signal.Notify(sigint, syscall.SIGINT, syscall.SIGTERM)
+		go func() {
+			<-sigint
+			cancel()
+		}()
+
+		checksum, err := downloadFile(ctx, client, &proto.DownloadFileRequest{Filepath: filepath, Offset: offset}, out)
+		if err != nil {
+			fmt.Fprintln(os.Stderr)
+			if ctx.Err() != nil {
+				fmt.Printf("download interrupted, resume it using --resume\n")
+			} else {
+				fmt.Printf("error: %v\n", err)
+			}
+			os.Exit(1)
+		}
+		fmt.Fprintln(os.Stderr)
+
+		// verify the checksum of the whole file, including the resumed part
+		localChecksum, err := fileChecksum(output)
+		if err != nil {
+			fmt.Printf("unable to compute checksum: %v\n", err)
+			os.Exit(1)
+		}
+		if localChecksum != checksum {
+			fmt.Printf(ErrorColor, fmt.Sprintf("checksum mismatch: expected %v, got %v\n", checksum, localChecksum))
+			os.Exit(1)
+		}
+
+		fmt.Printf("%v downloaded (sha256: %v)\n", output, checksum)
+	},
+}
+
+// downloadFile writes the chunks of the stream into out, displaying the progress,
+// and returns the checksum sent at the end of the stream
+func downloadFile(ctx context.Context, client proto.BackrApiClient, req *proto.DownloadFileRequest, out io.Writer) (string, error) {
+	stream, err := client.DownloadFile(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	size := int64(0)
+	received := req.Offset
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return "", fmt.Errorf("the stream ended without checksum")
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if chunk.Size > 0 {
+			size = chunk.Size
+		}
+		if chunk.Sha256 != "" {
+			return chunk.Sha256, nil
+		}
+		if chunk.Offset != received {
+			return "", fmt.Errorf("unexpected chunk at offset %d, expected %d", chunk.Offset, received)
+		}
+
+		if _, err := out.Write(chunk.Data); err != nil {
+			return "", err
+		}
+		received += int64(len(chunk.Data))
+
+		printProgress(received, size)
+	}
+}
+
+func printProgress(received, size int64) {
+	if size == 0 {
+		fmt.Fprintf(os.Stderr, "\r%d bytes", received)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "\r%d / %d bytes (%d%%)", received, size, received*100/size)
+}
+
+func fileChecksum(filename string) (string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func init() {
+	fileCmd.AddCommand(fileDownloadCmd)
+
+	fileDownloadCmd.Flags().StringP("output", "o", "", "Path of the downloaded file (default: the name of the file)")
+	fileDownloadCmd.Flags().Bool("resume", false, "Resume an interrupted download, appending to the output file")
+}
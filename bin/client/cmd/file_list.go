@@ -69,10 +69,31 @@ var fileListCmd = &cobra.Command{
 		if len(resp.Files) == 0 {
 			fmt.Println("empty list")
 		} else {
+			// the last modification date is displayed when the date is read from another source
+			showLastModified := false
+			for _, f := range resp.Files {
+				if f.LastModified != 0 {
+					showLastModified = true
+					break
+				}
+			}
+
 			w := tabwriter.NewWriter(os.Stdout, 1, 1, 3, ' ', 0)
-			fmt.Fprintf(w, "%v\t%v\t%v\t\n", "PATH", "DATE", "SIZE")
+			if showLastModified {
+				fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t\n", "PATH", "DATE", "LAST MODIFIED", "SIZE", "PINNED")
+			} else {
+				fmt.Fprintf(w, "%v\t%v\t%v\t%v\t\n", "PATH", "DATE", "SIZE", "PINNED")
+			}
 			for _, f := range resp.Files {
-				fmt.Fprintf(w, "%v\t%v\t%v\t\n", f.Path, time.Unix(f.Date, 0), f.Size)
+				if showLastModified {
+					lastModified := "-"
+					if f.LastModified != 0 {
+						lastModified = time.Unix(f.LastModified, 0).String()
+					}
+					fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t\n", f.Path, time.Unix(f.Date, 0), lastModified, f.Size, formatPin(f.Pin))
+				} else {
+					fmt.Fprintf(w, "%v\t%v\t%v\t%v\t\n", f.Path, time.Unix(f.Date, 0), f.Size, formatPin(f.Pin))
+				}
 			}
 			w.Flush()
 		}
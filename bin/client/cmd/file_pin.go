@@ -0,0 +1,136 @@
+/*
+Copyright © 2019 Matthieu MARTIN <matthieu@agence-webup.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/agence-webup/backr/manager/proto"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// filePinCmd pins a file, kept regardless of the rules
+var filePinCmd = &cobra.Command{
+	Use:   "pin FILEPATH",
+	Short: "Pin a file, kept regardless of the rules (legal hold, incident investigation...)",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		reason, err := cmd.Flags().GetString("reason")
+		if err != nil {
+			fmt.Println("unable to get 'reason' flag")
+			os.Exit(1)
+		}
+		if reason == "" {
+			fmt.Println("You must provide a reason.")
+			os.Exit(1)
+		}
+		expiry, err := cmd.Flags().GetDuration("expiry")
+		if err != nil {
+			fmt.Println("unable to get 'expiry' flag")
+			os.Exit(1)
+		}
+
+		addr := viper.GetString("endpoint")
+		conn, err := grpcConnect(addr)
+		if err != nil {
+			fmt.Println("unable to dial to addr")
+			os.Exit(1)
+		}
+		defer conn.Close()
+
+		client := proto.NewBackrApiClient(conn)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		req := &proto.PinFileRequest{Filepath: args[0], Reason: reason}
+		if expiry > 0 {
+			req.ExpiresAt = time.Now().Add(expiry).Unix()
+		}
+
+		resp, err := client.PinFile(ctx, req)
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("'%v' %v\n", resp.Pin.Path, formatPin(resp.Pin))
+	},
+}
+
+// fileUnpinCmd unpins a file
+var fileUnpinCmd = &cobra.Command{
+	Use:   "unpin FILEPATH",
+	Short: "Unpin a file, the rules apply to it again",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		addr := viper.GetString("endpoint")
+		conn, err := grpcConnect(addr)
+		if err != nil {
+			fmt.Println("unable to dial to addr")
+			os.Exit(1)
+		}
+		defer conn.Close()
+
+		client := proto.NewBackrApiClient(conn)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		resp, err := client.UnpinFile(ctx, &proto.UnpinFileRequest{Filepath: args[0]})
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("'%v' unpinned\n", resp.Pin.Path)
+	},
+}
+
+// formatPin describes a pin, "-" if the file is not pinned
+func formatPin(pin *proto.Pin) string {
+	if pin == nil {
+		return "-"
+	}
+
+	txt := fmt.Sprintf("pinned by %v (%v)", pin.Author, pin.Reason)
+	if pin.ExpiresAt > 0 {
+		txt += fmt.Sprintf(" until %v", time.Unix(pin.ExpiresAt, 0))
+	}
+	return txt
+}
+
+func init() {
+	fileCmd.AddCommand(filePinCmd)
+	fileCmd.AddCommand(fileUnpinCmd)
+
+	filePinCmd.Flags().StringP("reason", "r", "", "Reason of the pin (required)")
+	filePinCmd.Flags().Duration("expiry", 0, "Duration after which the file is unpinned, e.g. 720h (never if 0)")
+}
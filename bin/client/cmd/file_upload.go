@@ -0,0 +1,175 @@
+/*
+Copyright © 2019 Matthieu MARTIN <matthieu@agence-webup.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/agence-webup/backr/manager/proto"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// size of the data sent in each message of an upload
+const uploadChunkSize = 64 * 1024
+
+// fileUploadCmd uploads a file through the API
+var fileUploadCmd = &cobra.Command{
+	Use:   "upload LOCALFILE",
+	Short: "Upload a backup file into the folder of a project, streamed through the API",
+	Long: `Upload a backup file into the folder of a project, streamed through the API.
+
+The hosts producing the backups don't need any credentials of the storage:
+an account with the 'uploader' role, restricted to the project, is enough.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		projectName, err := cmd.Flags().GetString("project")
+		if err != nil {
+			fmt.Println("unable to get 'project' flag")
+			os.Exit(1)
+		}
+		filename, err := cmd.Flags().GetString("name")
+		if err != nil {
+			fmt.Println("unable to get 'name' flag")
+			os.Exit(1)
+		}
+
+		localFile := args[0]
+		if filename == "" {
+			filename = filepath.Base(localFile)
+		}
+
+		// the checksum is sent first, allowing the server to verify the stored file
+		checksum, err := fileChecksum(localFile)
+		if err != nil {
+			fmt.Printf("unable to compute checksum: %v\n", err)
+			os.Exit(1)
+		}
+
+		in, err := os.Open(localFile)
+		if err != nil {
+			fmt.Printf("unable to open file: %v\n", err)
+			os.Exit(1)
+		}
+		defer in.Close()
+
+		info, err := in.Stat()
+		if err != nil {
+			fmt.Printf("unable to read file: %v\n", err)
+			os.Exit(1)
+		}
+
+		addr := viper.GetString("endpoint")
+		conn, err := grpcConnect(addr)
+		if err != nil {
+			fmt.Printf("unable to dial to addr: %v\n", err)
+			os.Exit(1)
+		}
+		defer conn.Close()
+
+		client := proto.NewBackrApiClient(conn)
+
+		// no timeout: the upload may be long, stop it on SIGINT
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		sigint := make(chan os.Signal, 1)
+		signal.Notify(sigint, syscall.SIGINT, syscall.SIGTERM)
+		go func() {
+			<-sigint
+			cancel()
+		}()
+
+		header := &proto.UploadFileRequest{
+			ProjectName: projectName,
+			Filename:    filename,
+			Size:        info.Size(),
+			Sha256:      checksum,
+		}
+		resp, err := uploadFile(ctx, client, header, in)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("%v uploaded (sha256: %v)\n", resp.File.Path, resp.Sha256)
+	},
+}
+
+// uploadFile sends the description of the file, then its content read from in, displaying the progress
+func uploadFile(ctx context.Context, client proto.BackrApiClient, header *proto.UploadFileRequest, in io.Reader) (*proto.UploadFileResponse, error) {
+	stream, err := client.UploadFile(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = stream.Send(header)
+	if err != nil {
+		return nil, closeUpload(stream, err)
+	}
+
+	sent := int64(0)
+	buffer := make([]byte, uploadChunkSize)
+	for {
+		n, err := in.Read(buffer)
+		if n > 0 {
+			if err := stream.Send(&proto.UploadFileRequest{Data: buffer[:n]}); err != nil {
+				return nil, closeUpload(stream, err)
+			}
+			sent += int64(n)
+			printProgress(sent, header.Size)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return stream.CloseAndRecv()
+}
+
+// closeUpload returns the error sent by the server when the stream is interrupted
+func closeUpload(stream proto.BackrApi_UploadFileClient, err error) error {
+	if err != io.EOF {
+		return err
+	}
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
+func init() {
+	fileCmd.AddCommand(fileUploadCmd)
+
+	fileUploadCmd.Flags().StringP("project", "p", "", "Name of the project")
+	fileUploadCmd.Flags().StringP("name", "n", "", "Name of the uploaded file (default: the name of the local file)")
+
+	fileUploadCmd.MarkFlagRequired("project")
+}
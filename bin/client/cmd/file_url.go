@@ -39,6 +39,22 @@ var fileURLCmd = &cobra.Command{
 	Long:  ``,
 	Run: func(cmd *cobra.Command, args []string) {
 
+		expiry, err := cmd.Flags().GetDuration("expiry")
+		if err != nil {
+			fmt.Println("unable to get 'expiry' flag")
+			os.Exit(1)
+		}
+		downloadFilename, err := cmd.Flags().GetString("filename")
+		if err != nil {
+			fmt.Println("unable to get 'filename' flag")
+			os.Exit(1)
+		}
+		oneTime, err := cmd.Flags().GetBool("one-time")
+		if err != nil {
+			fmt.Println("unable to get 'one-time' flag")
+			os.Exit(1)
+		}
+
 		addr := viper.GetString("endpoint")
 		conn, err := grpcConnect(addr)
 		if err != nil {
@@ -57,7 +73,12 @@ var fileURLCmd = &cobra.Command{
 			filepath = args[0]
 		}
 
-		req := &proto.GetFileURLRequest{Filepath: filepath}
+		req := &proto.GetFileURLRequest{
+			Filepath:         filepath,
+			Expiry:           int64(expiry / time.Second),
+			DownloadFilename: downloadFilename,
+			OneTime:          oneTime,
+		}
 		resp, err := client.GetFileURL(ctx, req)
 		if err != nil {
 			fmt.Printf("error: %v\n", err)
@@ -65,12 +86,17 @@ var fileURLCmd = &cobra.Command{
 		}
 
 		fmt.Println(resp.Url)
+		fmt.Fprintf(os.Stderr, "expires at %v\n", time.Unix(resp.ExpiresAt, 0))
 	},
 }
 
 func init() {
 	fileCmd.AddCommand(fileURLCmd)
 
+	fileURLCmd.Flags().DurationP("expiry", "e", 0, "Lifetime of the URL (default 15m, capped by the server)")
+	fileURLCmd.Flags().String("filename", "", "Force the browser to download the file, saved with this name")
+	fileURLCmd.Flags().Bool("one-time", false, "Get a URL of the daemon, invalidated after the first download")
+
 	// Here you will define your flags and configuration settings.
 
 	// Cobra supports Persistent Flags which will work for this command
@@ -39,26 +39,13 @@ import (
 // loginCmd represents the login command
 var loginCmd = &cobra.Command{
 	Use:   "login",
-	Short: "Login using username and password, and save token into a file in $HOME directory (.backr_auth)",
+	Short: "Login using username and password (or OIDC), and save token into a file in $HOME directory (.backr_auth)",
 	Long:  ``,
 	Run: func(cmd *cobra.Command, args []string) {
 
-		rl, err := readline.New("username: ")
+		useOIDC, err := cmd.Flags().GetBool("oidc")
 		if err != nil {
-			panic(err)
-		}
-		defer rl.Close()
-
-		username, err := rl.Readline()
-		if err != nil && err != io.EOF {
-			fmt.Printf("unable to get username: %v", err)
-			os.Exit(1)
-		}
-
-		password, err := rl.ReadPassword("password: ")
-		if err != nil {
-			fmt.Printf("unable to get password: %v", err)
-			os.Exit(1)
+			fmt.Println("unable to get 'oidc' flag")
 		}
 
 		addr := viper.GetString("endpoint")
@@ -71,34 +58,88 @@ var loginCmd = &cobra.Command{
 
 		client := proto.NewBackrApiClient(conn)
 
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-
-		req := &proto.AuthenticateAccountRequest{Username: username, Password: string(password)}
-		resp, err := client.AuthenticateAccount(ctx, req)
+		var token string
+		if useOIDC {
+			token, err = loginOIDC(client)
+		} else {
+			token, err = loginPassword(client)
+		}
 		if err != nil {
 			fmt.Printf("%v\n", err)
 			os.Exit(1)
 		}
 
-		home, err := homedir.Dir()
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
+		saveToken(token)
+	},
+}
 
-		cacheFilepath := filepath.Join(home, ".backr_auth")
-		cacheFile, err := os.OpenFile(cacheFilepath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
-		defer cacheFile.Close()
-		if err != nil {
-			fmt.Println("unable to save token into file")
-			fmt.Println("")
-			fmt.Println("token:", resp.Token)
-		}
+// loginPassword asks for the username and password, and returns a token issued by the daemon
+func loginPassword(client proto.BackrApiClient) (string, error) {
+	rl, err := readline.New("username: ")
+	if err != nil {
+		panic(err)
+	}
+	defer rl.Close()
+
+	username, err := rl.Readline()
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("unable to get username: %v", err)
+	}
+
+	password, err := rl.ReadPassword("password: ")
+	if err != nil {
+		return "", fmt.Errorf("unable to get password: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req := &proto.AuthenticateAccountRequest{Username: username, Password: string(password)}
+	resp, err := client.AuthenticateAccount(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Token, nil
+}
 
-		fmt.Fprint(cacheFile, resp.Token)
-		fmt.Println("token saved in", cacheFilepath)
-	},
+// loginOIDC authenticates against the OpenID Connect provider configured on the daemon,
+// and returns the ID token
+func loginOIDC(client proto.BackrApiClient) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	authConfig, err := client.GetAuthConfig(ctx, &proto.GetAuthConfigRequest{})
+	if err != nil {
+		return "", fmt.Errorf("unable to get auth config: %v", err)
+	}
+	if authConfig.OidcIssuer == "" {
+		return "", fmt.Errorf("OIDC is not enabled on this server")
+	}
+
+	return loginWithDeviceFlow(authConfig.OidcIssuer, authConfig.OidcClientId)
+}
+
+// saveToken writes the token into the file used by other commands to authenticate
+func saveToken(token string) {
+	home, err := homedir.Dir()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	cacheFilepath := filepath.Join(home, ".backr_auth")
+	cacheFile, err := os.OpenFile(cacheFilepath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		fmt.Println("unable to save token into file")
+		fmt.Println("")
+		fmt.Println("token:", token)
+		return
+	}
+	defer cacheFile.Close()
+
+	fmt.Fprint(cacheFile, token)
+	fmt.Println("token saved in", cacheFilepath)
 }
 
 func init() {
@@ -113,4 +154,6 @@ func init() {
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
 	// loginCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+
+	loginCmd.Flags().Bool("oidc", false, "Login with the OpenID Connect provider configured on the server (device flow)")
 }
@@ -0,0 +1,169 @@
+/*
+Copyright © 2019 Matthieu MARTIN <matthieu@agence-webup.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+type oidcProviderMetadata struct {
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+	TokenEndpoint               string `json:"token_endpoint"`
+}
+
+type deviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURL         string `json:"verification_url"` // used by some providers instead of verification_uri
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// loginWithDeviceFlow performs the OAuth 2.0 device authorization grant (RFC 8628)
+// against the OpenID Connect provider, and returns the obtained ID token
+func loginWithDeviceFlow(issuer string, clientID string) (string, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	// discover the endpoints of the provider
+	metadata := oidcProviderMetadata{}
+	err := getJSON(client, strings.TrimSuffix(issuer, "/")+"/.well-known/openid-configuration", &metadata)
+	if err != nil {
+		return "", fmt.Errorf("unable to discover OIDC provider: %w", err)
+	}
+	if metadata.DeviceAuthorizationEndpoint == "" {
+		return "", fmt.Errorf("the OIDC provider does not support the device flow")
+	}
+
+	// request a device code
+	authorization := deviceAuthorizationResponse{}
+	err = postForm(client, metadata.DeviceAuthorizationEndpoint, url.Values{
+		"client_id": {clientID},
+		"scope":     {"openid profile email"},
+	}, &authorization)
+	if err != nil {
+		return "", fmt.Errorf("unable to request a device code: %w", err)
+	}
+
+	verificationURI := authorization.VerificationURI
+	if verificationURI == "" {
+		verificationURI = authorization.VerificationURL
+	}
+
+	fmt.Printf("To authenticate, open %v and enter the code: ", verificationURI)
+	fmt.Printf(NoticeColor, authorization.UserCode+"\n")
+	if authorization.VerificationURIComplete != "" {
+		fmt.Printf("or open directly %v\n", authorization.VerificationURIComplete)
+	}
+	fmt.Println("")
+
+	// poll the token endpoint until the user is authenticated
+	interval := time.Duration(authorization.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	deadline := time.Now().Add(time.Duration(authorization.ExpiresIn) * time.Second)
+
+	for authorization.ExpiresIn == 0 || time.Now().Before(deadline) {
+		time.Sleep(interval)
+
+		token := tokenResponse{}
+		err := postForm(client, metadata.TokenEndpoint, url.Values{
+			"grant_type":  {deviceCodeGrantType},
+			"device_code": {authorization.DeviceCode},
+			"client_id":   {clientID},
+		}, &token)
+		if err != nil && token.Error == "" {
+			return "", fmt.Errorf("unable to request a token: %w", err)
+		}
+
+		switch token.Error {
+		case "":
+			if token.IDToken == "" {
+				return "", fmt.Errorf("no ID token returned by the OIDC provider")
+			}
+			return token.IDToken, nil
+		case "authorization_pending":
+			continue
+		case "slow_down":
+			interval += 5 * time.Second
+			continue
+		default:
+			return "", fmt.Errorf("authentication failed: %v %v", token.Error, token.ErrorDescription)
+		}
+	}
+
+	return "", fmt.Errorf("the device code has expired")
+}
+
+func getJSON(client *http.Client, endpoint string, result interface{}) error {
+	resp, err := client.Get(endpoint)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("unexpected status: %v", resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// postForm posts the form and decodes the JSON response into result, even if the status is an error
+func postForm(client *http.Client, endpoint string, form url.Values, result interface{}) error {
+	resp, err := client.PostForm(endpoint, form)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(body, result)
+	if err != nil {
+		return fmt.Errorf("unable to decode response (%v): %w", resp.Status, err)
+	}
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("unexpected status: %v", resp.Status)
+	}
+
+	return nil
+}
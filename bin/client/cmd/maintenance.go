@@ -0,0 +1,81 @@
+/*
+Copyright © 2019 Matthieu MARTIN <matthieu@agence-webup.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/agence-webup/backr/manager/proto"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// maintenanceCmd displays the maintenance mode
+var maintenanceCmd = &cobra.Command{
+	Use:   "maintenance",
+	Short: "Display or toggle the maintenance mode, freezing all the deletions and alerts",
+	Long:  ``,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		addr := viper.GetString("endpoint")
+		conn, err := grpcConnect(addr)
+		if err != nil {
+			fmt.Println("unable to dial to addr")
+			os.Exit(1)
+		}
+		defer conn.Close()
+
+		client := proto.NewBackrApiClient(conn)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		resp, err := client.GetMaintenance(ctx, &proto.GetMaintenanceRequest{})
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Println(formatMaintenance(resp.Maintenance))
+	},
+}
+
+// formatMaintenance describes the maintenance mode
+func formatMaintenance(m *proto.Maintenance) string {
+	if m == nil {
+		return "maintenance mode: off"
+	}
+
+	txt := fmt.Sprintf("maintenance mode: on, since %v by %v (%v)", time.Unix(m.StartedAt, 0), m.Author, m.Reason)
+	if m.Until > 0 {
+		txt += fmt.Sprintf(" until %v", time.Unix(m.Until, 0))
+	}
+	return txt
+}
+
+func init() {
+	rootCmd.AddCommand(maintenanceCmd)
+}
@@ -0,0 +1,95 @@
+/*
+Copyright © 2019 Matthieu MARTIN <matthieu@agence-webup.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/agence-webup/backr/manager/proto"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// maintenanceOnCmd enables the maintenance mode
+var maintenanceOnCmd = &cobra.Command{
+	Use:   "on",
+	Short: "Enable the maintenance mode: the process and the trash purge are skipped, the API stays available",
+	Long:  ``,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		reason, err := cmd.Flags().GetString("reason")
+		if err != nil {
+			fmt.Println("unable to get 'reason' flag")
+			os.Exit(1)
+		}
+		if reason == "" {
+			fmt.Println("You must provide a reason.")
+			os.Exit(1)
+		}
+		until, err := cmd.Flags().GetDuration("until")
+		if err != nil {
+			fmt.Println("unable to get 'until' flag")
+			os.Exit(1)
+		}
+
+		req := &proto.SetMaintenanceRequest{Enabled: true, Reason: reason}
+		if until > 0 {
+			req.Until = time.Now().Add(until).Unix()
+		}
+
+		setMaintenance(req)
+	},
+}
+
+func setMaintenance(req *proto.SetMaintenanceRequest) {
+	addr := viper.GetString("endpoint")
+	conn, err := grpcConnect(addr)
+	if err != nil {
+		fmt.Println("unable to dial to addr")
+		os.Exit(1)
+	}
+	defer conn.Close()
+
+	client := proto.NewBackrApiClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := client.SetMaintenance(ctx, req)
+	if err != nil {
+		fmt.Printf("error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println(formatMaintenance(resp.Maintenance))
+}
+
+func init() {
+	maintenanceCmd.AddCommand(maintenanceOnCmd)
+
+	maintenanceOnCmd.Flags().StringP("reason", "r", "", "Reason of the maintenance (required)")
+	maintenanceOnCmd.Flags().Duration("until", 0, "Duration after which the maintenance mode ends, e.g. 4h (never if 0)")
+}
@@ -22,6 +22,9 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"fmt"
+
+	"github.com/agence-webup/backr/manager/proto"
 	"github.com/spf13/cobra"
 )
 
@@ -33,6 +36,42 @@ var projectsCmd = &cobra.Command{
 	Aliases: []string{"pr"},
 }
 
+// addDateSourceFlags adds the flags defining where the date of the files of a project is read
+func addDateSourceFlags(cmd *cobra.Command) {
+	cmd.Flags().String("date-source", "last_modified", "Source of the date of the files: last_modified, filename or metadata")
+	cmd.Flags().String("date-pattern", "", "Regexp extracting the date from the filename, the first group is parsed if any (i.e '(\\d{8}-\\d{4})')")
+	cmd.Flags().String("date-header", "", "Metadata header containing the date (i.e X-Amz-Meta-Backup-Date)")
+	cmd.Flags().String("date-layout", "", "Format of the date, with the Go reference time (i.e 20060102-1504), RFC3339 by default")
+}
+
+// getDateSource returns the date source defined by the flags
+func getDateSource(cmd *cobra.Command) *proto.DateSource {
+	sourceType, _ := cmd.Flags().GetString("date-source")
+	pattern, _ := cmd.Flags().GetString("date-pattern")
+	header, _ := cmd.Flags().GetString("date-header")
+	layout, _ := cmd.Flags().GetString("date-layout")
+
+	return &proto.DateSource{Type: sourceType, Pattern: pattern, Header: header, Layout: layout}
+}
+
+// formatDateSource returns a short description of the date source
+func formatDateSource(source *proto.DateSource) string {
+	if source == nil || source.Type == "" {
+		return "last_modified"
+	}
+	layout := source.Layout
+	if layout == "" {
+		layout = "RFC3339"
+	}
+	switch source.Type {
+	case "filename":
+		return fmt.Sprintf("filename /%v/ (%v)", source.Pattern, layout)
+	case "metadata":
+		return fmt.Sprintf("metadata %v (%v)", source.Header, layout)
+	}
+	return source.Type
+}
+
 func init() {
 	rootCmd.AddCommand(projectsCmd)
 
@@ -25,8 +25,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/agence-webup/backr/manager/proto"
@@ -55,21 +53,7 @@ var createCmd = &cobra.Command{
 		}
 		fmt.Println(rawRules)
 
-		rules := []*proto.Rule{}
-		for _, r := range rawRules {
-			comps := strings.Split(r, ".")
-			if len(comps) == 2 {
-				count, err := strconv.ParseInt(comps[0], 10, 32)
-				if err != nil || count == 0 {
-					continue
-				}
-				minAge, err := strconv.ParseInt(comps[1], 10, 32)
-				if err != nil || minAge == 0 {
-					continue
-				}
-				rules = append(rules, &proto.Rule{Count: int32(count), MinAge: int32(minAge)})
-			}
-		}
+		rules := parseRules(rawRules)
 
 		addr := viper.GetString("endpoint")
 		conn, err := grpcConnect(addr)
@@ -84,14 +68,22 @@ var createCmd = &cobra.Command{
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
+		uploadInterval, _ := cmd.Flags().GetDuration("upload-interval")
+
 		req := &proto.CreateProjectRequest{
-			Name:  name,
-			Rules: rules,
+			Name:           name,
+			Rules:          rules,
+			UploadInterval: int64(uploadInterval / time.Second),
+			Caps:           getCaps(cmd),
+			DateSource:     getDateSource(cmd),
 		}
-		_, err = client.CreateProject(ctx, req)
+		resp, err := client.CreateProject(ctx, req)
 		if err != nil {
 			fmt.Printf("error: %v", err)
+			os.Exit(1)
 		}
+
+		printRulesReport(resp.Report)
 	},
 }
 
@@ -109,7 +101,10 @@ func init() {
 	// createCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 
 	createCmd.Flags().StringP("name", "n", "", "Name of the project. Should be unique")
-	createCmd.Flags().StringSliceP("rule", "r", []string{}, "Define a rule with this pattern: COUNT.MIN_AGE  (i.e -r 3.1)")
+	createCmd.Flags().StringSliceP("rule", "r", []string{}, "Define a rule with this pattern: COUNT.MIN_AGE[+TOLERANCE], the min age is a count of days or a duration (i.e -r 3.1 -r 4.6h+30m)")
+	createCmd.Flags().Duration("upload-interval", 0, "Declared delay between two uploads, used to check the rules (i.e 24h)")
+	addCapsFlags(createCmd)
+	addDateSourceFlags(createCmd)
 
 	createCmd.MarkFlagRequired("name")
 	createCmd.MarkFlagRequired("rule")
@@ -79,12 +79,32 @@ var getCmd = &cobra.Command{
 
 		if showAll {
 			w := tabwriter.NewWriter(os.Stdout, 1, 1, 3, ' ', 0)
-			fmt.Fprintf(w, "%v\t%v\t\n", "PROJECT NAME", "CREATED AT")
-			fmt.Fprintf(w, "%v\t%v\t\n", p.Name, time.Unix(p.CreatedAt, 0))
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t\n", "PROJECT NAME", "CREATED AT", "CAPS", "DATE SOURCE")
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t\n", p.Name, time.Unix(p.CreatedAt, 0), formatCaps(p.Caps), formatDateSource(p.DateSource))
 			w.Flush()
 			fmt.Println("")
 		}
 
+		if p.Status != nil && p.Status.Status != "active" {
+			fmt.Printf("%v %v\n\n", fmt.Sprintf(ErrorColor, "status:"), formatProjectStatus(p.Status))
+		}
+
+		if len(p.CapConflicts) > 0 {
+			fmt.Println(fmt.Sprintf(ErrorColor, "caps conflict with the rules:"))
+			for _, conflict := range p.CapConflicts {
+				fmt.Printf("  - %v\n", conflict)
+			}
+			fmt.Println("")
+		}
+
+		if plan := p.DeletionPlan; plan != nil && plan.ApprovedAt == 0 {
+			fmt.Printf("%v %v file(s) to remove, blocked since %v (plan %v)\n", fmt.Sprintf(ErrorColor, "deletion blocked:"), len(plan.Files), time.Unix(plan.CreatedAt, 0), plan.Id)
+			for _, reason := range plan.Reasons {
+				fmt.Printf("  - %v\n", reason)
+			}
+			fmt.Printf("approve it with: backrctl project approve-deletion %v %v\n\n", p.Name, plan.Id)
+		}
+
 		showFiles, err := cmd.Flags().GetBool("files")
 		if err != nil {
 			fmt.Println(err)
@@ -93,21 +113,39 @@ var getCmd = &cobra.Command{
 		if showFiles || showAll {
 			w := tabwriter.NewWriter(os.Stdout, 1, 1, 3, ' ', 0)
 			for _, r := range p.Rules {
-				fmt.Printf("\033[1;36m%s\033[0m\n", fmt.Sprintf("%d.%d (next: %v)", r.Count, r.MinAge, time.Unix(r.NextDate, 0)))
+				fmt.Printf("\033[1;36m%s\033[0m\n", fmt.Sprintf("%v (next: %v)", formatRule(r), time.Unix(r.NextDate, 0)))
 				if r.Error > 0 {
 					fmt.Printf("%v %v\n", fmt.Sprintf(ErrorColor, "error:"), r.Error.String())
 				}
-				fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t\n", "PATH", "DATE", "EXPIRE AT", "SIZE", "ERROR")
+				fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t\n", "PATH", "DATE", "EXPIRE AT", "SIZE", "ERROR", "PINNED")
 				for _, f := range r.Files {
 					errTxt := "-"
 					if f.Error > 0 {
 						errTxt = fmt.Sprintf(ErrorColor, f.Error.String())
 					}
-					fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t\n", f.Path, time.Unix(f.Date, 0), time.Unix(f.Expiration, 0), f.Size, errTxt)
+					fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t\n", f.Path, time.Unix(f.Date, 0), time.Unix(f.Expiration, 0), f.Size, errTxt, formatPin(f.Pin))
 				}
 			}
 			w.Flush()
 			fmt.Println("")
+
+			// the pinned files may not be selected by any rule
+			if len(p.Pins) > 0 {
+				fmt.Printf("\033[1;36m%s\033[0m\n", "pinned files")
+				fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t\n", "PATH", "REASON", "AUTHOR", "PINNED AT", "EXPIRE AT")
+				for _, pin := range p.Pins {
+					expiration := "never"
+					if pin.ExpiresAt > 0 {
+						expiration = time.Unix(pin.ExpiresAt, 0).String()
+						if time.Unix(pin.ExpiresAt, 0).Before(time.Now()) {
+							expiration = fmt.Sprintf(ErrorColor, "expired "+expiration)
+						}
+					}
+					fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t\n", pin.Path, pin.Reason, pin.Author, time.Unix(pin.CreatedAt, 0), expiration)
+				}
+				w.Flush()
+				fmt.Println("")
+			}
 		}
 
 	},
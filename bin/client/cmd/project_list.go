@@ -68,14 +68,14 @@ var listCmd = &cobra.Command{
 		}
 
 		w := tabwriter.NewWriter(os.Stdout, 1, 1, 3, ' ', 0)
-		fmt.Fprintf(w, "%v\t%v\t%v\t\n", "NAME", "CREATED_AT", "RULES (count.min_age)")
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t\n", "NAME", "CREATED_AT", "RULES (count.min_age)", "STATUS")
 		for _, p := range resp.Projects {
 			t := time.Unix(p.CreatedAt, 0)
 			rules := []string{}
 			for _, r := range p.Rules {
-				rules = append(rules, fmt.Sprintf("%v.%v", r.Count, r.MinAge))
+				rules = append(rules, formatRule(r))
 			}
-			fmt.Fprintf(w, "%v\t%v\t%v\t\n", p.Name, t, strings.Join(rules, " "))
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t\n", p.Name, t, strings.Join(rules, " "), formatProjectStatus(p.Status))
 		}
 		w.Flush()
 	},
@@ -0,0 +1,130 @@
+/*
+Copyright © 2019 Matthieu MARTIN <matthieu@agence-webup.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/agence-webup/backr/manager/proto"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// projectPauseCmd pauses the processing of a project
+var projectPauseCmd = &cobra.Command{
+	Use:   "pause PROJECT_NAME",
+	Short: "Pause the deletions of a project, or its whole processing",
+	Long: `Pause the deletions of a project: the files are still selected and monitored (alerts included), but never removed.
+With --all, the project is not processed at all, and no alert is sent.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		reason, err := cmd.Flags().GetString("reason")
+		if err != nil {
+			fmt.Println("unable to get 'reason' flag")
+			os.Exit(1)
+		}
+		if reason == "" {
+			fmt.Println("You must provide a reason.")
+			os.Exit(1)
+		}
+		all, err := cmd.Flags().GetBool("all")
+		if err != nil {
+			fmt.Println("unable to get 'all' flag")
+			os.Exit(1)
+		}
+		resumeIn, err := cmd.Flags().GetDuration("resume-in")
+		if err != nil {
+			fmt.Println("unable to get 'resume-in' flag")
+			os.Exit(1)
+		}
+
+		req := &proto.SetProjectStatusRequest{ProjectName: args[0], Status: "paused-deletions", Reason: reason}
+		if all {
+			req.Status = "paused-all"
+		}
+		if resumeIn > 0 {
+			req.ResumeAt = time.Now().Add(resumeIn).Unix()
+		}
+
+		setProjectStatus(req)
+	},
+}
+
+// projectResumeCmd resumes the processing of a project
+var projectResumeCmd = &cobra.Command{
+	Use:   "resume PROJECT_NAME",
+	Short: "Resume the processing of a paused project",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		setProjectStatus(&proto.SetProjectStatusRequest{ProjectName: args[0], Status: "active"})
+	},
+}
+
+func setProjectStatus(req *proto.SetProjectStatusRequest) {
+	addr := viper.GetString("endpoint")
+	conn, err := grpcConnect(addr)
+	if err != nil {
+		fmt.Println("unable to dial to addr")
+		os.Exit(1)
+	}
+	defer conn.Close()
+
+	client := proto.NewBackrApiClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := client.SetProjectStatus(ctx, req)
+	if err != nil {
+		fmt.Printf("error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("'%v' %v\n", resp.Project.Name, formatProjectStatus(resp.Project.Status))
+}
+
+// formatProjectStatus describes the status of a project
+func formatProjectStatus(status *proto.ProjectStatus) string {
+	if status == nil || status.Status == "active" {
+		return "active"
+	}
+
+	txt := fmt.Sprintf("%v by %v (%v)", status.Status, status.Author, status.Reason)
+	if status.ResumeAt > 0 {
+		txt += fmt.Sprintf(" until %v", time.Unix(status.ResumeAt, 0))
+	}
+	return txt
+}
+
+func init() {
+	projectsCmd.AddCommand(projectPauseCmd)
+	projectsCmd.AddCommand(projectResumeCmd)
+
+	projectPauseCmd.Flags().StringP("reason", "r", "", "Reason of the pause (required)")
+	projectPauseCmd.Flags().Bool("all", false, "Pause the whole processing, including the monitoring")
+	projectPauseCmd.Flags().Duration("resume-in", 0, "Duration after which the project is automatically resumed, e.g. 48h (never if 0)")
+}
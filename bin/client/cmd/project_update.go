@@ -0,0 +1,95 @@
+/*
+Copyright © 2019 Matthieu MARTIN <matthieu@agence-webup.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/agence-webup/backr/manager/proto"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// projectUpdateCmd replaces the rules of a project
+var projectUpdateCmd = &cobra.Command{
+	Use:   "update PROJECT",
+	Short: "Replace the rules and the caps of a project",
+	Long: `Replace the rules and the caps of a project. The files selected only by the removed rules
+are not kept anymore, they are removed by the next process.
+
+The caps take precedence over the rules: the files exceeding them are removed, the oldest first,
+except the pinned files and the newest files (--keep-newest).`,
+	Example: "  backrctl project update project1 -r 3.1 -r 2.15 --upload-interval 24h --max-age 2160h --keep-newest 2",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		rawRules, err := cmd.Flags().GetStringSlice("rule")
+		if err != nil {
+			fmt.Printf("unable to get 'rule' params: %v\n", err)
+			os.Exit(1)
+		}
+		uploadInterval, _ := cmd.Flags().GetDuration("upload-interval")
+
+		addr := viper.GetString("endpoint")
+		conn, err := grpcConnect(addr)
+		if err != nil {
+			fmt.Println("unable to dial to addr")
+			os.Exit(1)
+		}
+		defer conn.Close()
+
+		client := proto.NewBackrApiClient(conn)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		req := &proto.UpdateProjectRequest{
+			Name:           args[0],
+			Rules:          parseRules(rawRules),
+			UploadInterval: int64(uploadInterval / time.Second),
+			Caps:           getCaps(cmd),
+			DateSource:     getDateSource(cmd),
+		}
+		resp, err := client.UpdateProject(ctx, req)
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("project %v updated\n", resp.Project.Name)
+		printRulesReport(resp.Report)
+	},
+}
+
+func init() {
+	projectsCmd.AddCommand(projectUpdateCmd)
+
+	projectUpdateCmd.Flags().StringSliceP("rule", "r", []string{}, "Define a rule with this pattern: COUNT.MIN_AGE[+TOLERANCE], the min age is a count of days or a duration (i.e -r 3.1 -r 4.6h+30m)")
+	projectUpdateCmd.Flags().Duration("upload-interval", 0, "Declared delay between two uploads, used to check the rules (i.e 24h)")
+	addCapsFlags(projectUpdateCmd)
+	addDateSourceFlags(projectUpdateCmd)
+
+	projectUpdateCmd.MarkFlagRequired("rule")
+}
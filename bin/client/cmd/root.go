@@ -84,5 +84,9 @@ func grpcConnect(addr string) (*grpc.ClientConn, error) {
 		}
 	}
 
-	return grpc.Dial(addr, grpc.WithInsecure(), grpc.WithPerRPCCredentials(tokenAuth{token: cleanToken}))
+	return grpcConnectWithToken(addr, cleanToken)
+}
+
+func grpcConnectWithToken(addr string, token string) (*grpc.ClientConn, error) {
+	return grpc.Dial(addr, grpc.WithInsecure(), grpc.WithPerRPCCredentials(tokenAuth{token: token}), grpc.WithUnaryInterceptor(maintenanceBanner))
 }
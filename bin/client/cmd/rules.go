@@ -0,0 +1,153 @@
+/*
+Copyright © 2019 Matthieu MARTIN <matthieu@agence-webup.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/proto"
+	"github.com/spf13/cobra"
+)
+
+// rulesCmd represents the rules command
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "Design the retention rules",
+	Long:  ``,
+}
+
+// parseRules parses the rules with this pattern: COUNT.MIN_AGE[+TOLERANCE], the invalid rules are ignored.
+// MIN_AGE is a count of days (i.e 3.1) or a duration (i.e 4.6h, 2.1w), TOLERANCE is a duration (i.e 4.6h+30m)
+func parseRules(rawRules []string) []*proto.Rule {
+	rules := []*proto.Rule{}
+	for _, r := range rawRules {
+		comps := strings.SplitN(r, ".", 2)
+		if len(comps) == 2 {
+			count, err := strconv.ParseInt(comps[0], 10, 32)
+			if err != nil || count == 0 {
+				continue
+			}
+
+			rawMinAge := comps[1]
+			tolerance := time.Duration(0)
+			if i := strings.Index(rawMinAge, "+"); i >= 0 {
+				tolerance, err = manager.ParseRuleDuration(rawMinAge[i+1:])
+				if err != nil {
+					continue
+				}
+				rawMinAge = rawMinAge[:i]
+			}
+			minAge, err := manager.ParseRuleDuration(rawMinAge)
+			if err != nil || minAge <= 0 {
+				continue
+			}
+
+			rules = append(rules, &proto.Rule{
+				Count:         int32(count),
+				MinAge:        int32(minAge / manager.Day),
+				MinAgeSeconds: int64(minAge / time.Second),
+				Tolerance:     int64(tolerance / time.Second),
+			})
+		}
+	}
+	return rules
+}
+
+// formatRule returns the rule with the pattern of parseRules
+func formatRule(rule *proto.Rule) string {
+	minAge := time.Duration(rule.MinAge) * manager.Day
+	if rule.MinAgeSeconds > 0 {
+		minAge = time.Duration(rule.MinAgeSeconds) * time.Second
+	}
+	desc := fmt.Sprintf("%d.%v", rule.Count, manager.FormatRuleDuration(minAge))
+	if rule.Tolerance > 0 {
+		desc += "+" + manager.FormatRuleDuration(time.Duration(rule.Tolerance)*time.Second)
+	}
+	return desc
+}
+
+// printRulesReport displays the warnings about the rules, and their expected effect
+func printRulesReport(report *proto.RulesReport) {
+	if report == nil {
+		return
+	}
+	for _, w := range report.Warnings {
+		fmt.Printf("%v %v\n", fmt.Sprintf(ErrorColor, "warning ("+w.Type+"):"), w.Message)
+	}
+	retention := time.Duration(report.MaxRetention) * time.Second
+	fmt.Printf("expected files: %d, max retention: %v days\n", report.ExpectedFileCount, retention.Hours()/24)
+}
+
+// addCapsFlags adds the flags defining the caps of a project
+func addCapsFlags(cmd *cobra.Command) {
+	cmd.Flags().Int32("max-files", 0, "Max count of files of the project, the oldest files are removed first")
+	cmd.Flags().Int64("max-size", 0, "Max total size of the files of the project, in bytes")
+	cmd.Flags().Duration("max-age", 0, "Files older than this duration are removed, regardless of the rules (i.e 2160h)")
+	cmd.Flags().Int32("keep-newest", 0, "Count of the newest files always kept, regardless of their errors and of the caps")
+}
+
+// getCaps returns the caps defined by the flags
+func getCaps(cmd *cobra.Command) *proto.ProjectCaps {
+	maxFiles, _ := cmd.Flags().GetInt32("max-files")
+	maxSize, _ := cmd.Flags().GetInt64("max-size")
+	maxAge, _ := cmd.Flags().GetDuration("max-age")
+	keepNewest, _ := cmd.Flags().GetInt32("keep-newest")
+
+	return &proto.ProjectCaps{
+		MaxFiles:   maxFiles,
+		MaxBytes:   maxSize,
+		MaxAge:     int64(maxAge / time.Second),
+		KeepNewest: keepNewest,
+	}
+}
+
+// formatCaps returns a short description of the caps, "-" if none
+func formatCaps(caps *proto.ProjectCaps) string {
+	if caps == nil {
+		return "-"
+	}
+	desc := []string{}
+	if caps.MaxFiles > 0 {
+		desc = append(desc, fmt.Sprintf("max %d files", caps.MaxFiles))
+	}
+	if caps.MaxBytes > 0 {
+		desc = append(desc, fmt.Sprintf("max %d bytes", caps.MaxBytes))
+	}
+	if caps.MaxAge > 0 {
+		desc = append(desc, fmt.Sprintf("max age %v", time.Duration(caps.MaxAge)*time.Second))
+	}
+	if caps.KeepNewest > 0 {
+		desc = append(desc, fmt.Sprintf("keep %d newest", caps.KeepNewest))
+	}
+	if len(desc) == 0 {
+		return "-"
+	}
+	return strings.Join(desc, ", ")
+}
+
+func init() {
+	rootCmd.AddCommand(rulesCmd)
+}
@@ -0,0 +1,196 @@
+/*
+Copyright © 2019 Matthieu MARTIN <matthieu@agence-webup.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/agence-webup/backr/manager/proto"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// rulesSimulateCmd replays the process day by day with the specified rules
+var rulesSimulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Simulate what rules do over a long period",
+	Long: `Replay the process day by day with the specified rules, on synthetic uploads
+or on the current files of a project, and display the storage used over time,
+the alerts raised, and the files surviving at the end.`,
+	Example: `  backrctl rules simulate -r 3.1 -r 2.15 --days 365
+  backrctl rules simulate -r 3.1 -r 2.15 --gap 100:104 --shrink-from 200 --shrink-ratio 0.1
+  backrctl rules simulate -r 3.1 -r 2.15 --project project1 --no-upload`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		rawRules, err := cmd.Flags().GetStringSlice("rule")
+		if err != nil {
+			fmt.Printf("unable to get 'rule' params: %v\n", err)
+			os.Exit(1)
+		}
+		rules := parseRules(rawRules)
+		if len(rules) == 0 {
+			fmt.Println("You must provide at least one rule.")
+			os.Exit(1)
+		}
+
+		flags := cmd.Flags()
+		days, _ := flags.GetInt32("days")
+		projectName, _ := flags.GetString("project")
+		noUpload, _ := flags.GetBool("no-upload")
+		interval, _ := flags.GetDuration("interval")
+		size, _ := flags.GetInt64("size")
+		rawGaps, _ := flags.GetStringSlice("gap")
+		shrinkFrom, _ := flags.GetInt32("shrink-from")
+		shrinkRatio, _ := flags.GetFloat64("shrink-ratio")
+		every, _ := flags.GetInt("every")
+
+		req := &proto.SimulateRulesRequest{Rules: rules, Days: days, ProjectName: projectName}
+		if !noUpload {
+			req.Schedule = &proto.UploadSchedule{
+				Interval:    int64(interval / time.Second),
+				Size:        size,
+				ShrinkFrom:  shrinkFrom,
+				ShrinkRatio: shrinkRatio,
+			}
+			for _, g := range rawGaps {
+				gap, err := parseDayRange(g)
+				if err != nil {
+					fmt.Printf("invalid gap '%v': %v\n", g, err)
+					os.Exit(1)
+				}
+				req.Schedule.Gaps = append(req.Schedule.Gaps, gap)
+			}
+		}
+
+		addr := viper.GetString("endpoint")
+		conn, err := grpcConnect(addr)
+		if err != nil {
+			fmt.Println("unable to dial to addr")
+			os.Exit(1)
+		}
+		defer conn.Close()
+
+		client := proto.NewBackrApiClient(conn)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		resp, err := client.SimulateRules(ctx, req)
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+
+		printSimulation(resp, every)
+	},
+}
+
+// parseDayRange parses a range of days with this pattern: FROM:TO (i.e 100:104)
+func parseDayRange(raw string) (*proto.DayRange, error) {
+	comps := strings.Split(raw, ":")
+	if len(comps) != 2 {
+		return nil, fmt.Errorf("expected FROM:TO")
+	}
+	from, err := strconv.ParseInt(comps[0], 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	to, err := strconv.ParseInt(comps[1], 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.DayRange{From: int32(from), To: int32(to)}, nil
+}
+
+// printSimulation displays a day every `every` days, and each day where the alerts change
+func printSimulation(resp *proto.SimulateRulesResponse, every int) {
+	if every <= 0 {
+		every = 1
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 1, 1, 3, ' ', 0)
+	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t\n", "DAY", "DATE", "FILES", "SIZE", "REMOVED", "ALERTS")
+
+	maxCount := int32(0)
+	maxSize := int64(0)
+	daysWithAlerts := 0
+	previousAlerts := ""
+	for i, day := range resp.Days {
+		if day.FileCount > maxCount {
+			maxCount = day.FileCount
+		}
+		if day.TotalSize > maxSize {
+			maxSize = day.TotalSize
+		}
+		if len(day.Alerts) > 0 {
+			daysWithAlerts++
+		}
+
+		alerts := strings.Join(day.Alerts, ", ")
+		if i%every != 0 && alerts == previousAlerts && i != len(resp.Days)-1 {
+			continue
+		}
+
+		alertsTxt := "-"
+		if alerts != "" {
+			alertsTxt = fmt.Sprintf(ErrorColor, day.AlertLevel+": "+alerts)
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t\n", i, time.Unix(day.Date, 0).Format("2006-01-02"), day.FileCount, day.TotalSize, len(day.Removed), alertsTxt)
+		previousAlerts = alerts
+	}
+	w.Flush()
+	fmt.Println("")
+
+	fmt.Printf("\033[1;36m%s\033[0m\n", fmt.Sprintf("surviving files (%d)", len(resp.Survivors)))
+	fmt.Fprintf(w, "%v\t%v\t%v\t\n", "PATH", "DATE", "SIZE")
+	for _, f := range resp.Survivors {
+		fmt.Fprintf(w, "%v\t%v\t%v\t\n", f.Path, time.Unix(f.Date, 0).Format("2006-01-02 15:04"), f.Size)
+	}
+	w.Flush()
+	fmt.Println("")
+
+	fmt.Printf("max files: %d, max size: %d, days with alerts: %d/%d\n", maxCount, maxSize, daysWithAlerts, len(resp.Days))
+}
+
+func init() {
+	rulesCmd.AddCommand(rulesSimulateCmd)
+
+	rulesSimulateCmd.Flags().StringSliceP("rule", "r", []string{}, "Define a rule with this pattern: COUNT.MIN_AGE[+TOLERANCE], the min age is a count of days or a duration (i.e -r 3.1 -r 4.6h+30m)")
+	rulesSimulateCmd.Flags().Int32("days", 365, "Count of simulated days")
+	rulesSimulateCmd.Flags().StringP("project", "p", "", "Start with the current files of this project")
+	rulesSimulateCmd.Flags().Bool("no-upload", false, "Simulate no upload (only with --project)")
+	rulesSimulateCmd.Flags().Duration("interval", 24*time.Hour, "Delay between two uploads (at least 1h)")
+	rulesSimulateCmd.Flags().Int64("size", 1<<30, "Size of the uploaded files, in bytes")
+	rulesSimulateCmd.Flags().StringSlice("gap", []string{}, "Days without upload with this pattern: FROM:TO (i.e --gap 100:104)")
+	rulesSimulateCmd.Flags().Int32("shrink-from", 0, "Day from which the size of the uploaded files shrinks")
+	rulesSimulateCmd.Flags().Float64("shrink-ratio", 0.1, "Ratio applied to the size of the uploaded files, from --shrink-from")
+	rulesSimulateCmd.Flags().Int("every", 7, "Display a day every N days (the days where the alerts change are always displayed)")
+
+	rulesSimulateCmd.MarkFlagRequired("rule")
+}
@@ -0,0 +1,83 @@
+/*
+Copyright © 2019 Matthieu MARTIN <matthieu@agence-webup.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/agence-webup/backr/manager/proto"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// trashListCmd lists the files of the trash
+var trashListCmd = &cobra.Command{
+	Use:     "list [PROJECT_NAME]",
+	Short:   "List the files of the trash, or the ones of the specified project",
+	Long:    ``,
+	Aliases: []string{"ls"},
+	Run: func(cmd *cobra.Command, args []string) {
+
+		addr := viper.GetString("endpoint")
+		conn, err := grpcConnect(addr)
+		if err != nil {
+			fmt.Println("unable to dial to addr")
+			os.Exit(1)
+		}
+		defer conn.Close()
+
+		client := proto.NewBackrApiClient(conn)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		projectName := ""
+		if len(args) == 1 {
+			projectName = args[0]
+		}
+
+		resp, err := client.ListTrash(ctx, &proto.ListTrashRequest{ProjectName: projectName})
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if len(resp.Files) == 0 {
+			fmt.Println("empty list")
+		} else {
+			w := tabwriter.NewWriter(os.Stdout, 1, 1, 3, ' ', 0)
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t\n", "PATH", "DATE", "SIZE", "TRASHED AT", "PURGED AT")
+			for _, f := range resp.Files {
+				fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t\n", f.File.Path, time.Unix(f.File.Date, 0), f.File.Size, time.Unix(f.TrashedAt, 0), time.Unix(f.PurgeAt, 0))
+			}
+			w.Flush()
+		}
+	},
+}
+
+func init() {
+	trashCmd.AddCommand(trashListCmd)
+}
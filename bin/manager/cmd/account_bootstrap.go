@@ -0,0 +1,79 @@
+// Copyright © 2018 Matthieu MARTIN <matthieu@agence-webup.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/config"
+	"github.com/agence-webup/backr/manager/repositories/bolt"
+	"github.com/spf13/cobra"
+)
+
+// accountBootstrapCmd creates the first account, operating directly on the Bolt file
+var accountBootstrapCmd = &cobra.Command{
+	Use:   "bootstrap",
+	Short: "Create the first account, when no account exists yet",
+	Long: `Create the first account, operating directly on the Bolt file.
+The daemon must be stopped, because the Bolt file cannot be opened by 2 processes.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		username, err := cmd.Flags().GetString("username")
+		if err != nil {
+			fmt.Println("unable to get 'username' flag")
+			os.Exit(1)
+		}
+
+		config := config.Get()
+
+		db, err := openBoltDB(config.Bolt.Filepath)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		defer db.Close()
+
+		accountRepo := bolt.NewAccountRepository(db)
+
+		accounts, err := accountRepo.List()
+		if err != nil {
+			fmt.Printf("unable to list accounts: %v\n", err)
+			os.Exit(1)
+		}
+		if len(accounts) > 0 {
+			fmt.Println("an account already exists: use `backrctl account create` with an authenticated account")
+			os.Exit(1)
+		}
+
+		password, err := accountRepo.Create(username, manager.RoleAdmin, nil)
+		if err != nil {
+			fmt.Printf("unable to create account: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Println("password:")
+		fmt.Println(password)
+	},
+}
+
+func init() {
+	accountCmd.AddCommand(accountBootstrapCmd)
+
+	accountBootstrapCmd.Flags().StringP("username", "u", "", "Username of the first account")
+
+	accountBootstrapCmd.MarkFlagRequired("username")
+}
@@ -0,0 +1,71 @@
+// Copyright © 2018 Matthieu MARTIN <matthieu@agence-webup.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/agence-webup/backr/manager/repositories/bolt"
+	"github.com/rs/zerolog/log"
+	"go.etcd.io/bbolt"
+)
+
+// openBoltDB opens the Bolt DB file, creating it if needed
+func openBoltDB(filepath string) (*bbolt.DB, error) {
+	if _, err := os.Stat(filepath); os.IsNotExist(err) {
+		_, err := os.Create(filepath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create BoltDB file: %v", err)
+		}
+	}
+
+	db, err := bbolt.Open(filepath, 0666, &bbolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("unable to open BoltDB file (is the daemon running?): %v", err)
+	}
+
+	return db, nil
+}
+
+// migrateBoltDB applies the pending migrations to the DB.
+// The DB file is copied next to it before migrating.
+func migrateBoltDB(db *bbolt.DB, filepath string) error {
+	version, err := bolt.SchemaVersion(db)
+	if err != nil {
+		return fmt.Errorf("unable to get schema version: %v", err)
+	}
+
+	pending, err := bolt.PendingMigrations(db)
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	backupPath := fmt.Sprintf("%v.v%d-%v.bak", filepath, version, time.Now().Format("20060102-150405"))
+	applied, err := bolt.Migrate(db, backupPath)
+	for _, m := range applied {
+		log.Info().Int("version", m.Version).Str("description", m.Description).Msg("db: migration applied")
+	}
+	if err != nil {
+		return fmt.Errorf("unable to migrate the DB (a backup is available at %v): %v", backupPath, err)
+	}
+	log.Info().Str("backup", backupPath).Int("version", bolt.LatestSchemaVersion()).Msg("db: migrated")
+
+	return nil
+}
@@ -16,10 +16,14 @@ package cmd
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"path"
 	"sync"
 	"syscall"
 	"time"
@@ -27,15 +31,22 @@ import (
 	"github.com/agence-webup/backr/manager/config"
 
 	"github.com/agence-webup/backr/manager/api"
+	"github.com/agence-webup/backr/manager/api/download"
+	"github.com/agence-webup/backr/manager/api/health"
+	"github.com/agence-webup/backr/manager/api/rest"
+	"github.com/agence-webup/backr/manager/api/web"
 	"github.com/agence-webup/backr/manager/proto"
 	"google.golang.org/grpc"
 
 	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/events"
 
 	"github.com/agence-webup/backr/manager/notifier/stateful"
 	"github.com/agence-webup/backr/manager/process"
 	"github.com/agence-webup/backr/manager/repositories/bolt"
 	"github.com/agence-webup/backr/manager/repositories/s3"
+	"github.com/agence-webup/backr/manager/selfbackup"
+	"github.com/agence-webup/backr/manager/trash"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 	"go.etcd.io/bbolt"
@@ -53,40 +64,59 @@ var startCmd = &cobra.Command{
 		config := config.Get()
 
 		// open a Bolt DB
-		if _, err := os.Stat(config.Bolt.Filepath); os.IsNotExist(err) {
-			_, err := os.Create(config.Bolt.Filepath)
-			if err != nil {
-				fmt.Printf("unable to create BoltDB file: %v\n", err.Error())
-				os.Exit(1)
-			}
-		}
-		db, err := bbolt.Open(config.Bolt.Filepath, 0666, &bbolt.Options{Timeout: 1 * time.Second})
+		db, err := openBoltDB(config.Bolt.Filepath)
 		if err != nil {
-			fmt.Printf("unable to open BoltDB file: %v\n", err.Error())
+			fmt.Println(err)
 			os.Exit(1)
 		}
 		defer db.Close()
 
+		// upgrade the schema of the DB
+		err = migrateBoltDB(db, config.Bolt.Filepath)
+		if err != nil {
+			log.Error().Err(err).Msg("unable to migrate the DB")
+			os.Exit(1)
+		}
+
 		// prepare tools & repositories
-		notifier := stateful.NewNotifier(db, config.SlackNotifier)
+		eventBus := events.NewBus(events.DefaultBacklogSize)
+		notificationRepo := bolt.NewNotificationRepository(db)
+		notifier := stateful.NewNotifier(db, config.SlackNotifier, notificationRepo, eventBus)
 		projectRepo := bolt.NewProjectRepository(db)
 		accountRepo := bolt.NewAccountRepository(db)
-		fileRepo, err := s3.NewFileRepository(config.S3)
+		auditRepo := bolt.NewAuditRepository(db)
+		downloadTokenRepo := bolt.NewDownloadTokenRepository(db)
+		maintenanceRepo := bolt.NewMaintenanceRepository(db)
+		storageRepo, err := s3.NewFileRepository(config.S3)
 		if err != nil {
 			log.Error().Str("err", err.Error()).Msg("unable to setup S3 file repository")
 			os.Exit(1)
 		}
 
+		// the removed files are kept in the trash during a grace period
+		fileTrash := trash.New(storageRepo, bolt.NewTrashRepository(db), config.Trash)
+		fileRepo := fileTrash.FileRepository()
+
+		// when no account exists yet, generate a one-time token allowing to create the first one
+		setupToken, err := prepareSetupToken(accountRepo)
+		if err != nil {
+			log.Error().Err(err).Msg("unable to prepare bootstrap")
+			os.Exit(1)
+		}
+
 		// prepare a context to allow cancelling of the 2 goroutines
 		ctx, cancel := context.WithCancel(context.Background())
 		wg := sync.WaitGroup{}
 
 		// each goroutine must increment WaitGroup counter
-		startProcess(ctx, &wg, projectRepo, fileRepo, notifier)
-		startAPI(ctx, &wg, config, projectRepo, fileRepo, accountRepo)
+		processHealth := &process.Health{}
+		startProcess(ctx, &wg, projectRepo, fileRepo, notifier, process.Options{Publisher: eventBus, DeletionGuard: config.DeletionGuard, Maintenance: maintenanceRepo}, processHealth)
+		startTrashPurge(ctx, &wg, fileTrash, maintenanceRepo, eventBus)
+		startSelfBackup(ctx, &wg, db, storageRepo, config.SelfBackup)
+		startAPI(ctx, &wg, config, projectRepo, fileRepo, accountRepo, auditRepo, notificationRepo, downloadTokenRepo, maintenanceRepo, fileTrash, eventBus, setupToken, processHealth)
 
 		// prepare chan for listening to SIGINT signal
-		sigint := make(chan os.Signal)
+		sigint := make(chan os.Signal, 1)
 		signal.Notify(sigint, syscall.SIGINT, syscall.SIGTERM)
 		// wait for SIGINT
 		<-sigint
@@ -105,7 +135,13 @@ func init() {
 	daemonCmd.AddCommand(startCmd)
 }
 
-func startProcess(ctx context.Context, wg *sync.WaitGroup, projectRepo manager.ProjectRepository, fileRepo manager.FileRepository, notifier manager.Notifier) {
+// processInterval is the delay between two runs of the process
+const processInterval = 1 * time.Minute
+
+// healthMaxAge is the age of the last run of the process after which the daemon is reported as stalled
+const healthMaxAge = 5 * processInterval
+
+func startProcess(ctx context.Context, wg *sync.WaitGroup, projectRepo manager.ProjectRepository, fileRepo manager.FileRepository, notifier manager.Notifier, options process.Options, processHealth *process.Health) {
 
 	wg.Add(1)
 
@@ -115,7 +151,7 @@ func startProcess(ctx context.Context, wg *sync.WaitGroup, projectRepo manager.P
 		defer wg.Done()
 
 		// prepare ticker
-		tick := time.NewTicker(1 * time.Minute)
+		tick := time.NewTicker(processInterval)
 
 		for {
 			select {
@@ -123,31 +159,97 @@ func startProcess(ctx context.Context, wg *sync.WaitGroup, projectRepo manager.P
 				referenceDate := time.Now()
 
 				log.Debug().Time("ref_date", referenceDate).Msg("tick: executing process...")
-				err := process.Execute(referenceDate, projectRepo, fileRepo)
-				if err != nil {
-					log.Error().Err(err).Msg("error executing process")
+				result := process.Tick(ctx, referenceDate, projectRepo, fileRepo, notifier, options)
+				processHealth.Record(result)
+				log.Debug().Dur("duration", result.Duration).Msg("tick: process & notify done")
+
+				log.Debug().Msg("---------------")
+
+			case <-ctx.Done():
+				tick.Stop()
+				log.Debug().Msg("process cleaning done.")
+				return
+			}
+		}
+	}()
+}
+
+// trashPurgeInterval is the delay between two purges of the files whose grace period is over
+const trashPurgeInterval = 1 * time.Hour
+
+func startTrashPurge(ctx context.Context, wg *sync.WaitGroup, fileTrash *trash.Trash, maintenanceRepo manager.MaintenanceRepository, publisher manager.EventPublisher) {
+
+	wg.Add(1)
+
+	log.Debug().Dur("interval", trashPurgeInterval).Msg("trash purge started")
+
+	go func() {
+		defer wg.Done()
+
+		tick := time.NewTicker(trashPurgeInterval)
+
+		for {
+			select {
+			case <-tick.C:
+				// the deletions are frozen during the maintenance
+				maintenance, err := maintenanceRepo.Get()
+				if err != nil || maintenance.IsActive(time.Now()) {
+					log.Info().Err(err).Msg("trash: purge skipped, maintenance mode")
+					continue
 				}
-				log.Debug().Msg("tick: process done")
 
-				log.Debug().Msg("tick: starting notify...")
-				err = process.Notify(projectRepo, notifier)
+				purged, err := fileTrash.PurgeExpired(time.Now())
+				for _, f := range purged {
+					publisher.Publish(manager.Event{Type: manager.EventFilePurged, ProjectName: path.Dir(f.File.Path), FilePath: f.File.Path})
+				}
 				if err != nil {
-					log.Error().Err(err).Msg("unable to execute process")
+					log.Error().Err(err).Msg("trash: unable to purge the expired files")
 				}
-				log.Debug().Msg("tick: notify done")
 
-				log.Debug().Msg("---------------")
+			case <-ctx.Done():
+				tick.Stop()
+				log.Debug().Msg("trash purge stopped")
+				return
+			}
+		}
+	}()
+}
+
+func startSelfBackup(ctx context.Context, wg *sync.WaitGroup, db *bbolt.DB, fileRepo manager.FileRepository, config manager.SelfBackupConfig) {
+	if config.Interval <= 0 {
+		log.Debug().Msg("self-backup disabled")
+		return
+	}
+
+	wg.Add(1)
+
+	log.Debug().Dur("interval", config.Interval).Msg("self-backup started")
+
+	go func() {
+		defer wg.Done()
+
+		tick := time.NewTicker(config.Interval)
+
+		for {
+			select {
+			case <-tick.C:
+				file, err := selfbackup.Execute(db, fileRepo, config.Keep, time.Now())
+				if err != nil {
+					log.Error().Err(err).Msg("self-backup: unable to export the manager state")
+					continue
+				}
+				log.Info().Str("path", file.Path).Int64("size", file.Size).Msg("self-backup: manager state exported")
 
 			case <-ctx.Done():
 				tick.Stop()
-				log.Debug().Msg("process cleaning done.")
+				log.Debug().Msg("self-backup stopped")
 				return
 			}
 		}
 	}()
 }
 
-func startAPI(ctx context.Context, wg *sync.WaitGroup, config manager.Config, projectRepo manager.ProjectRepository, fileRepo manager.FileRepository, accountRepo manager.AccountRepository) {
+func startAPI(ctx context.Context, wg *sync.WaitGroup, config manager.Config, projectRepo manager.ProjectRepository, fileRepo manager.FileRepository, accountRepo manager.AccountRepository, auditRepo manager.AuditRepository, notificationRepo manager.NotificationRepository, downloadTokenRepo manager.DownloadTokenRepository, maintenanceRepo manager.MaintenanceRepository, fileTrash *trash.Trash, eventBus *events.Bus, setupToken string, processHealth *process.Health) {
 
 	wg.Add(1)
 
@@ -158,7 +260,7 @@ func startAPI(ctx context.Context, wg *sync.WaitGroup, config manager.Config, pr
 		log.Fatal().Str("addr", addr).Err(err).Msg("grpc: failed to listen on addr")
 	}
 
-	backrSrv := api.NewServer(projectRepo, fileRepo, accountRepo, config.API)
+	backrSrv := api.NewServer(projectRepo, fileRepo, accountRepo, auditRepo, notificationRepo, downloadTokenRepo, maintenanceRepo, fileTrash, eventBus, config.API, setupToken)
 	srv := grpc.NewServer()
 	proto.RegisterBackrApiServer(srv, backrSrv)
 
@@ -168,11 +270,70 @@ func startAPI(ctx context.Context, wg *sync.WaitGroup, config manager.Config, pr
 		srv.Serve(lis)
 	}()
 
+	// REST/JSON gateway & web dashboard
+	var httpSrv *http.Server
+	if config.API.HTTPListenPort != "" {
+		restHandler := rest.NewHandler(backrSrv)
+		mux := http.NewServeMux()
+		mux.Handle("/v1/", restHandler)
+		mux.Handle("/openapi.json", restHandler)
+		mux.Handle(download.PathPrefix, download.NewHandler(downloadTokenRepo, fileRepo, auditRepo))
+		mux.Handle(health.Path, health.NewHandler(processHealth, healthMaxAge))
+		mux.Handle("/", web.NewHandler(backrSrv))
+
+		httpAddr := fmt.Sprintf("%s:%s", config.API.ListenIP, config.API.HTTPListenPort)
+		httpSrv = &http.Server{
+			Addr:    httpAddr,
+			Handler: mux,
+		}
+
+		log.Debug().Str("addr", httpAddr).Msg("REST API & dashboard started")
+
+		go func() {
+			err := httpSrv.ListenAndServe()
+			if err != nil && err != http.ErrServerClosed {
+				log.Fatal().Str("addr", httpAddr).Err(err).Msg("rest: failed to listen on addr")
+			}
+		}()
+	}
+
 	go func() {
 		defer wg.Done()
 
 		<-ctx.Done()
 		srv.GracefulStop()
+		if httpSrv != nil {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			httpSrv.Shutdown(shutdownCtx)
+			cancel()
+		}
 		log.Debug().Msg("API stopped")
 	}()
 }
+
+// prepareSetupToken returns a random token allowing to create the first account through the API.
+// An empty token is returned when an account already exists.
+// Listing accounts must succeed: the bootstrap is never enabled when the state is unknown.
+func prepareSetupToken(accountRepo manager.AccountRepository) (string, error) {
+	accounts, err := accountRepo.List()
+	if err != nil {
+		return "", fmt.Errorf("unable to list accounts: %w", err)
+	}
+	if len(accounts) > 0 {
+		return "", nil
+	}
+
+	b := make([]byte, 24)
+	_, err = rand.Read(b)
+	if err != nil {
+		return "", fmt.Errorf("unable to generate setup token: %w", err)
+	}
+	token := hex.EncodeToString(b)
+
+	log.Warn().Msg("no account exists: the API only allows to create the first account, using the setup token below")
+	fmt.Printf("\nsetup token: %s\n\n", token)
+	fmt.Println("  backrctl account create --username USERNAME --setup-token TOKEN")
+	fmt.Println("")
+
+	return token, nil
+}
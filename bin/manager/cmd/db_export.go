@@ -0,0 +1,77 @@
+// Copyright © 2018 Matthieu MARTIN <matthieu@agence-webup.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/agence-webup/backr/manager/config"
+	"github.com/agence-webup/backr/manager/repositories/bolt"
+	"github.com/spf13/cobra"
+)
+
+// dbExportCmd exports the state of the manager into an archive
+var dbExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the projects (with their state), accounts, notifications and audit trail into an archive",
+	Long: `Export the projects (with their state), accounts (with their hashed password),
+notifications history and audit trail into a portable archive (gzipped JSON).
+The archive can be restored using 'backr-manager db import'.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		output, err := cmd.Flags().GetString("output")
+		if err != nil {
+			fmt.Println("unable to get 'output' flag")
+			os.Exit(1)
+		}
+		if output == "" {
+			output = fmt.Sprintf("backr-manager-export-%v.json.gz", time.Now().Format("20060102-150405"))
+		}
+
+		config := config.Get()
+
+		db, err := openBoltDB(config.Bolt.Filepath)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		defer db.Close()
+
+		// the archive contains the hashed passwords
+		f, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err != nil {
+			fmt.Printf("unable to create the archive: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+
+		err = bolt.Export(db, f)
+		if err != nil {
+			fmt.Println(err)
+			os.Remove(output)
+			os.Exit(1)
+		}
+
+		fmt.Printf("exported to %v\n", output)
+	},
+}
+
+func init() {
+	dbCmd.AddCommand(dbExportCmd)
+
+	dbExportCmd.Flags().StringP("output", "o", "", "Path of the archive (default: backr-manager-export-DATE.json.gz)")
+}
@@ -0,0 +1,95 @@
+// Copyright © 2018 Matthieu MARTIN <matthieu@agence-webup.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/agence-webup/backr/manager/config"
+	"github.com/agence-webup/backr/manager/repositories/bolt"
+	"github.com/spf13/cobra"
+	"go.etcd.io/bbolt"
+)
+
+// dbImportCmd restores an archive produced by the export command
+var dbImportCmd = &cobra.Command{
+	Use:   "import ARCHIVE",
+	Short: "Restore an archive produced by 'backr-manager db export'",
+	Long: `Restore an archive produced by 'backr-manager db export' (or by the scheduled self-backup).
+By default, the DB must not contain any project or account.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		overwrite, err := cmd.Flags().GetBool("overwrite")
+		if err != nil {
+			fmt.Println("unable to get 'overwrite' flag")
+			os.Exit(1)
+		}
+
+		f, err := os.Open(args[0])
+		if err != nil {
+			fmt.Printf("unable to open the archive: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+
+		config := config.Get()
+
+		db, err := openBoltDB(config.Bolt.Filepath)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		defer db.Close()
+
+		// the migrations of the DB itself must be applied first
+		err = migrateBoltDB(db, config.Bolt.Filepath)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		if overwrite {
+			backupPath := fmt.Sprintf("%v.%v.bak", config.Bolt.Filepath, time.Now().Format("20060102-150405"))
+			err := db.View(func(tx *bbolt.Tx) error {
+				return tx.CopyFile(backupPath, 0600)
+			})
+			if err != nil {
+				fmt.Printf("unable to backup the DB before importing: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("the DB has been copied to %v\n", backupPath)
+		}
+
+		applied, err := bolt.Import(db, f, overwrite)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		for _, m := range applied {
+			fmt.Printf("migration applied: %d\t%v\n", m.Version, m.Description)
+		}
+		fmt.Println("archive imported")
+	},
+}
+
+func init() {
+	dbCmd.AddCommand(dbImportCmd)
+
+	dbImportCmd.Flags().Bool("overwrite", false, "Import even if the DB already contains projects or accounts (the records of the archive overwrite the existing ones)")
+}
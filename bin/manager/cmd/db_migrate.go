@@ -0,0 +1,52 @@
+// Copyright © 2018 Matthieu MARTIN <matthieu@agence-webup.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/agence-webup/backr/manager/config"
+	"github.com/spf13/cobra"
+)
+
+// dbMigrateCmd applies the pending migrations
+var dbMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Apply the pending migrations to the DB",
+	Long: `Apply the pending migrations to the DB, after copying the DB file next to it.
+The migrations are also applied when the daemon starts.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		config := config.Get()
+
+		db, err := openBoltDB(config.Bolt.Filepath)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		defer db.Close()
+
+		err = migrateBoltDB(db, config.Bolt.Filepath)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	dbCmd.AddCommand(dbMigrateCmd)
+}
@@ -0,0 +1,69 @@
+// Copyright © 2018 Matthieu MARTIN <matthieu@agence-webup.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/agence-webup/backr/manager/config"
+	"github.com/agence-webup/backr/manager/repositories/bolt"
+	"github.com/spf13/cobra"
+)
+
+// dbStatusCmd displays the schema version of the DB and the pending migrations
+var dbStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Display the schema version of the DB and the pending migrations",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		config := config.Get()
+
+		db, err := openBoltDB(config.Bolt.Filepath)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		defer db.Close()
+
+		version, err := bolt.SchemaVersion(db)
+		if err != nil {
+			fmt.Printf("unable to get schema version: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("schema version: %d (latest: %d)\n", version, bolt.LatestSchemaVersion())
+
+		pending, err := bolt.PendingMigrations(db)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if len(pending) == 0 {
+			fmt.Println("the DB is up to date")
+			return
+		}
+
+		fmt.Println("pending migrations:")
+		for _, m := range pending {
+			fmt.Printf("  %d\t%v\n", m.Version, m.Description)
+		}
+	},
+}
+
+func init() {
+	dbCmd.AddCommand(dbStatusCmd)
+}
@@ -1,11 +1,16 @@
 package manager
 
+import "time"
+
 // Config stores configuration used by the manager
 type Config struct {
 	S3            S3Config
 	Bolt          BoltConfig
 	API           APIConfig
 	SlackNotifier SlackNotifierConfig
+	SelfBackup    SelfBackupConfig
+	Trash         TrashConfig
+	DeletionGuard DeletionGuardConfig
 }
 
 // S3Config stores S3-like API configuration
@@ -28,6 +33,89 @@ type APIConfig struct {
 	ListenIP   string
 	ListenPort string
 	JWTSecret  string
+
+	// HTTPListenPort is the port of the REST/JSON gateway and the web dashboard,
+	// listening on ListenIP (disabled if empty)
+	HTTPListenPort string
+	// PublicURL is the base URL of the HTTP server, as seen by the users (e.g. https://backr.example.com).
+	// It is used to build the one-time download URLs.
+	PublicURL string
+
+	// URLMaxExpiry caps the lifetime of the file URLs requested by the users
+	URLMaxExpiry time.Duration
+
+	// brute-force protection: an username (or an IP) is locked out during LoginLockoutDuration
+	// when its failed login attempts reach the max count during LoginAttemptsWindow
+	LoginMaxAttempts      int
+	LoginMaxAttemptsPerIP int
+	LoginAttemptsWindow   time.Duration
+	LoginLockoutDuration  time.Duration
+
+	OIDC OIDCConfig
+}
+
+// OIDCConfig stores settings to accept ID tokens issued by an OpenID Connect provider.
+// OIDC is disabled when Issuer is empty.
+type OIDCConfig struct {
+	Issuer string
+	// ClientID is used by the CLI to perform the device flow
+	ClientID string
+	// Audience is the expected audience of ID tokens (ClientID if empty)
+	Audience string
+	// JWKSURL is the URL of the provider's keys (discovered using the issuer if empty)
+	JWKSURL string
+	// JWKSFile allows to load the keys from a local file, instead of JWKSURL
+	JWKSFile string
+	// UsernameClaim is the claim used as username: "sub" (default) or "email", which must be verified by the provider.
+	// The other claims (i.e. preferred_username) can be chosen by the users, so they are not supported.
+	UsernameClaim string
+	// RolesClaim is the claim containing the groups/roles of the user ("groups" if empty)
+	RolesClaim string
+	// RoleMapping maps a value of RolesClaim to a Role
+	RoleMapping map[string]string
+	// LinkLocalAccounts grants the role (and the projects) of the local account with the same username,
+	// when no role is mapped. Disabled by default.
+	LinkLocalAccounts bool
+}
+
+// SelfBackupConfig stores settings of the scheduled export of the manager state into the file repository
+type SelfBackupConfig struct {
+	// Interval between 2 exports (disabled if zero)
+	Interval time.Duration
+	// Keep is the count of exports kept in the file repository
+	Keep int
+}
+
+// SelfBackupFolder is the folder of the file repository receiving the exports of the manager state.
+// It is reserved: no project can use it.
+const SelfBackupFolder = "_backr-manager"
+
+// TrashConfig stores settings of the trash, keeping the removed files before deleting them permanently
+type TrashConfig struct {
+	// GracePeriod is the time a file is kept in the trash (DefaultTrashGracePeriod if zero)
+	GracePeriod time.Duration
+	// Prefix is the prefix of the file repository receiving the trashed files (DefaultTrashPrefix if empty)
+	Prefix string
+}
+
+// DefaultTrashGracePeriod is the time a file is kept in the trash, when not specified
+const DefaultTrashGracePeriod = 7 * 24 * time.Hour
+
+// DefaultTrashPrefix is the prefix of the file repository receiving the trashed files, when not specified.
+// A trashed file is moved to <prefix>/<folder>/<filename>, out of the folders of the projects.
+const DefaultTrashPrefix = "_backr-trash"
+
+// DeletionGuardConfig stores the checks blocking an abnormal deletion plan, until it is approved.
+// The zero value disables the guard.
+type DeletionGuardConfig struct {
+	// MaxPercent is the max percentage of the files of a project removed in one pass (no limit if zero)
+	MaxPercent int
+	// MaxFilesPerTick is the max count of files removed by a run of the process, across all projects (no limit if zero)
+	MaxFilesPerTick int
+	// BlockNewestFile blocks the removal of the newest file of a project
+	BlockNewestFile bool
+	// BlockOnRuleErrors blocks the removals of a project having a rule error
+	BlockOnRuleErrors bool
 }
 
 // SlackNotifierConfig stores settings to configure Slack notifier
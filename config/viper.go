@@ -14,6 +14,12 @@ func Get() manager.Config {
 
 // SetupFromViper get config from viper
 func SetupFromViper() {
+	// the deletion guard is enabled unless configured otherwise
+	viper.SetDefault("deletion_guard.max_percent", 50)
+	viper.SetDefault("deletion_guard.max_files_per_tick", 100)
+	viper.SetDefault("deletion_guard.block_newest_file", true)
+	viper.SetDefault("deletion_guard.block_on_rule_errors", true)
+
 	c := manager.Config{
 		S3: manager.S3Config{
 			Bucket:    viper.GetString("s3.bucket"),
@@ -30,10 +36,45 @@ func SetupFromViper() {
 			ListenIP:   viper.GetString("api.listen_ip"),
 			ListenPort: viper.GetString("api.listen_port"),
 			JWTSecret:  viper.GetString("api.jwt_secret"),
+
+			HTTPListenPort: viper.GetString("api.http_listen_port"),
+			PublicURL:      viper.GetString("api.public_url"),
+			URLMaxExpiry:   viper.GetDuration("api.url_max_expiry"),
+
+			LoginMaxAttempts:      viper.GetInt("api.login_max_attempts"),
+			LoginMaxAttemptsPerIP: viper.GetInt("api.login_max_attempts_per_ip"),
+			LoginAttemptsWindow:   viper.GetDuration("api.login_attempts_window"),
+			LoginLockoutDuration:  viper.GetDuration("api.login_lockout_duration"),
+
+			OIDC: manager.OIDCConfig{
+				Issuer:            viper.GetString("api.oidc.issuer"),
+				ClientID:          viper.GetString("api.oidc.client_id"),
+				Audience:          viper.GetString("api.oidc.audience"),
+				JWKSURL:           viper.GetString("api.oidc.jwks_url"),
+				JWKSFile:          viper.GetString("api.oidc.jwks_file"),
+				UsernameClaim:     viper.GetString("api.oidc.username_claim"),
+				RolesClaim:        viper.GetString("api.oidc.roles_claim"),
+				RoleMapping:       viper.GetStringMapString("api.oidc.role_mapping"),
+				LinkLocalAccounts: viper.GetBool("api.oidc.link_local_accounts"),
+			},
 		},
 		SlackNotifier: manager.SlackNotifierConfig{
 			WebhookURL: viper.GetString("slack.webhook_url"),
 		},
+		SelfBackup: manager.SelfBackupConfig{
+			Interval: viper.GetDuration("self_backup.interval"),
+			Keep:     viper.GetInt("self_backup.keep"),
+		},
+		Trash: manager.TrashConfig{
+			GracePeriod: viper.GetDuration("trash.grace_period"),
+			Prefix:      viper.GetString("trash.prefix"),
+		},
+		DeletionGuard: manager.DeletionGuardConfig{
+			MaxPercent:        viper.GetInt("deletion_guard.max_percent"),
+			MaxFilesPerTick:   viper.GetInt("deletion_guard.max_files_per_tick"),
+			BlockNewestFile:   viper.GetBool("deletion_guard.block_newest_file"),
+			BlockOnRuleErrors: viper.GetBool("deletion_guard.block_on_rule_errors"),
+		},
 	}
 
 	config = c
@@ -0,0 +1,177 @@
+package manager
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DateSourceType represents where the date of the files of a project is read
+type DateSourceType string
+
+const (
+	// DateSourceLastModified uses the date of the last modification of the stored file (default).
+	// It changes when the file is copied or uploaded again, i.e. during a bucket migration.
+	DateSourceLastModified DateSourceType = "last_modified"
+	// DateSourceFilename parses the date from the filename
+	DateSourceFilename DateSourceType = "filename"
+	// DateSourceMetadata parses the date from a metadata header of the stored file
+	DateSourceMetadata DateSourceType = "metadata"
+)
+
+// DateSource defines how the date of the files of a project is determined
+type DateSource struct {
+	// Type is DateSourceLastModified if empty
+	Type DateSourceType
+	// Pattern is the regexp extracting the date from the filename (DateSourceFilename):
+	// the first group is parsed if any, the whole match otherwise
+	Pattern string
+	// Header is the name of the metadata header (DateSourceMetadata), i.e. X-Amz-Meta-Backup-Date
+	Header string
+	// Layout is the format of the date (see time.Parse), RFC3339 if empty. The dates without timezone are UTC.
+	Layout string
+}
+
+// Validate checks the source, and returns an error describing the invalid setting
+func (source DateSource) Validate() error {
+	switch source.Type {
+	case "", DateSourceLastModified:
+		return nil
+	case DateSourceFilename:
+		if source.Pattern == "" {
+			return fmt.Errorf("a pattern is required to read the date from the filename")
+		}
+		_, err := regexp.Compile(source.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern: %v", err)
+		}
+		return nil
+	case DateSourceMetadata:
+		if source.Header == "" {
+			return fmt.Errorf("a header is required to read the date from the metadata")
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown date source '%v'", source.Type)
+}
+
+// IsLastModified returns true if the dates of the files are their last modification dates
+func (source DateSource) IsLastModified() bool {
+	return source.Type == "" || source.Type == DateSourceLastModified
+}
+
+// String returns a short description of the source
+func (source DateSource) String() string {
+	switch source.Type {
+	case DateSourceFilename:
+		return fmt.Sprintf("filename /%v/ (%v)", source.Pattern, source.getLayout())
+	case DateSourceMetadata:
+		return fmt.Sprintf("metadata %v (%v)", source.Header, source.getLayout())
+	}
+	return string(DateSourceLastModified)
+}
+
+func (source DateSource) getLayout() string {
+	if source.Layout == "" {
+		return time.RFC3339
+	}
+	return source.Layout
+}
+
+// DateReader reads the dates of the files from a source, its pattern being compiled once
+type DateReader struct {
+	source  DateSource
+	pattern *regexp.Regexp
+	repo    FileRepository
+}
+
+// Reader returns a reader of the dates of the files stored in repo.
+// An error is returned if the source is invalid.
+func (source DateSource) Reader(repo FileRepository) (*DateReader, error) {
+	err := source.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	reader := DateReader{source: source, repo: repo}
+	if source.Type == DateSourceFilename {
+		reader.pattern, err = regexp.Compile(source.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern: %v", err)
+		}
+	}
+	return &reader, nil
+}
+
+// FileDate returns the date of the file read from the source.
+// An error is returned if the date can't be read, the date of the file is not changed.
+func (reader *DateReader) FileDate(file File) (time.Time, error) {
+	source := reader.source
+
+	var raw string
+	switch source.Type {
+	case "", DateSourceLastModified:
+		return file.Date, nil
+
+	case DateSourceFilename:
+		match := reader.pattern.FindStringSubmatch(path.Base(file.Path))
+		if match == nil {
+			return file.Date, fmt.Errorf("the filename of '%v' doesn't match the pattern", file.Path)
+		}
+		raw = match[0]
+		if len(match) > 1 {
+			raw = match[1]
+		}
+
+	case DateSourceMetadata:
+		metadata, err := reader.repo.Metadata(file)
+		if err != nil {
+			return file.Date, err
+		}
+		raw = strings.TrimSpace(metadata.Get(source.Header))
+		if raw == "" {
+			return file.Date, fmt.Errorf("the header %v of '%v' is not set", source.Header, file.Path)
+		}
+
+	default:
+		return file.Date, fmt.Errorf("unknown date source '%v'", source.Type)
+	}
+
+	date, err := time.ParseInLocation(source.getLayout(), raw, time.UTC)
+	if err != nil {
+		return file.Date, fmt.Errorf("unable to parse the date of '%v': %v", file.Path, err)
+	}
+	return date, nil
+}
+
+// ResolveFileDates returns the files with the dates read from the source.
+// The files whose date can't be read keep their last modification date, their errors are returned.
+func (reader *DateReader) ResolveFileDates(files []File) ([]File, []error) {
+	if reader.source.IsLastModified() {
+		return files, nil
+	}
+
+	resolved := []File{}
+	errs := []error{}
+	for _, f := range files {
+		date, err := reader.FileDate(f)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		f.Date = date
+		resolved = append(resolved, f)
+	}
+	return resolved, errs
+}
+
+// ResolveFileDates returns the files with the dates read from the source (see DateReader).
+// If the source is invalid, the files keep their last modification date.
+func (source DateSource) ResolveFileDates(files []File, repo FileRepository) ([]File, []error) {
+	reader, err := source.Reader(repo)
+	if err != nil {
+		return files, []error{err}
+	}
+	return reader.ResolveFileDates(files)
+}
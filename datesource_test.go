@@ -0,0 +1,67 @@
+package manager
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// metadataRepository returns the same metadata for all the files
+type metadataRepository struct {
+	FileRepository
+	header http.Header
+}
+
+func (repo metadataRepository) Metadata(File) (http.Header, error) {
+	return repo.header, nil
+}
+
+func TestDateSourceFileDate(t *testing.T) {
+	lastModified := time.Date(2020, 01, 10, 8, 0, 0, 0, time.UTC)
+	file := File{Path: "project1/backup-20190325-0500.tar.gz", Date: lastModified}
+	repo := metadataRepository{header: http.Header{"X-Amz-Meta-Backup-Date": []string{"2019-03-24T05:00:00Z"}}}
+
+	tests := []struct {
+		name        string
+		source      DateSource
+		expected    time.Time
+		expectedErr bool
+	}{
+		{"last modified", DateSource{}, lastModified, false},
+		{"filename", DateSource{Type: DateSourceFilename, Pattern: `backup-(\d{8}-\d{4})`, Layout: "20060102-1504"}, time.Date(2019, 03, 25, 5, 0, 0, 0, time.UTC), false},
+		{"filename without group", DateSource{Type: DateSourceFilename, Pattern: `\d{8}`, Layout: "20060102"}, time.Date(2019, 03, 25, 0, 0, 0, 0, time.UTC), false},
+		{"filename not matching", DateSource{Type: DateSourceFilename, Pattern: `dump-(\d{8})`, Layout: "20060102"}, lastModified, true},
+		{"metadata", DateSource{Type: DateSourceMetadata, Header: "x-amz-meta-backup-date"}, time.Date(2019, 03, 24, 5, 0, 0, 0, time.UTC), false},
+		{"metadata not set", DateSource{Type: DateSourceMetadata, Header: "X-Amz-Meta-Date"}, lastModified, true},
+	}
+
+	for _, test := range tests {
+		reader, err := test.source.Reader(repo)
+		if err != nil {
+			t.Errorf("%v: unexpected validation error: %v", test.name, err)
+			continue
+		}
+
+		date, err := reader.FileDate(file)
+		if test.expectedErr != (err != nil) {
+			t.Errorf("%v: unexpected error: %v", test.name, err)
+		}
+		if !date.Equal(test.expected) {
+			t.Errorf("%v: expected %v, got %v", test.name, test.expected, date)
+		}
+	}
+}
+
+func TestDateSourceValidate(t *testing.T) {
+	invalid := []DateSource{
+		{Type: "unknown"},
+		{Type: DateSourceFilename},
+		{Type: DateSourceFilename, Pattern: "("},
+		{Type: DateSourceMetadata},
+	}
+	for _, source := range invalid {
+		if source.Validate() == nil {
+			t.Errorf("%+v must be rejected", source)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package manager
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNotFound is the kind of the errors returned when a record does not exist
+	ErrNotFound = errors.New("not found")
+	// ErrCorruptedRecord is the kind of the errors returned when a stored record can't be decoded
+	ErrCorruptedRecord = errors.New("corrupted record")
+	// ErrStorage is the kind of the errors returned when the storage fails (I/O error, full disk...)
+	ErrStorage = errors.New("storage failure")
+)
+
+// RepositoryError is returned by the repositories.
+// errors.Is(err, ErrNotFound), errors.Is(err, ErrCorruptedRecord) or errors.Is(err, ErrStorage)
+// can be used to check its kind.
+type RepositoryError struct {
+	// Kind is ErrNotFound, ErrCorruptedRecord or ErrStorage
+	Kind error
+	// Op describes the failed operation, e.g. "get project 'foo'"
+	Op string
+	// Keys are the keys of the records concerned by the error, if known
+	Keys []string
+	Err  error
+}
+
+func (e *RepositoryError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("%v: %v", e.Op, e.Kind)
+	}
+	return fmt.Sprintf("%v: %v: %v", e.Op, e.Kind, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *RepositoryError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the kind of the error is target
+func (e *RepositoryError) Is(target error) bool {
+	return e.Kind == target
+}
+
+// NewNotFoundError returns a RepositoryError of kind ErrNotFound
+func NewNotFoundError(op string, key string) error {
+	return &RepositoryError{Kind: ErrNotFound, Op: op, Keys: []string{key}}
+}
+
+// NewCorruptedRecordError returns a RepositoryError of kind ErrCorruptedRecord
+func NewCorruptedRecordError(op string, err error, keys ...string) error {
+	return &RepositoryError{Kind: ErrCorruptedRecord, Op: op, Keys: keys, Err: err}
+}
+
+// NewStorageError returns a RepositoryError of kind ErrStorage, or err itself if it is already a RepositoryError
+func NewStorageError(op string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var repoErr *RepositoryError
+	if errors.As(err, &repoErr) {
+		return err
+	}
+	return &RepositoryError{Kind: ErrStorage, Op: op, Err: err}
+}
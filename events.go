@@ -0,0 +1,65 @@
+package manager
+
+import "time"
+
+// Event represents something that happened in the manager:
+// a retention decision taken by the process, a change on a project, a sent notification...
+type Event struct {
+	// ID is set by the publisher, increasing with each event
+	ID          uint64
+	Type        EventType
+	Date        time.Time
+	ProjectName string
+	RuleID      RuleID
+	FilePath    string
+	Message     string
+}
+
+// EventType identifies the kind of an Event
+type EventType string
+
+const (
+	// EventFileSelected is emitted when a file is selected to be kept by a rule
+	EventFileSelected EventType = "file.selected"
+	// EventFileDeleted is emitted when a file is removed, not being needed by any rule.
+	// The file is kept in the trash during the grace period.
+	EventFileDeleted EventType = "file.deleted"
+	// EventFileUploaded is emitted when a file is uploaded through the API
+	EventFileUploaded EventType = "file.uploaded"
+	// EventFileRestored is emitted when a file is restored from the trash
+	EventFileRestored EventType = "file.restored"
+	// EventFilePurged is emitted when a file is permanently deleted from the trash
+	EventFilePurged EventType = "file.purged"
+	// EventFilePinned is emitted when a file is pinned, being kept regardless of the rules
+	EventFilePinned EventType = "file.pinned"
+	// EventFileUnpinned is emitted when a file is unpinned
+	EventFileUnpinned EventType = "file.unpinned"
+	// EventRuleErrorRaised is emitted when an error is associated to a rule, or to a file kept by a rule
+	EventRuleErrorRaised EventType = "rule.error_raised"
+	// EventRuleErrorCleared is emitted when the error of a rule is resolved
+	EventRuleErrorCleared EventType = "rule.error_cleared"
+	// EventProjectCreated is emitted when a project is created
+	EventProjectCreated EventType = "project.created"
+	// EventProjectUpdated is emitted when a project is changed, including its state
+	EventProjectUpdated EventType = "project.updated"
+	// EventProjectStatusChanged is emitted when a project is paused or resumed, including the automatic resumption
+	EventProjectStatusChanged EventType = "project.status_changed"
+	// EventDeletionPlanBlocked is emitted when the deletion guard blocks the removal of files of a project
+	EventDeletionPlanBlocked EventType = "deletion_plan.blocked"
+	// EventDeletionPlanApproved is emitted when a blocked deletion plan is approved
+	EventDeletionPlanApproved EventType = "deletion_plan.approved"
+	// EventCapConflict is emitted when files kept by the rules are removed because of the caps of the project
+	EventCapConflict EventType = "project.cap_conflict"
+	// EventProjectCorrupted is emitted when the record of a project can't be decoded, the project is skipped by the process
+	EventProjectCorrupted EventType = "project.corrupted"
+	// EventMaintenanceChanged is emitted when the maintenance mode is enabled or disabled, including its automatic end
+	EventMaintenanceChanged EventType = "maintenance.changed"
+	// EventNotificationSent is emitted when an alert is sent for a project
+	EventNotificationSent EventType = "notification.sent"
+)
+
+// EventPublisher publishes events to the interested subscribers.
+// Publish must not block.
+type EventPublisher interface {
+	Publish(event Event)
+}
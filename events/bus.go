@@ -0,0 +1,129 @@
+// Package events provides an in-memory event bus, dispatching the events
+// published by the process (and the API) to the subscribers of the event stream.
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+)
+
+// DefaultBacklogSize is the count of recent events kept in memory, allowing subscribers to replay them
+const DefaultBacklogSize = 1000
+
+// size of the buffer of each subscription: when it is full, the subscriber is too slow and it is dropped
+const subscriptionBufferSize = 256
+
+// NewBus returns an event bus keeping the last backlogSize events
+func NewBus(backlogSize int) *Bus {
+	if backlogSize <= 0 {
+		backlogSize = DefaultBacklogSize
+	}
+
+	return &Bus{
+		backlog:       make([]manager.Event, 0, backlogSize),
+		backlogSize:   backlogSize,
+		subscriptions: map[*Subscription]bool{},
+	}
+}
+
+// Bus dispatches the published events to the subscriptions.
+// It implements manager.EventPublisher.
+type Bus struct {
+	mutex sync.Mutex
+
+	lastID uint64
+
+	// backlog is a ring buffer storing the most recent events
+	backlog      []manager.Event
+	backlogSize  int
+	backlogStart int
+
+	subscriptions map[*Subscription]bool
+}
+
+// Subscription receives the events published after its creation
+type Subscription struct {
+	// Events is closed when the subscription is closed,
+	// or when the subscriber is too slow to consume the events
+	Events <-chan manager.Event
+
+	events chan manager.Event
+	bus    *Bus
+}
+
+// Publish sets the ID (and the date if missing) of the event, and dispatches it to the subscriptions
+func (bus *Bus) Publish(event manager.Event) {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	bus.lastID++
+	event.ID = bus.lastID
+	if event.Date.IsZero() {
+		event.Date = time.Now()
+	}
+
+	// append to the backlog, overwriting the oldest event when full
+	if len(bus.backlog) < bus.backlogSize {
+		bus.backlog = append(bus.backlog, event)
+	} else {
+		bus.backlog[bus.backlogStart] = event
+		bus.backlogStart = (bus.backlogStart + 1) % bus.backlogSize
+	}
+
+	for sub := range bus.subscriptions {
+		select {
+		case sub.events <- event:
+		default:
+			// don't block the publisher: drop the subscriber
+			delete(bus.subscriptions, sub)
+			close(sub.events)
+		}
+	}
+}
+
+// Subscribe returns the events of the backlog published after sinceID (at most replay events),
+// and a subscription receiving the next events.
+// The subscription must be closed when it's not used anymore.
+func (bus *Bus) Subscribe(sinceID uint64, replay int) ([]manager.Event, *Subscription) {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	// the backlog and the subscription are fetched atomically, so no event is missed
+	backlog := []manager.Event{}
+	if replay > 0 {
+		for i := 0; i < len(bus.backlog); i++ {
+			event := bus.backlog[(bus.backlogStart+i)%len(bus.backlog)]
+			if event.ID > sinceID {
+				backlog = append(backlog, event)
+			}
+		}
+		if len(backlog) > replay {
+			backlog = backlog[len(backlog)-replay:]
+		}
+	}
+
+	events := make(chan manager.Event, subscriptionBufferSize)
+	sub := &Subscription{
+		Events: events,
+		events: events,
+		bus:    bus,
+	}
+	bus.subscriptions[sub] = true
+
+	return backlog, sub
+}
+
+// Close stops the subscription
+func (sub *Subscription) Close() {
+	bus := sub.bus
+
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	if _, ok := bus.subscriptions[sub]; ok {
+		delete(bus.subscriptions, sub)
+		close(sub.events)
+	}
+}
@@ -0,0 +1,55 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/agence-webup/backr/manager"
+)
+
+func TestBusShouldReplayBacklogAndDispatchEvents(t *testing.T) {
+	bus := NewBus(3)
+
+	for i := 0; i < 5; i++ {
+		bus.Publish(manager.Event{Type: manager.EventFileSelected})
+	}
+
+	// only the last 3 events are kept
+	backlog, sub := bus.Subscribe(0, 10)
+	defer sub.Close()
+	if len(backlog) != 3 || backlog[0].ID != 3 || backlog[2].ID != 5 {
+		t.Fatalf("unexpected backlog: %+v", backlog)
+	}
+
+	// resume after an event, limiting the replay
+	backlog, other := bus.Subscribe(3, 1)
+	other.Close()
+	if len(backlog) != 1 || backlog[0].ID != 5 {
+		t.Fatalf("unexpected backlog: %+v", backlog)
+	}
+
+	bus.Publish(manager.Event{Type: manager.EventFileDeleted})
+	event := <-sub.Events
+	if event.ID != 6 || event.Type != manager.EventFileDeleted || event.Date.IsZero() {
+		t.Errorf("unexpected event: %+v", event)
+	}
+}
+
+func TestBusShouldDropSlowSubscribers(t *testing.T) {
+	bus := NewBus(10)
+	_, sub := bus.Subscribe(0, 0)
+
+	for i := 0; i < subscriptionBufferSize+1; i++ {
+		bus.Publish(manager.Event{Type: manager.EventFileSelected})
+	}
+
+	count := 0
+	for range sub.Events {
+		count++
+	}
+	if count != subscriptionBufferSize {
+		t.Errorf("expected %d buffered events before closing, got %d", subscriptionBufferSize, count)
+	}
+
+	// closing a dropped subscription is a no-op
+	sub.Close()
+}
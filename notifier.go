@@ -4,9 +4,13 @@ import "fmt"
 
 import "crypto/sha1"
 
+import "time"
+
 // Notifier defines methods required to notify alerts
 type Notifier interface {
 	Notify(statement ProjectErrorStatement) error
+	// NotifyGlobal alerts about an issue preventing the whole process to run, not related to a project
+	NotifyGlobal(statement GlobalErrorStatement) error
 }
 
 // ProjectErrorStatement stores global error state for a project
@@ -24,6 +28,31 @@ func (stmt *ProjectErrorStatement) GetUniqueID() string {
 	return fmt.Sprintf("%x", d)
 }
 
+// GlobalErrorType represents the kind of a global error
+type GlobalErrorType string
+
+const (
+	// GlobalErrorStorageUnreachable is raised when the files can't be listed, even after retries
+	GlobalErrorStorageUnreachable GlobalErrorType = "storage_unreachable"
+)
+
+// GlobalErrorStatement stores an error which is not related to a project
+type GlobalErrorStatement struct {
+	Reason  GlobalErrorType
+	Message string
+	Level   AlertLevel
+}
+
+// NotificationRecord stores a notification that has been sent, to keep an history
+type NotificationRecord struct {
+	ID          uint64
+	ProjectName string
+	Level       AlertLevel
+	Count       int
+	Reasons     []string
+	SentAt      time.Time
+}
+
 // AlertLevel represents a level of alert
 type AlertLevel int
 
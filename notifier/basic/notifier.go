@@ -33,3 +33,21 @@ func (n *basicNotifier) Notify(stmt manager.ProjectErrorStatement) error {
 
 	return nil
 }
+
+func (n *basicNotifier) NotifyGlobal(stmt manager.GlobalErrorStatement) error {
+	fmt.Println("")
+
+	switch stmt.Level {
+	case manager.Warning:
+		fmt.Println("*** ⚠️  WARNING ***")
+	case manager.Critic:
+		fmt.Println("*** 🆘  CRITICAL ***")
+	}
+
+	fmt.Printf("→ %s\n", stmt.Reason)
+	fmt.Println(stmt.Message)
+	fmt.Println("——————————————————————")
+	fmt.Println("")
+
+	return nil
+}
@@ -1,9 +1,10 @@
 package stateful
 
 import (
-	"bytes"
-	"encoding/gob"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/agence-webup/backr/manager"
@@ -13,17 +14,26 @@ import (
 
 var notificationBucket = []byte("notifications")
 
-// NewNotifier returns a notifier maintaining its state using bolt
-func NewNotifier(db *bolt.DB, config manager.SlackNotifierConfig) manager.Notifier {
+// NewNotifier returns a notifier maintaining its state using bolt.
+// Every sent notification is appended to history, and published as an event (publisher can be nil).
+func NewNotifier(db *bolt.DB, config manager.SlackNotifierConfig, history manager.NotificationRepository, publisher manager.EventPublisher) manager.Notifier {
 	return &notifier{
 		db:         db,
 		webhookURL: config.WebhookURL,
+		history:    history,
+		publisher:  publisher,
 	}
 }
 
 type notifier struct {
 	db         *bolt.DB
 	webhookURL string
+	history    manager.NotificationRepository
+	publisher  manager.EventPublisher
+
+	// global alerts are throttled in memory: they are related to the current run of the daemon
+	globalMutex  sync.Mutex
+	globalSentAt map[manager.GlobalErrorType]time.Time
 }
 
 type notification struct {
@@ -32,6 +42,41 @@ type notification struct {
 	SentAt    time.Time
 }
 
+// notificationDocument is the JSON layout of a notification in the bucket. Only the name of the project
+// is stored, so the records don't depend on the layout of manager.Project (see the migrations of the bolt repositories).
+type notificationDocument struct {
+	ProjectName string                                `json:"project_name"`
+	Count       int                                   `json:"count"`
+	Reasons     map[manager.RuleStateErrorType]string `json:"reasons"`
+	MaxLevel    manager.AlertLevel                    `json:"max_level"`
+	CreatedAt   time.Time                             `json:"created_at"`
+	SentAt      time.Time                             `json:"sent_at"`
+}
+
+func newNotificationDocument(notif notification) notificationDocument {
+	return notificationDocument{
+		ProjectName: notif.Statement.Project.Name,
+		Count:       notif.Statement.Count,
+		Reasons:     notif.Statement.Reasons,
+		MaxLevel:    notif.Statement.MaxLevel,
+		CreatedAt:   notif.CreatedAt,
+		SentAt:      notif.SentAt,
+	}
+}
+
+func (d notificationDocument) toNotification() notification {
+	return notification{
+		Statement: manager.ProjectErrorStatement{
+			Project:  manager.Project{Name: d.ProjectName},
+			Count:    d.Count,
+			Reasons:  d.Reasons,
+			MaxLevel: d.MaxLevel,
+		},
+		CreatedAt: d.CreatedAt,
+		SentAt:    d.SentAt,
+	}
+}
+
 // var delayBetweenSending = 6 * time.Hour
 var delayBetweenSending = 10 * time.Minute
 
@@ -78,13 +123,70 @@ func (n *notifier) Notify(statement manager.ProjectErrorStatement) error {
 	sendSlackMessage(n.webhookURL, notif)
 
 	// save notification
-	n.save(notif)
+	err = n.save(notif)
+	if err != nil {
+		log.Error().Err(err).Str("project_name", notif.Statement.Project.Name).Msg("unable to save notification")
+	}
+	n.record(notif)
+
+	if n.publisher != nil {
+		n.publisher.Publish(manager.Event{
+			Type:        manager.EventNotificationSent,
+			ProjectName: notif.Statement.Project.Name,
+			Message:     fmt.Sprintf("%v: %d issue(s)", notif.Statement.MaxLevel.String(), notif.Statement.Count),
+		})
+	}
 
 	log.Info().Str("project_name", notif.Statement.Project.Name).Msg("notify: backup issue")
 
 	return nil
 }
 
+func (n *notifier) NotifyGlobal(statement manager.GlobalErrorStatement) error {
+	n.globalMutex.Lock()
+	defer n.globalMutex.Unlock()
+
+	now := time.Now()
+	if sentAt, ok := n.globalSentAt[statement.Reason]; ok && now.Before(sentAt.Add(delayBetweenSending)) {
+		log.Info().Str("reason", string(statement.Reason)).Time("sent_at", sentAt).Msg("notify: global issue was already notified")
+		return nil
+	}
+
+	err := sendGlobalSlackMessage(n.webhookURL, statement, now)
+	if err != nil {
+		log.Error().Err(err).Str("reason", string(statement.Reason)).Msg("unable to send global alert to Slack")
+	}
+
+	if n.globalSentAt == nil {
+		n.globalSentAt = map[manager.GlobalErrorType]time.Time{}
+	}
+	n.globalSentAt[statement.Reason] = now
+
+	if n.history != nil {
+		record := manager.NotificationRecord{
+			Level:   statement.Level,
+			Count:   1,
+			Reasons: []string{fmt.Sprintf("%v: %v", statement.Reason, statement.Message)},
+			SentAt:  now,
+		}
+		err := n.history.Append(record)
+		if err != nil {
+			log.Error().Err(err).Str("reason", string(statement.Reason)).Msg("unable to record notification in history")
+		}
+	}
+
+	if n.publisher != nil {
+		n.publisher.Publish(manager.Event{
+			Type:    manager.EventNotificationSent,
+			Message: fmt.Sprintf("%v: %v", statement.Level.String(), statement.Reason),
+		})
+	}
+
+	log.Warn().Str("reason", string(statement.Reason)).Msg("notify: global issue")
+
+	return nil
+}
+
 func (n *notifier) getNotificationForStatement(statement manager.ProjectErrorStatement) (*notification, error) {
 	var notif *notification
 
@@ -96,11 +198,13 @@ func (n *notifier) getNotificationForStatement(statement manager.ProjectErrorSta
 
 		value := b.Get([]byte(statement.GetUniqueID()))
 		if value != nil {
-			buf := bytes.NewBuffer(value)
-			err := gob.NewDecoder(buf).Decode(&notif)
+			var document notificationDocument
+			err := json.Unmarshal(value, &document)
 			if err != nil {
-				return fmt.Errorf("unable to deserialize gob data: %v", err)
+				return fmt.Errorf("unable to deserialize json data: %v", err)
 			}
+			n := document.toNotification()
+			notif = &n
 		}
 
 		return nil
@@ -111,7 +215,7 @@ func (n *notifier) getNotificationForStatement(statement manager.ProjectErrorSta
 
 func (n *notifier) save(notif notification) error {
 
-	n.db.Update(func(tx *bolt.Tx) error {
+	return n.db.Update(func(tx *bolt.Tx) error {
 		// get or create the bucket
 		b, err := tx.CreateBucketIfNotExists(notificationBucket)
 		if err != nil {
@@ -119,20 +223,43 @@ func (n *notifier) save(notif notification) error {
 		}
 
 		// serialize notification
-		buf := bytes.Buffer{}
-		err = gob.NewEncoder(&buf).Encode(notif)
+		data, err := json.Marshal(newNotificationDocument(notif))
 		if err != nil {
-			return fmt.Errorf("unable to serialize gob data: %v", err)
+			return fmt.Errorf("unable to serialize json data: %v", err)
 		}
 
 		// put it into the bucket
-		err = b.Put([]byte(notif.Statement.GetUniqueID()), buf.Bytes())
+		err = b.Put([]byte(notif.Statement.GetUniqueID()), data)
 		if err != nil {
 			return fmt.Errorf("unable to put data in bucket: %v", err)
 		}
 
 		return nil
 	})
+}
 
-	return nil
+// record appends the sent notification to the history
+func (n *notifier) record(notif notification) {
+	if n.history == nil {
+		return
+	}
+
+	reasons := []string{}
+	for reason, detail := range notif.Statement.Reasons {
+		reasons = append(reasons, fmt.Sprintf("%v: %v", reason, detail))
+	}
+	sort.Strings(reasons)
+
+	record := manager.NotificationRecord{
+		ProjectName: notif.Statement.Project.Name,
+		Level:       notif.Statement.MaxLevel,
+		Count:       notif.Statement.Count,
+		Reasons:     reasons,
+		SentAt:      notif.SentAt,
+	}
+
+	err := n.history.Append(record)
+	if err != nil {
+		log.Error().Err(err).Str("project_name", record.ProjectName).Msg("unable to record notification in history")
+	}
 }
@@ -1,33 +1,27 @@
 package stateful
 
 import (
+	"bytes"
+	"encoding/gob"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/agence-webup/backr/manager"
+	boltrepo "github.com/agence-webup/backr/manager/repositories/bolt"
 	bolt "go.etcd.io/bbolt"
 )
 
-// func TestMain(m *testing.M) {
-
-// 	testDB = db
-
-// 	// execute tests
-// 	returnCode := m.Run()
-
-// 	// cleanup DB file
-// 	testDB.Close()
-// 	// os.Remove("notifier_test.db")
-
-// 	os.Exit(returnCode)
-// }
-
 type testContext struct {
-	DB *bolt.DB
+	DB  *bolt.DB
+	Dir string
 }
 
-func TestShouldNotifyWhenThereIsNoIssueAnymore(t *testing.T) {
+func TestShouldSaveNotificationForStatement(t *testing.T) {
 	ctx := setupTest()
+	defer teardownTest(ctx)
 
 	n := notifier{db: ctx.DB}
 
@@ -38,25 +32,124 @@ func TestShouldNotifyWhenThereIsNoIssueAnymore(t *testing.T) {
 	}
 	fakeExistingNotif := notification{
 		Statement: fakeStatement,
-		SentCount: 1,
+		SentAt:    time.Now(),
+	}
+	err := n.save(fakeExistingNotif)
+	if err != nil {
+		t.Fatalf("unable to save notification: %v", err)
 	}
-	n.save(fakeExistingNotif)
 
-	// teardownTest(ctx)
+	notif, err := n.getNotificationForStatement(fakeStatement)
+	if err != nil {
+		t.Fatalf("unable to get notification: %v", err)
+	}
+	if notif == nil || notif.Statement.Project.Name != "test" {
+		t.Errorf("expected to find the saved notification, got %v", notif)
+	}
+}
+
+func TestShouldRecordSentNotificationInHistory(t *testing.T) {
+	ctx := setupTest()
+	defer teardownTest(ctx)
+
+	history := boltrepo.NewNotificationRepository(ctx.DB)
+	n := notifier{db: ctx.DB, history: history}
+
+	fakeStatement := manager.ProjectErrorStatement{
+		Project:  manager.Project{Name: "test"},
+		MaxLevel: manager.Critic,
+		Count:    1,
+		Reasons:  map[manager.RuleStateErrorType]string{manager.RuleStateErrorObsolete: "test/backup.tar.gz"},
+	}
+
+	err := n.Notify(fakeStatement)
+	if err != nil {
+		t.Fatalf("unable to notify: %v", err)
+	}
+	// the statement was just notified: it must not be sent again
+	err = n.Notify(fakeStatement)
+	if err != nil {
+		t.Fatalf("unable to notify: %v", err)
+	}
+
+	records, err := history.List(0)
+	if err != nil {
+		t.Fatalf("unable to list history: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record in history, got %d", len(records))
+	}
+	if records[0].ProjectName != "test" || records[0].Level != manager.Critic || len(records[0].Reasons) != 1 {
+		t.Errorf("unexpected record: %+v", records[0])
+	}
+}
+
+func TestShouldReadMigratedGobNotification(t *testing.T) {
+	ctx := setupTest()
+	defer teardownTest(ctx)
+
+	n := notifier{db: ctx.DB}
+
+	// a notification written by the previous versions, embedding the whole project
+	sentAt := time.Date(2019, 8, 16, 2, 0, 0, 0, time.UTC)
+	statement := manager.ProjectErrorStatement{
+		Project:  manager.Project{Name: "test", Rules: []manager.Rule{{Count: 3, MinAge: manager.Day}}},
+		MaxLevel: manager.Critic,
+		Count:    1,
+		Reasons:  map[manager.RuleStateErrorType]string{manager.RuleStateErrorObsolete: "test/backup.tar.gz"},
+	}
+	err := ctx.DB.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(notificationBucket)
+		if err != nil {
+			return err
+		}
+		buf := bytes.Buffer{}
+		err = gob.NewEncoder(&buf).Encode(notification{Statement: statement, CreatedAt: sentAt, SentAt: sentAt})
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(statement.GetUniqueID()), buf.Bytes())
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = boltrepo.Migrate(ctx.DB, "")
+	if err != nil {
+		t.Fatalf("unable to migrate: %v", err)
+	}
+
+	notif, err := n.getNotificationForStatement(statement)
+	if err != nil {
+		t.Fatalf("unable to get notification: %v", err)
+	}
+	if notif == nil || notif.Statement.Project.Name != "test" || notif.Statement.MaxLevel != manager.Critic || !notif.SentAt.Equal(sentAt) {
+		t.Errorf("expected to find the migrated notification, got %+v", notif)
+	}
+	if notif != nil && notif.Statement.GetUniqueID() != statement.GetUniqueID() {
+		t.Errorf("expected the migrated statement to keep its ID, got %+v", notif.Statement)
+	}
 }
 
 func setupTest() testContext {
 	// create a test DB file
-	db, err := bolt.Open("notifier_test.db", 0666, &bolt.Options{Timeout: 1 * time.Second})
+	dir, err := ioutil.TempDir("", "notifier_test")
+	if err != nil {
+		panic(err)
+	}
+
+	db, err := bolt.Open(filepath.Join(dir, "notifier_test.db"), 0666, &bolt.Options{Timeout: 1 * time.Second})
 	if err != nil {
 		panic(err)
 	}
 
 	return testContext{
-		DB: db,
+		DB:  db,
+		Dir: dir,
 	}
 }
 
 func teardownTest(ctx testContext) {
 	ctx.DB.Close()
+	os.RemoveAll(ctx.Dir)
 }
@@ -59,6 +59,14 @@ type slackPayloadAttachmentField struct {
 }
 
 func sendSlackMessage(webhookURL string, notif notification) error {
+	return postSlackPayload(webhookURL, getPayload(notif))
+}
+
+func sendGlobalSlackMessage(webhookURL string, statement manager.GlobalErrorStatement, date time.Time) error {
+	return postSlackPayload(webhookURL, getGlobalPayload(statement, date))
+}
+
+func postSlackPayload(webhookURL string, payload slackPayload) error {
 
 	// check if a webhook URL is set
 	if webhookURL == "" {
@@ -66,7 +74,6 @@ func sendSlackMessage(webhookURL string, notif notification) error {
 	}
 
 	// prepare payload
-	payload := getPayload(notif)
 	data, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("cannot marshal payload into json: %w", err)
@@ -135,6 +142,35 @@ func getPayload(notif notification) slackPayload {
 	}
 }
 
+func getGlobalPayload(statement manager.GlobalErrorStatement, date time.Time) slackPayload {
+	return slackPayload{
+		Attachments: []slackPayloadAttachment{
+			slackPayloadAttachment{
+				Title:    "Backup manager issue",
+				Color:    getSlackColorForLevel(&statement.Level),
+				Fallback: fmt.Sprintf("%v: %s (%v)", statement.Level.String(), "Backup manager issue", statement.Reason),
+				Fields: []slackPayloadAttachmentField{
+					slackPayloadAttachmentField{
+						Title: "Reason",
+						Value: string(statement.Reason),
+						Short: true,
+					},
+					slackPayloadAttachmentField{
+						Title: "Date",
+						Value: date.UTC().Format(time.RFC822),
+						Short: true,
+					},
+					slackPayloadAttachmentField{
+						Title: "Message",
+						Value: statement.Message,
+						Short: false,
+					},
+				},
+			},
+		},
+	}
+}
+
 func getSlackColorForLevel(level *manager.AlertLevel) string {
 	if level != nil {
 		switch *level {
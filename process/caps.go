@@ -0,0 +1,98 @@
+package process
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+)
+
+// applyCaps removes from filesToKeep the files exceeding the caps of the project, the oldest first.
+// The protected files (pinned or newest files) are never removed.
+// It returns the conflicts: the files kept by the rules but removed because of the caps,
+// and the caps which can't be honored because of the protected files.
+func (pm *processManager) applyCaps(project *manager.Project, allFiles []manager.File, filesToKeep map[string]bool, protected map[string]bool, referenceDate time.Time) []string {
+	caps := project.Caps
+	conflicts := []string{}
+	if !caps.IsSet() {
+		return conflicts
+	}
+
+	// the files kept by the rules, before applying the caps
+	keptByRules := map[string]bool{}
+	for path := range filesToKeep {
+		if !protected[path] {
+			keptByRules[path] = true
+		}
+	}
+
+	// the kept files, from the oldest to the newest
+	newestFiles := manager.FilesSortedByDateDesc(allFiles)
+	kept := []manager.File{}
+	for i := len(newestFiles) - 1; i >= 0; i-- {
+		if filesToKeep[newestFiles[i].Path] {
+			kept = append(kept, newestFiles[i])
+		}
+	}
+
+	removedByCap := func(cap string, removed []manager.File) {
+		conflicting := 0
+		for _, f := range removed {
+			delete(filesToKeep, f.Path)
+			if keptByRules[f.Path] {
+				conflicting++
+			}
+		}
+		if len(removed) > 0 {
+			pm.logger.Info().Str("project", project.Name).Str("cap", cap).Int("count", len(removed)).Msg("files removed by cap")
+		}
+		if conflicting > 0 {
+			conflicts = append(conflicts, fmt.Sprintf("%v: %d file(s) kept by the rules are removed", cap, conflicting))
+		}
+	}
+
+	// max age: a hard limit, except for the protected files
+	if caps.MaxAge > 0 {
+		remaining := []manager.File{}
+		removed := []manager.File{}
+		for _, f := range kept {
+			if !protected[f.Path] && referenceDate.Sub(f.Date) > caps.MaxAge {
+				removed = append(removed, f)
+			} else {
+				remaining = append(remaining, f)
+			}
+		}
+		removedByCap(fmt.Sprintf("max age %v", caps.MaxAge), removed)
+		kept = remaining
+	}
+
+	// max files & max bytes: the oldest files are removed first
+	totalSize := int64(0)
+	for _, f := range kept {
+		totalSize += f.Size
+	}
+	exceeds := func(count int, size int64) bool {
+		return (caps.MaxFiles > 0 && count > caps.MaxFiles) || (caps.MaxBytes > 0 && size > caps.MaxBytes)
+	}
+
+	count := len(kept)
+	removed := []manager.File{}
+	for _, f := range kept {
+		if !exceeds(count, totalSize) {
+			break
+		}
+		if protected[f.Path] {
+			continue
+		}
+		removed = append(removed, f)
+		count--
+		totalSize -= f.Size
+	}
+	removedByCap(fmt.Sprintf("max %d files, max %d bytes", caps.MaxFiles, caps.MaxBytes), removed)
+
+	if exceeds(count, totalSize) {
+		conflicts = append(conflicts, fmt.Sprintf("the pinned and newest files exceed the caps: %d file(s), %d bytes", count, totalSize))
+	}
+
+	return conflicts
+}
@@ -0,0 +1,115 @@
+package process
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"sort"
+
+	"github.com/agence-webup/backr/manager"
+)
+
+// guardDeletionPlan checks if the removal of the files of the project is abnormal.
+// An abnormal plan is stored in the project, and the files are removed only once it is approved.
+// It returns true if the files can be removed.
+func (pm *processManager) guardDeletionPlan(project *manager.Project, allFiles []manager.File, filesToRemove []manager.File) bool {
+	if len(filesToRemove) == 0 {
+		project.DeletionPlan = nil
+		return true
+	}
+
+	reasons := pm.checkDeletionPlan(project, allFiles, filesToRemove)
+	if len(reasons) == 0 {
+		project.DeletionPlan = nil
+		return true
+	}
+
+	planID := deletionPlanID(filesToRemove)
+
+	// the same plan has been approved
+	if project.DeletionPlan.IsApproved() && project.DeletionPlan.ID == planID {
+		pm.logger.Info().Str("project", project.Name).Str("plan_id", planID).Str("approved_by", project.DeletionPlan.ApprovedBy).Msg("deletion plan approved")
+		project.DeletionPlan = nil
+		return true
+	}
+
+	// the plan is already waiting for an approval
+	if project.DeletionPlan != nil && project.DeletionPlan.ID == planID {
+		return false
+	}
+
+	project.DeletionPlan = &manager.DeletionPlan{
+		ID:        planID,
+		Files:     filesToRemove,
+		Reasons:   reasons,
+		CreatedAt: pm.referenceDate,
+	}
+
+	pm.logger.Warn().Str("project", project.Name).Str("plan_id", planID).Strs("reasons", reasons).Int("count", len(filesToRemove)).Msg("deletion plan blocked")
+	pm.publish(manager.Event{
+		Type:        manager.EventDeletionPlanBlocked,
+		ProjectName: project.Name,
+		Message:     fmt.Sprintf("plan %v: %d file(s), %v", planID, len(filesToRemove), reasons),
+	})
+
+	return false
+}
+
+// checkDeletionPlan returns the reasons why the removal of the files is abnormal, none if it can be done
+func (pm *processManager) checkDeletionPlan(project *manager.Project, allFiles []manager.File, filesToRemove []manager.File) []string {
+	guard := pm.deletionGuard
+	reasons := []string{}
+
+	if guard.MaxPercent > 0 && len(filesToRemove)*100 > guard.MaxPercent*len(allFiles) {
+		reasons = append(reasons, fmt.Sprintf("%d of %d files would be removed (max %d%%)", len(filesToRemove), len(allFiles), guard.MaxPercent))
+	}
+
+	if guard.MaxFilesPerTick > 0 && pm.removedCount+len(filesToRemove) > guard.MaxFilesPerTick {
+		reasons = append(reasons, fmt.Sprintf("%d files have already been removed by this run (max %d)", pm.removedCount, guard.MaxFilesPerTick))
+	}
+
+	if guard.BlockNewestFile && len(allFiles) > 0 {
+		newest := manager.FilesSortedByDateDesc(allFiles)[0]
+		for _, f := range filesToRemove {
+			if f.Path == newest.Path {
+				reasons = append(reasons, fmt.Sprintf("the newest file '%v' would be removed", newest.Path))
+				break
+			}
+		}
+	}
+
+	if guard.BlockOnRuleErrors && projectHasRuleError(project) {
+		reasons = append(reasons, "the project has a rule error")
+	}
+
+	return reasons
+}
+
+func projectHasRuleError(project *manager.Project) bool {
+	for _, rs := range project.State {
+		if rs.Error != nil {
+			return true
+		}
+		for _, f := range rs.Files {
+			if f.Error != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// deletionPlanID identifies a set of files
+func deletionPlanID(files []manager.File) string {
+	paths := []string{}
+	for _, f := range files {
+		paths = append(paths, f.Path)
+	}
+	sort.Strings(paths)
+
+	hash := sha1.New()
+	for _, p := range paths {
+		fmt.Fprintln(hash, p)
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil))[:12]
+}
@@ -0,0 +1,205 @@
+package process
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+)
+
+func TestDeletionGuardBlocksUntilApproval(t *testing.T) {
+	refDate := time.Date(2019, 03, 25, 8, 0, 0, 0, time.UTC)
+	rule := manager.Rule{Count: 1, MinAge: manager.Day}
+	initialNext := refDate.Add(-24 * time.Hour)
+
+	projectRepo := newMockProjectRepository([]manager.Project{
+		{
+			Name:  "project1",
+			Rules: []manager.Rule{rule},
+			State: manager.ProjectState{rule.GetID(): manager.RuleState{Rule: rule, Next: &initialNext}},
+		},
+	})
+	fileRepo := newMockFileRepository([]manager.File{
+		{Path: "project1/file0.tar.gz", Date: time.Date(2019, 03, 20, 5, 0, 0, 0, time.UTC), Size: 300},
+		{Path: "project1/file1.tar.gz", Date: time.Date(2019, 03, 25, 5, 0, 0, 0, time.UTC), Size: 300},
+	})
+	options := Options{DeletionGuard: manager.DeletionGuardConfig{MaxPercent: 40}}
+
+	err := Execute(context.Background(), refDate, projectRepo, fileRepo, options)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	project, _ := projectRepo.GetByName("project1")
+	plan := project.DeletionPlan
+	if plan == nil || len(plan.Files) != 1 || plan.Files[0].Path != "project1/file0.tar.gz" {
+		t.Fatalf("expected a blocked plan removing file0, got %+v", plan)
+	}
+	files, _ := fileRepo.GetAll()
+	if len(files) != 2 {
+		t.Fatalf("expected the files to be kept while the plan is blocked, got %v", files)
+	}
+
+	// the plan stays blocked on the next run
+	err = Execute(context.Background(), refDate.Add(time.Minute), projectRepo, fileRepo, options)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	files, _ = fileRepo.GetAll()
+	if len(files) != 2 {
+		t.Fatalf("expected the files to be kept while the plan is blocked, got %v", files)
+	}
+
+	project, _ = projectRepo.GetByName("project1")
+	project.DeletionPlan.ApprovedBy = "admin"
+	project.DeletionPlan.ApprovedAt = refDate
+	projectRepo.Save(*project)
+
+	err = Execute(context.Background(), refDate.Add(2*time.Minute), projectRepo, fileRepo, options)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	files, _ = fileRepo.GetAll()
+	if len(files) != 1 || files[0].Path != "project1/file1.tar.gz" {
+		t.Errorf("expected file0 to be removed once the plan is approved, got %v", files)
+	}
+	project, _ = projectRepo.GetByName("project1")
+	if project.DeletionPlan != nil {
+		t.Errorf("expected the plan to be cleared, got %+v", project.DeletionPlan)
+	}
+}
+
+func TestDeletionGuardKeepsApprovalGivenDuringRun(t *testing.T) {
+	refDate := time.Date(2019, 03, 25, 8, 0, 0, 0, time.UTC)
+	rule := manager.Rule{Count: 1, MinAge: manager.Day}
+	initialNext := refDate.Add(-24 * time.Hour)
+
+	projectRepo := newMockProjectRepository([]manager.Project{
+		{
+			Name:  "project1",
+			Rules: []manager.Rule{rule},
+			State: manager.ProjectState{rule.GetID(): manager.RuleState{Rule: rule, Next: &initialNext}},
+		},
+	})
+	fileRepo := newMockFileRepository([]manager.File{
+		{Path: "project1/file0.tar.gz", Date: time.Date(2019, 03, 20, 5, 0, 0, 0, time.UTC), Size: 300},
+		{Path: "project1/file1.tar.gz", Date: time.Date(2019, 03, 25, 5, 0, 0, 0, time.UTC), Size: 300},
+	})
+	options := Options{DeletionGuard: manager.DeletionGuardConfig{MaxPercent: 40}}
+
+	err := Execute(context.Background(), refDate, projectRepo, fileRepo, options)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	project, _ := projectRepo.GetByName("project1")
+	if project.DeletionPlan == nil {
+		t.Fatalf("expected a blocked plan")
+	}
+
+	// the plan is approved through the API once the process has read it, on the next selection
+	approvingRepo := &approvingProjectRepository{ProjectRepository: projectRepo, approvedBy: "admin", approvedAt: refDate}
+	err = Execute(context.Background(), refDate.Add(manager.Day), approvingRepo, fileRepo, options)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !approvingRepo.approved {
+		t.Fatalf("expected the plan to be approved during the run")
+	}
+
+	// the approval is not overwritten, and is considered by the next run only
+	project, _ = projectRepo.GetByName("project1")
+	if !project.DeletionPlan.IsApproved() {
+		t.Fatalf("expected the approval to be kept, got %+v", project.DeletionPlan)
+	}
+	files, _ := fileRepo.GetAll()
+	if len(files) != 2 {
+		t.Fatalf("expected the files to be kept during the run, got %v", files)
+	}
+
+	err = Execute(context.Background(), refDate.Add(manager.Day+time.Minute), projectRepo, fileRepo, options)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	files, _ = fileRepo.GetAll()
+	if len(files) != 1 || files[0].Path != "project1/file1.tar.gz" {
+		t.Errorf("expected file0 to be removed once the plan is approved, got %v", files)
+	}
+}
+
+// approvingProjectRepository approves the deletion plan right after the first read of the project, as the API would do
+type approvingProjectRepository struct {
+	manager.ProjectRepository
+	approvedBy string
+	approvedAt time.Time
+	approved   bool
+}
+
+func (repo *approvingProjectRepository) GetByName(name string) (*manager.Project, error) {
+	project, err := repo.ProjectRepository.GetByName(name)
+	if err != nil || project == nil || repo.approved {
+		return project, err
+	}
+
+	// the returned project is a copy, with the previous plan
+	if project.DeletionPlan != nil {
+		plan := *project.DeletionPlan
+		project.DeletionPlan = &plan
+	}
+
+	repo.approved = true
+	err = repo.ProjectRepository.Update(name, func(stored *manager.Project) error {
+		plan := *stored.DeletionPlan
+		plan.ApprovedBy = repo.approvedBy
+		plan.ApprovedAt = repo.approvedAt
+		stored.DeletionPlan = &plan
+		return nil
+	})
+	return project, err
+}
+
+func TestCheckDeletionPlan(t *testing.T) {
+	files := []manager.File{
+		{Path: "project1/file0.tar.gz", Date: time.Date(2019, 03, 20, 5, 0, 0, 0, time.UTC)},
+		{Path: "project1/file1.tar.gz", Date: time.Date(2019, 03, 21, 5, 0, 0, 0, time.UTC)},
+		{Path: "project1/file2.tar.gz", Date: time.Date(2019, 03, 22, 5, 0, 0, 0, time.UTC)},
+		{Path: "project1/file3.tar.gz", Date: time.Date(2019, 03, 23, 5, 0, 0, 0, time.UTC)},
+	}
+	ruleErr := manager.RuleStateError{Reason: manager.RuleStateErrorNoFile}
+
+	tests := []struct {
+		name          string
+		guard         manager.DeletionGuardConfig
+		removedCount  int
+		state         manager.ProjectState
+		filesToRemove []manager.File
+		blocked       bool
+	}{
+		{name: "disabled", filesToRemove: files, blocked: false},
+		{name: "under max percent", guard: manager.DeletionGuardConfig{MaxPercent: 50}, filesToRemove: files[:2], blocked: false},
+		{name: "above max percent", guard: manager.DeletionGuardConfig{MaxPercent: 50}, filesToRemove: files[:3], blocked: true},
+		{name: "under global cap", guard: manager.DeletionGuardConfig{MaxFilesPerTick: 5}, removedCount: 3, filesToRemove: files[:2], blocked: false},
+		{name: "above global cap", guard: manager.DeletionGuardConfig{MaxFilesPerTick: 5}, removedCount: 4, filesToRemove: files[:2], blocked: true},
+		{name: "older files", guard: manager.DeletionGuardConfig{BlockNewestFile: true}, filesToRemove: files[:3], blocked: false},
+		{name: "newest file", guard: manager.DeletionGuardConfig{BlockNewestFile: true}, filesToRemove: files[3:], blocked: true},
+		{
+			name:          "rule error",
+			guard:         manager.DeletionGuardConfig{BlockOnRuleErrors: true},
+			state:         manager.ProjectState{"rule1.1": manager.RuleState{Error: &ruleErr}},
+			filesToRemove: files[:1],
+			blocked:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pm := processManager{deletionGuard: tt.guard, removedCount: tt.removedCount}
+			project := manager.Project{Name: "project1", State: tt.state}
+
+			reasons := pm.checkDeletionPlan(&project, files, tt.filesToRemove)
+			if blocked := len(reasons) > 0; blocked != tt.blocked {
+				t.Errorf("expected blocked to be %v, got reasons %v", tt.blocked, reasons)
+			}
+		})
+	}
+}
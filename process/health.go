@@ -0,0 +1,143 @@
+package process
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/rs/zerolog/log"
+)
+
+// TickResult is the outcome of a run of the process
+type TickResult struct {
+	Date     time.Time
+	Duration time.Duration
+	// Err is the error returned by Execute, if any
+	Err error
+	// FailedProjects are the names of the projects which can't be processed
+	FailedProjects []string
+	// Maintenance is set when the run has been skipped because of the maintenance mode
+	Maintenance *manager.Maintenance
+}
+
+// Tick runs the process for the reference date, then sends the alerts.
+// When the storage is unreachable, a global alert is sent.
+// Nothing is done while the maintenance mode is active.
+func Tick(ctx context.Context, referenceDate time.Time, projectRepo manager.ProjectRepository, fileRepo manager.FileRepository, notifier manager.Notifier, options Options) TickResult {
+	start := time.Now()
+	result := TickResult{Date: referenceDate}
+
+	// when the maintenance mode can't be checked, the deletions are not done
+	maintenance, err := checkMaintenance(referenceDate, options)
+	if err != nil {
+		log.Error().Err(err).Msg("unable to check the maintenance mode")
+		result.Err = err
+		result.Duration = time.Since(start)
+		return result
+	}
+	if maintenance != nil {
+		log.Info().Str("reason", maintenance.Reason).Time("until", maintenance.Until).Msg("maintenance mode: process skipped")
+		result.Maintenance = maintenance
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	err = Execute(ctx, referenceDate, projectRepo, fileRepo, options)
+	if err != nil {
+		log.Error().Err(err).Msg("error executing process")
+		result.Err = err
+
+		var projectErrors ProjectErrors
+		if errors.As(err, &projectErrors) {
+			for name := range projectErrors {
+				result.FailedProjects = append(result.FailedProjects, name)
+			}
+			sort.Strings(result.FailedProjects)
+		}
+
+		if errors.Is(err, ErrStorageUnreachable) {
+			err := notifier.NotifyGlobal(manager.GlobalErrorStatement{
+				Reason:  manager.GlobalErrorStorageUnreachable,
+				Message: err.Error(),
+				Level:   manager.Critic,
+			})
+			if err != nil {
+				log.Error().Err(err).Msg("unable to notify that the storage is unreachable")
+			}
+		}
+	}
+
+	err = Notify(projectRepo, notifier)
+	if err != nil {
+		log.Error().Err(err).Msg("unable to notify")
+	}
+
+	result.Duration = time.Since(start)
+
+	return result
+}
+
+// checkMaintenance returns the maintenance mode if it's active at the date.
+// The maintenance mode is disabled once it is over.
+func checkMaintenance(date time.Time, options Options) (*manager.Maintenance, error) {
+	if options.Maintenance == nil {
+		return nil, nil
+	}
+
+	maintenance, err := options.Maintenance.Get()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the maintenance mode: %w", err)
+	}
+	if maintenance == nil {
+		return nil, nil
+	}
+	if maintenance.IsActive(date) {
+		return maintenance, nil
+	}
+
+	err = options.Maintenance.Set(nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to disable the maintenance mode: %w", err)
+	}
+	log.Info().Time("until", maintenance.Until).Msg("maintenance mode over")
+	if options.Publisher != nil {
+		options.Publisher.Publish(manager.Event{Type: manager.EventMaintenanceChanged, Message: "maintenance mode over"})
+	}
+
+	return nil, nil
+}
+
+// Health keeps the result of the last ticks, to report the health of the process.
+// It is safe for concurrent use.
+type Health struct {
+	mutex       sync.RWMutex
+	last        *TickResult
+	lastSuccess time.Time
+}
+
+// Record stores the result of a tick
+func (h *Health) Record(result TickResult) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.last = &result
+	if result.Err == nil && result.Maintenance == nil {
+		h.lastSuccess = result.Date
+	}
+}
+
+// Last returns the result of the last tick (nil if no tick has been run yet), and the date of the last successful tick
+func (h *Health) Last() (*TickResult, time.Time) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	if h.last == nil {
+		return nil, h.lastSuccess
+	}
+	last := *h.last
+	return &last, h.lastSuccess
+}
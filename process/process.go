@@ -1,11 +1,15 @@
 package process
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/agence-webup/backr/manager"
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
 
@@ -18,14 +22,26 @@ import (
 //     - if some files don't fulfill exactly the rule, an error is associated to the file, for this rule
 //     - if backup is needed but no file is available, an error is set to the rule
 //     - if some files are not needed anymore, by any rule, they are deleted, except if this prevents to fulfill the rule
-func Execute(referenceDate time.Time, projectRepo manager.ProjectRepository, fileRepo manager.FileRepository) error {
+//       or if the deletion guard blocks the deletion plan
+//   - the dates of the files are read from the date source of the project (last modification date by default)
+//   - the pinned files and the newest files (see Caps.KeepNewest) are always kept, the caps of the project
+//     remove the oldest files kept by the rules
+//   - a project paused-all is skipped, the files of a project paused-deletions are never removed
+//   - the listings are retried on failure, until ctx is done
+func Execute(ctx context.Context, referenceDate time.Time, projectRepo manager.ProjectRepository, fileRepo manager.FileRepository, options Options) error {
 	pm := processManager{
 		referenceDate: referenceDate,
 		projectRepo:   projectRepo,
 		fileRepo:      fileRepo,
+		publisher:     options.Publisher,
+		deletionGuard: options.DeletionGuard,
+		logger:        log.Logger,
+	}
+	if options.Logger != nil {
+		pm.logger = *options.Logger
 	}
 
-	err := pm.execute()
+	err := pm.execute(ctx)
 
 	return err
 }
@@ -33,10 +49,10 @@ func Execute(referenceDate time.Time, projectRepo manager.ProjectRepository, fil
 // Notify is responsible to send alerts, according to the state of each projects.
 // If an error is associated to a rule or a file linked to a rule, an alert will be sent
 func Notify(projectRepo manager.ProjectRepository, notifier manager.Notifier) error {
+	// the corrupted projects are skipped, they are reported by Execute
 	projects, err := projectRepo.GetAll()
-	if err != nil {
-		log.Fatal().AnErr("error", err).Msg("unable to fetch all projects")
-		return err
+	if err != nil && !errors.Is(err, manager.ErrCorruptedRecord) {
+		return fmt.Errorf("unable to fetch all projects: %w", err)
 	}
 
 	type projectError struct {
@@ -47,6 +63,11 @@ func Notify(projectRepo manager.ProjectRepository, notifier manager.Notifier) er
 
 	for _, project := range projects {
 
+		// a project paused-all is not monitored (a project paused-deletions is)
+		if project.Pause != nil && project.Pause.Status == manager.ProjectStatusPausedAll {
+			continue
+		}
+
 		projectErr := projectError{Reasons: map[manager.RuleStateErrorType]string{}}
 
 		for _, ruleState := range project.State {
@@ -77,6 +98,19 @@ func Notify(projectRepo manager.ProjectRepository, notifier manager.Notifier) er
 
 		}
 
+		// a blocked deletion plan requires an action
+		if project.DeletionPlan != nil && !project.DeletionPlan.IsApproved() {
+			projectErr.Count++
+			projectErr.Reasons[manager.RuleStateErrorDeletionBlocked] = fmt.Sprintf("plan %v: %d file(s), %v", project.DeletionPlan.ID, len(project.DeletionPlan.Files), strings.Join(project.DeletionPlan.Reasons, ", "))
+			projectErr.Level = manager.Critic
+		}
+
+		// files kept by the rules are removed because of the caps
+		if len(project.CapConflicts) > 0 {
+			projectErr.Count++
+			projectErr.Reasons[manager.RuleStateErrorCapConflict] = strings.Join(project.CapConflicts, ", ")
+		}
+
 		if projectErr.Count > 0 {
 			stmt := manager.ProjectErrorStatement{
 				Project:  project,
@@ -85,7 +119,10 @@ func Notify(projectRepo manager.ProjectRepository, notifier manager.Notifier) er
 				MaxLevel: projectErr.Level,
 			}
 
-			notifier.Notify(stmt)
+			err := notifier.Notify(stmt)
+			if err != nil {
+				log.Error().Err(err).Str("project", project.Name).Msg("unable to notify")
+			}
 		}
 
 	}
@@ -93,47 +130,189 @@ func Notify(projectRepo manager.ProjectRepository, notifier manager.Notifier) er
 	return nil
 }
 
+// ErrStorageUnreachable is returned by Execute when the files can't be listed, even after retries
+var ErrStorageUnreachable = errors.New("storage unreachable")
+
+// ProjectErrors is returned by Execute when some projects can't be processed,
+// the other projects being processed anyway. The errors are indexed by project name.
+type ProjectErrors map[string]error
+
+func (e ProjectErrors) Error() string {
+	names := []string{}
+	for name := range e {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	messages := []string{}
+	for _, name := range names {
+		messages = append(messages, fmt.Sprintf("%v: %v", name, e[name]))
+	}
+
+	return fmt.Sprintf("%d project(s) failed: %v", len(e), strings.Join(messages, "; "))
+}
+
+// retryDelays are the delays between the attempts of a listing
+var retryDelays = []time.Duration{1 * time.Second, 5 * time.Second, 15 * time.Second}
+
+// retry calls fn until it succeeds, waiting between the attempts according to retryDelays.
+// The wait is interrupted when ctx is done: the last error is returned.
+func retry(ctx context.Context, op string, fn func() error) error {
+	err := fn()
+	for _, delay := range retryDelays {
+		if err == nil {
+			return nil
+		}
+		log.Warn().Err(err).Str("op", op).Dur("delay", delay).Msg("retrying after failure")
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w (retry cancelled: %v)", err, ctx.Err())
+		case <-timer.C:
+		}
+
+		err = fn()
+	}
+	return err
+}
+
+// Options are the settings of a run of the process
+type Options struct {
+	// Publisher receives the retention decisions as events (can be nil)
+	Publisher manager.EventPublisher
+	// DeletionGuard blocks the abnormal deletion plans (disabled if zero)
+	DeletionGuard manager.DeletionGuardConfig
+	// Maintenance is checked by Tick before each run (can be nil)
+	Maintenance manager.MaintenanceRepository
+	// Logger receives the logs of the run (the global logger if nil)
+	Logger *zerolog.Logger
+}
+
 type processManager struct {
 	referenceDate time.Time
 	projectRepo   manager.ProjectRepository
 	fileRepo      manager.FileRepository
+	publisher     manager.EventPublisher
+	deletionGuard manager.DeletionGuardConfig
+	logger        zerolog.Logger
+	// removedCount is the count of files removed by this run, across all projects
+	removedCount int
 }
 
-func (pm *processManager) execute() error {
-	projects, err := pm.projectRepo.GetAll()
+func (pm *processManager) publish(event manager.Event) {
+	if pm.publisher != nil {
+		pm.publisher.Publish(event)
+	}
+}
+
+func (pm *processManager) execute(ctx context.Context) error {
+	var projects []manager.Project
+	err := retry(ctx, "fetch projects", func() error {
+		var err error
+		projects, err = pm.getProjects()
+		return err
+	})
 	if err != nil {
-		log.Fatal().AnErr("error", err).Msg("unable to fetch all projects")
+		return fmt.Errorf("unable to fetch all projects: %w", err)
 	}
 
 	// fetch backups
-	filesByFolder, err := pm.fileRepo.GetAllByFolder()
+	var filesByFolder manager.FilesByFolder
+	err = retry(ctx, "fetch files", func() error {
+		var err error
+		filesByFolder, err = pm.fileRepo.GetAllByFolder()
+		return err
+	})
 	if err != nil {
-		log.Fatal().AnErr("error", err).Msg("unable to fetch files from S3")
+		return fmt.Errorf("%w: unable to fetch files: %v", ErrStorageUnreachable, err)
 	}
 
-	// process for each project
+	// process for each project, a failing project must not prevent the other ones to be processed
+	projectErrors := ProjectErrors{}
 	for _, project := range projects {
 
 		err := pm.processForProject(&project, filesByFolder)
 		if err != nil {
-			return err
+			pm.logger.Error().Err(err).Str("project", project.Name).Msg("unable to process project")
+			projectErrors[project.Name] = err
 		}
 
 	}
 
+	if len(projectErrors) > 0 {
+		return projectErrors
+	}
+
 	return nil
 }
 
+// getProjects returns the projects to process. The corrupted projects are skipped
+// and reported, so they don't prevent the other projects from being processed.
+func (pm *processManager) getProjects() ([]manager.Project, error) {
+	projects, err := pm.projectRepo.GetAll()
+
+	var repoErr *manager.RepositoryError
+	if errors.Is(err, manager.ErrCorruptedRecord) && errors.As(err, &repoErr) {
+		for _, name := range repoErr.Keys {
+			pm.logger.Error().Str("project", name).Err(repoErr.Err).Msg("corrupted project, skipped")
+			pm.publish(manager.Event{Type: manager.EventProjectCorrupted, ProjectName: name, Message: "corrupted record, project skipped"})
+		}
+		return projects, nil
+	}
+
+	return projects, err
+}
+
 func (pm *processManager) processForProject(project *manager.Project, filesByFolder manager.FilesByFolder) error {
+	status := project.GetStatus(pm.referenceDate)
+
+	// the auto-resume date is reached
+	if project.Pause != nil && status == manager.ProjectStatusActive {
+		pm.logger.Info().Str("project", project.Name).Time("resume_at", project.Pause.ResumeAt).Msg("project resumed")
+		project.Pause = nil
+		err := pm.projectRepo.Update(project.Name, func(stored *manager.Project) error {
+			// the project may have been paused again since it has been fetched
+			if stored.Pause != nil && stored.GetStatus(pm.referenceDate) == manager.ProjectStatusActive {
+				stored.Pause = nil
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("unable to save project '%v': %w", project.Name, err)
+		}
+		pm.publish(manager.Event{Type: manager.EventProjectStatusChanged, ProjectName: project.Name, Message: string(manager.ProjectStatusActive) + " (auto-resume)"})
+	}
+
+	if status == manager.ProjectStatusPausedAll {
+		pm.logger.Info().Str("project", project.Name).Str("reason", project.Pause.Reason).Msg("project paused, skipped")
+		return nil
+	}
+
 	// sort the rules
 	rulesByMinAgeDesc := manager.RulesByMinAge(project.Rules)
 	sort.Sort(sort.Reverse(rulesByMinAgeDesc))
 
-	// get project files
-	files := filesByFolder[project.Name]
+	// get project files, with the dates read from the date source of the project.
+	// Reading the metadata requires a request for each file: the dates are read only when they are needed.
+	var files, filesByDateDesc []manager.File
+	getFiles := func() []manager.File {
+		if files == nil {
+			var dateErrs []error
+			files, dateErrs = project.DateSource.ResolveFileDates(filesByFolder[project.Name], pm.fileRepo)
+			if len(dateErrs) > 0 {
+				pm.logger.Warn().Str("project", project.Name).Str("date_source", project.DateSource.String()).Int("count", len(dateErrs)).AnErr("first_err", dateErrs[0]).Msg("unable to read the date of some files, the last modification date is used")
+			}
+			if files == nil {
+				files = []manager.File{}
+			}
 
-	// sort files by date (desc)
-	filesByDateDesc := manager.FilesSortedByDateDesc(files)
+			// sort files by date (desc)
+			filesByDateDesc = manager.FilesSortedByDateDesc(files)
+		}
+		return files
+	}
 
 	// to track if a file selection has been done
 	hasPerformedSelection := false
@@ -151,54 +330,118 @@ func (pm *processManager) processForProject(project *manager.Project, filesByFol
 				Files: []manager.SelectedFile{},
 			}
 		}
+		// the tolerance is not a part of the rule ID, it may have changed
+		ruleState.Rule = rule
 
 		// check if a backup is wanted by the rule
 		backupIsNeeded := ruleState.Check(pm.referenceDate)
 		if backupIsNeeded {
-			log.Info().Str("project", project.Name).Str("rule_id", string(rule.GetID())).Time("next_date", *ruleState.Next).Msg("backup needed. selecting files...")
+			pm.logger.Info().Str("project", project.Name).Str("rule_id", string(rule.GetID())).Time("next_date", *ruleState.Next).Msg("backup needed. selecting files...")
 
+			previousState := ruleState
+			previousState.Files = append([]manager.SelectedFile{}, ruleState.Files...)
+
+			getFiles()
 			pm.selectFilesToBackup(&ruleState, filesByDateDesc)
 			hasPerformedSelection = true
+
+			pm.publishSelectionEvents(project.Name, previousState, ruleState)
 		} else {
 			// logging
 			if ruleState.Next == nil {
-				log.Info().Str("project", project.Name).Str("rule_id", string(rule.GetID())).Msg("backup not needed. Next date is not set yet.")
+				pm.logger.Info().Str("project", project.Name).Str("rule_id", string(rule.GetID())).Msg("backup not needed. Next date is not set yet.")
 			} else {
-				log.Info().Str("project", project.Name).Str("rule_id", string(rule.GetID())).Time("next_date", *ruleState.Next).Time("ref_date", pm.referenceDate).Msg("backup not needed")
+				pm.logger.Info().Str("project", project.Name).Str("rule_id", string(rule.GetID())).Time("next_date", *ruleState.Next).Time("ref_date", pm.referenceDate).Msg("backup not needed")
 			}
 		}
 
-		// set the next backup date for fresh state
+		// set the next backup date for fresh state, waiting for the first files (at most 1 day)
 		if ruleState.Next == nil {
-			n := pm.referenceDate.Add(1 * time.Hour * 24)
+			delay := manager.Day
+			if rule.MinAge < delay {
+				delay = rule.MinAge
+			}
+			n := pm.referenceDate.Add(delay)
 			ruleState.Next = &n
-			log.Info().Time("next_date", n).Msg("set Next date")
+			pm.logger.Info().Time("next_date", n).Msg("set Next date")
 		}
 
 		// update state
 		project.UpdateState(id, ruleState)
 
-		// save project & state
-		pm.projectRepo.Save(*project)
+		// save state
+		err := pm.saveState(project)
+		if err != nil {
+			return err
+		}
 	}
 
-	// remove unused files, only if a file selection has been done
-	if hasPerformedSelection {
+	// the project may have been paused, or its deletion plan approved, during the run
+	err := pm.refreshProject(project)
+	if err != nil {
+		return err
+	}
+
+	// the files are monitored, but not removed
+	status = project.GetStatus(pm.referenceDate)
+	if status != manager.ProjectStatusActive {
+		pm.logger.Info().Str("project", project.Name).Str("reason", project.Pause.Reason).Msg("deletions paused, no file removed")
+		return nil
+	}
+
+	// remove unused files, only if a file selection has been done (or if a deletion plan has been approved)
+	if hasPerformedSelection || project.DeletionPlan.IsApproved() {
+		files := getFiles()
 		filesToRemove := pm.getFilesToRemove(project, files, pm.referenceDate)
-		log.Info().Str("project", project.Name).Int("count", len(filesToRemove)).Msg("files to be removed")
+		pm.logger.Info().Str("project", project.Name).Int("count", len(filesToRemove)).Msg("files to be removed")
 
+		previousPlan := project.DeletionPlan
+		allowed := pm.guardDeletionPlan(project, files, filesToRemove)
+		saved, err := pm.saveDeletionPlan(project, previousPlan)
+		if err != nil {
+			return err
+		}
+		if !saved {
+			pm.logger.Info().Str("project", project.Name).Msg("deletion plan changed during the run, no file removed")
+		}
+		if !allowed || !saved {
+			filesToRemove = []manager.File{}
+		}
+
+		removedFiles := []manager.File{}
+		var removeErr error
 		for _, f := range filesToRemove {
-			err := pm.fileRepo.RemoveFile(f)
+			removable, err := pm.isRemovable(project.Name, f)
+			if err != nil {
+				removeErr = fmt.Errorf("unable to check the project before removing '%v': %w", f.Path, err)
+				break
+			}
+			if !removable {
+				continue
+			}
+
+			err = pm.fileRepo.RemoveFile(f)
 			if err != nil {
-				log.Error().Str("project", project.Name).Str("path", f.Path).Msg("unable to remove file")
-				return fmt.Errorf("unable to remove file: %v", err)
+				pm.logger.Error().Str("project", project.Name).Str("path", f.Path).Msg("unable to remove file")
+				removeErr = fmt.Errorf("unable to remove file '%v': %w", f.Path, err)
+				break
 			}
+			removedFiles = append(removedFiles, f)
+			pm.removedCount++
+			pm.publish(manager.Event{Type: manager.EventFileDeleted, ProjectName: project.Name, FilePath: f.Path})
 		}
 
-		// save the state after removal
-		project.RemoveFilesFromState(filesToRemove)
-		// save project & state
-		pm.projectRepo.Save(*project)
+		// save the state after removal, keeping the files which are not removed yet
+		project.RemoveFilesFromState(removedFiles)
+		err = pm.saveState(project)
+		if err != nil {
+			return err
+		}
+		if removeErr != nil {
+			return removeErr
+		}
+
+		pm.publish(manager.Event{Type: manager.EventProjectUpdated, ProjectName: project.Name, Message: "state updated"})
 	}
 
 	// fmt.Println("")
@@ -208,6 +451,143 @@ func (pm *processManager) processForProject(project *manager.Project, filesByFol
 	return nil
 }
 
+// saveState saves the state of the rules and the caps conflicts of the project, the fields owned by the process.
+// The other fields (rules, pins, pause...) may be changed through the API during the run: they are
+// not overwritten, and the project is refreshed with their stored values.
+func (pm *processManager) saveState(project *manager.Project) error {
+	var saved manager.Project
+	err := pm.projectRepo.Update(project.Name, func(stored *manager.Project) error {
+		// the state of the rules removed during the run is dropped
+		state := manager.ProjectState{}
+		for _, rule := range stored.Rules {
+			id := rule.GetID()
+			if ruleState, ok := project.State[id]; ok {
+				state[id] = ruleState
+			} else if ruleState, ok := stored.State[id]; ok {
+				state[id] = ruleState
+			}
+		}
+		stored.State = state
+		stored.CapConflicts = project.CapConflicts
+		saved = *stored
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to save project '%v': %w", project.Name, err)
+	}
+
+	*project = saved
+	return nil
+}
+
+// refreshProject reloads the fields of the project which may be changed through the API during the run,
+// keeping the state computed by the process
+func (pm *processManager) refreshProject(project *manager.Project) error {
+	stored, err := pm.projectRepo.GetByName(project.Name)
+	if err != nil {
+		return fmt.Errorf("unable to fetch project '%v': %w", project.Name, err)
+	}
+	if stored == nil {
+		return fmt.Errorf("unable to fetch project '%v': %w", project.Name, manager.NewNotFoundError("get project", project.Name))
+	}
+
+	stored.State = project.State
+	stored.CapConflicts = project.CapConflicts
+	*project = *stored
+	return nil
+}
+
+// saveDeletionPlan saves the deletion plan decided by the guard, only if the stored plan is still the previous one:
+// a plan approved meanwhile is not overwritten, and is considered by the next run.
+// It returns false if the plan has not been saved.
+func (pm *processManager) saveDeletionPlan(project *manager.Project, previous *manager.DeletionPlan) (bool, error) {
+	saved := false
+	err := pm.projectRepo.Update(project.Name, func(stored *manager.Project) error {
+		if !sameDeletionPlan(stored.DeletionPlan, previous) {
+			return nil
+		}
+		stored.DeletionPlan = project.DeletionPlan
+		saved = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("unable to save project '%v': %w", project.Name, err)
+	}
+	return saved, nil
+}
+
+func sameDeletionPlan(a *manager.DeletionPlan, b *manager.DeletionPlan) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.ID == b.ID && a.ApprovedBy == b.ApprovedBy && a.ApprovedAt.Equal(b.ApprovedAt)
+}
+
+// isRemovable reloads the project right before the removal of a file:
+// the file may have been pinned, or the project paused, since the project has been fetched
+func (pm *processManager) isRemovable(projectName string, file manager.File) (bool, error) {
+	project, err := pm.projectRepo.GetByName(projectName)
+	if err != nil {
+		return false, err
+	}
+	if project == nil {
+		return false, nil
+	}
+
+	if status := project.GetStatus(pm.referenceDate); status != manager.ProjectStatusActive {
+		pm.logger.Info().Str("project", projectName).Str("path", file.Path).Str("status", string(status)).Msg("project paused during the run, file kept")
+		return false, nil
+	}
+
+	if pin := project.GetPin(file.Path, pm.referenceDate); pin != nil {
+		pm.logger.Info().Str("project", projectName).Str("path", file.Path).Str("reason", pin.Reason).Msg("file pinned during the run, kept")
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// publishSelectionEvents compares the state of a rule before and after a selection,
+// and publishes the newly selected files and the errors changes
+func (pm *processManager) publishSelectionEvents(projectName string, previous manager.RuleState, current manager.RuleState) {
+	ruleID := current.Rule.GetID()
+
+	previousFiles := map[string]manager.SelectedFile{}
+	for _, f := range previous.Files {
+		previousFiles[f.Path] = f
+	}
+
+	for _, f := range current.Files {
+		previousFile, existed := previousFiles[f.Path]
+		if !existed {
+			pm.publish(manager.Event{
+				Type:        manager.EventFileSelected,
+				ProjectName: projectName,
+				RuleID:      ruleID,
+				FilePath:    f.Path,
+				Message:     "expires " + f.Expiration.UTC().Format(time.RFC3339),
+			})
+		}
+
+		if f.Error != nil && (previousFile.Error == nil || previousFile.Error.Reason != f.Error.Reason) {
+			pm.publish(manager.Event{
+				Type:        manager.EventRuleErrorRaised,
+				ProjectName: projectName,
+				RuleID:      ruleID,
+				FilePath:    f.Path,
+				Message:     f.Error.Reason.String(),
+			})
+		}
+	}
+
+	switch {
+	case current.Error != nil && (previous.Error == nil || previous.Error.Reason != current.Error.Reason):
+		pm.publish(manager.Event{Type: manager.EventRuleErrorRaised, ProjectName: projectName, RuleID: ruleID, Message: current.Error.Reason.String()})
+	case current.Error == nil && previous.Error != nil:
+		pm.publish(manager.Event{Type: manager.EventRuleErrorCleared, ProjectName: projectName, RuleID: ruleID, Message: previous.Error.Reason.String()})
+	}
+}
+
 func (pm *processManager) selectFilesToBackup(ruleState *manager.RuleState, files []manager.File) {
 	// olderRefDate allows to go back to the past to collect
 	// previous files, if needed
@@ -215,7 +595,7 @@ func (pm *processManager) selectFilesToBackup(ruleState *manager.RuleState, file
 	olderRefDate := pm.referenceDate
 
 	if len(files) == 0 {
-		log.Debug().Caller().Msg("no file available")
+		pm.logger.Debug().Caller().Msg("no file available")
 		err := manager.RuleStateError{
 			Reason: manager.RuleStateErrorNoFile,
 		}
@@ -237,8 +617,8 @@ func (pm *processManager) selectFilesToBackup(ruleState *manager.RuleState, file
 			existingFilesByPath[f.Path] = f
 		}
 
-		log.Debug().Caller().Int("count", len(files)).Msgf("available files count")
-		log.Debug().Caller().Int("count", len(existingFilesByExpDesc)).Msg("existing files count")
+		pm.logger.Debug().Caller().Int("count", len(files)).Msgf("available files count")
+		pm.logger.Debug().Caller().Int("count", len(existingFilesByExpDesc)).Msg("existing files count")
 
 		// iterate on each file
 		for i, f := range files {
@@ -249,14 +629,14 @@ func (pm *processManager) selectFilesToBackup(ruleState *manager.RuleState, file
 			// i.e. minAge: 3 => if we keep the 'today file', we want to keep the '3 days before file'
 			// and not the 'yesterday file'
 			if f.Date.After(olderRefDate) {
-				log.Debug().Caller().Time("date", f.Date).Time("ref_date", olderRefDate).Str("path", f.Path).Msg("file date is after ref date")
+				pm.logger.Debug().Caller().Time("date", f.Date).Time("ref_date", olderRefDate).Str("path", f.Path).Msg("file date is after ref date")
 				continue
 			}
 
-			log.Debug().Caller().Time("date", f.Date).Time("ref_date", olderRefDate).Str("path", f.Path).Msg("candidate file")
+			pm.logger.Debug().Caller().Time("date", f.Date).Time("ref_date", olderRefDate).Str("path", f.Path).Msg("candidate file")
 
 			// prepare the expiration date of the file
-			expiration := f.Date.Add(time.Duration(ruleState.Rule.MinAge) * 24 * time.Hour)
+			expiration := f.Date.Add(ruleState.Rule.MinAge)
 
 			var fileError *manager.RuleStateError
 
@@ -273,7 +653,7 @@ func (pm *processManager) selectFilesToBackup(ruleState *manager.RuleState, file
 						Reason: manager.RuleStateErrorSizeTooSmall,
 					}
 					fileError = &err
-					log.Debug().Caller().Int64("previous_size", previousSize).Int64("actual_size", f.Size).Str("path", f.Path).Msg("file is at least 50% smaller than previous backup")
+					pm.logger.Debug().Caller().Int64("previous_size", previousSize).Int64("actual_size", f.Size).Str("path", f.Path).Msg("file is at least 50% smaller than previous backup")
 				}
 			}
 
@@ -284,13 +664,13 @@ func (pm *processManager) selectFilesToBackup(ruleState *manager.RuleState, file
 					Reason: manager.RuleStateErrorObsolete,
 				}
 				fileError = &err
-				log.Debug().Caller().Time("ref_date", olderRefDate).Time("expiration", expiration).Str("path", f.Path).Msg("file is obsolete")
+				pm.logger.Debug().Caller().Time("ref_date", olderRefDate).Time("expiration", expiration).Str("path", f.Path).Msg("file is obsolete")
 			}
 
 			if fileError != nil {
-				log.Debug().Caller().AnErr("err", fileError).Str("path", f.Path).Msg("detected file error")
+				pm.logger.Debug().Caller().AnErr("err", fileError).Str("path", f.Path).Msg("detected file error")
 			} else {
-				log.Debug().Caller().Str("path", f.Path).Msg("no file error detected")
+				pm.logger.Debug().Caller().Str("path", f.Path).Msg("no file error detected")
 			}
 
 			// keep the file, updating the state
@@ -303,22 +683,20 @@ func (pm *processManager) selectFilesToBackup(ruleState *manager.RuleState, file
 					Error:      fileError,
 				}
 				ruleState.Files = append(ruleState.Files, *selectedFile)
-				log.Debug().Caller().Str("path", f.Path).Msg("new file, adding it to state")
+				pm.logger.Debug().Caller().Str("path", f.Path).Msg("new file, adding it to state")
 			} else {
 				// if it's already in the kept files, update the eventual error
 				if i, ok := existingFilesIndexesByPath[f.Path]; ok && fileError != nil {
 					ruleState.Files[i].Error = fileError
-					log.Debug().Caller().Str("path", f.Path).Msg("existing file, update the associated error")
+					pm.logger.Debug().Caller().Str("path", f.Path).Msg("existing file, update the associated error")
 				}
 			}
 
 			// update Next date
 			if fileError == nil {
-				unit := 24 * time.Hour
-				tolerance := 2 * time.Hour
-				next := f.Date.Add(time.Duration(ruleState.Rule.MinAge)*unit + tolerance)
+				next := f.Date.Add(ruleState.Rule.MinAge + ruleState.Rule.GetTolerance())
 				if ruleState.Next == nil || next.After(*ruleState.Next) {
-					l := log.Debug().Caller()
+					l := pm.logger.Debug().Caller()
 					if ruleState.Next != nil {
 						l = l.Time("previous_next", *ruleState.Next)
 					} else {
@@ -328,16 +706,16 @@ func (pm *processManager) selectFilesToBackup(ruleState *manager.RuleState, file
 					ruleState.Next = &next
 				}
 			} else {
-				log.Debug().Caller().Str("path", f.Path).Msg("Next date not updated: file has an error")
+				pm.logger.Debug().Caller().Str("path", f.Path).Msg("Next date not updated: file has an error")
 			}
 
 			if fileError != nil && fileError.Reason == manager.RuleStateErrorSizeTooSmall {
 				// don't update the refDate, trying to find another file to fulfill the needs of the rule
-				log.Debug().Caller().Str("rule_id", string(ruleState.Rule.GetID())).Str("path", f.Path).Msg("file is too small, trying to find another file for the rule")
+				pm.logger.Debug().Caller().Str("rule_id", string(ruleState.Rule.GetID())).Str("path", f.Path).Msg("file is too small, trying to find another file for the rule")
 			} else {
-				// substract (minAge * 24h)
-				newRefDate := olderRefDate.Add(time.Duration(-ruleState.Rule.MinAge) * 24 * time.Hour)
-				log.Debug().Caller().Time("older_ref_date", olderRefDate).Time("new_ref_date", newRefDate).Str("rule_id", string(ruleState.Rule.GetID())).Msg("decrease reference date")
+				// substract minAge
+				newRefDate := olderRefDate.Add(-ruleState.Rule.MinAge)
+				pm.logger.Debug().Caller().Time("older_ref_date", olderRefDate).Time("new_ref_date", newRefDate).Str("rule_id", string(ruleState.Rule.GetID())).Msg("decrease reference date")
 				olderRefDate = newRefDate
 			}
 		}
@@ -384,7 +762,36 @@ func (pm *processManager) getFilesToRemove(project *manager.Project, allFiles []
 		}
 	}
 
-	log.Info().Str("project", project.Name).Int("count", len(filesToKeep)).Msg("files to keep")
+	// the pinned files are always kept, regardless of the rules and of the caps
+	protected := map[string]bool{}
+	for _, f := range allFiles {
+		if pin := project.GetPin(f.Path, referenceDate); pin != nil {
+			pm.logger.Info().Str("project", project.Name).Str("path", f.Path).Str("reason", pin.Reason).Msg("pinned file kept")
+			protected[f.Path] = true
+		}
+	}
+
+	// the newest files are always kept, regardless of their errors and of the caps
+	for i, f := range manager.FilesSortedByDateDesc(allFiles) {
+		if i >= project.Caps.KeepNewest {
+			break
+		}
+		protected[f.Path] = true
+	}
+
+	for path := range protected {
+		filesToKeep[path] = true
+	}
+
+	// the caps take precedence over the rules
+	conflicts := pm.applyCaps(project, allFiles, filesToKeep, protected, referenceDate)
+	if len(conflicts) > 0 && strings.Join(conflicts, "\n") != strings.Join(project.CapConflicts, "\n") {
+		pm.logger.Warn().Str("project", project.Name).Strs("conflicts", conflicts).Msg("caps conflict with the rules")
+		pm.publish(manager.Event{Type: manager.EventCapConflict, ProjectName: project.Name, Message: strings.Join(conflicts, ", ")})
+	}
+	project.CapConflicts = conflicts
+
+	pm.logger.Info().Str("project", project.Name).Int("count", len(filesToKeep)).Msg("files to keep")
 
 	filesToRemove := []manager.File{}
 	for _, f := range allFiles {
@@ -1,6 +1,12 @@
 package process
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,7 +22,8 @@ func TestProcessExecution(t *testing.T) {
 		t.Run(test.Name, func(t *testing.T) {
 
 			// execute process
-			err := Execute(test.ReferenceDate, test.ProjectRepository, test.FileRepository)
+			publisher := &testPublisher{}
+			err := Execute(context.Background(), test.ReferenceDate, test.ProjectRepository, test.FileRepository, Options{Publisher: publisher})
 			if err != nil {
 				t.Fatalf("Execute returned an error: %v", err.Error())
 			}
@@ -27,6 +34,9 @@ func TestProcessExecution(t *testing.T) {
 			/**** tests start here ****/
 			/**************************/
 
+			// the events must be consistent with the state and the files
+			publisher.checkEvents(t, test.ProjectRepository, test.FileRepository)
+
 			// check the state for each project in repo
 			expectedProjectsStates, expectedFiles, expectedSentAlerts := test.Expected()
 			projects, _ := test.ProjectRepository.GetAll()
@@ -149,7 +159,7 @@ func getProcessTestCases() []processTest {
 	return []processTest{
 		func() processTest {
 			refDate := time.Date(2019, 03, 25, 8, 0, 0, 0, time.UTC)
-			rule := manager.Rule{Count: 3, MinAge: 1}
+			rule := manager.Rule{Count: 3, MinAge: manager.Day}
 			files := []manager.File{
 				manager.File{Path: "project1/file0.tar.gz", Date: time.Date(2019, 03, 20, 5, 0, 0, 0, time.UTC), Size: 300},
 				manager.File{Path: "project1/file1.tar.gz", Date: time.Date(2019, 03, 23, 5, 0, 0, 0, time.UTC), Size: 300},
@@ -209,7 +219,7 @@ func getProcessTestCases() []processTest {
 		}(),
 		func() processTest {
 			refDate := time.Date(2019, 03, 25, 8, 0, 0, 0, time.UTC)
-			rule := manager.Rule{Count: 3, MinAge: 1}
+			rule := manager.Rule{Count: 3, MinAge: manager.Day}
 			files := []manager.File{
 				manager.File{Path: "project2/file1.tar.gz", Date: time.Date(2019, 03, 23, 5, 0, 0, 0, time.UTC), Size: 300},
 			}
@@ -249,8 +259,8 @@ func getProcessTestCases() []processTest {
 		}(),
 		func() processTest {
 			refDate := time.Date(2019, 03, 25, 8, 0, 0, 0, time.UTC)
-			rule1 := manager.Rule{Count: 3, MinAge: 1}
-			rule2 := manager.Rule{Count: 2, MinAge: 15}
+			rule1 := manager.Rule{Count: 3, MinAge: manager.Day}
+			rule2 := manager.Rule{Count: 2, MinAge: 15 * manager.Day}
 			files := []manager.File{
 				manager.File{Path: "project1/file0.tar.gz", Date: time.Date(2019, 03, 20, 5, 0, 0, 0, time.UTC), Size: 300},
 				manager.File{Path: "project1/file1.tar.gz", Date: time.Date(2019, 03, 23, 5, 0, 0, 0, time.UTC), Size: 300},
@@ -298,16 +308,16 @@ func getProcessTestCases() []processTest {
 						Rule: rule1,
 						Next: &expectedNext1,
 						Files: []manager.SelectedFile{
-							manager.SelectedFile{File: files[7], Expiration: files[7].Date.Add(time.Duration(rule1.MinAge) * 24 * time.Hour), Error: nil},
-							manager.SelectedFile{File: files[6], Expiration: files[6].Date.Add(time.Duration(rule1.MinAge) * 24 * time.Hour), Error: nil},
-							manager.SelectedFile{File: files[1], Expiration: files[1].Date.Add(time.Duration(rule1.MinAge) * 24 * time.Hour), Error: nil},
+							manager.SelectedFile{File: files[7], Expiration: files[7].Date.Add(rule1.MinAge), Error: nil},
+							manager.SelectedFile{File: files[6], Expiration: files[6].Date.Add(rule1.MinAge), Error: nil},
+							manager.SelectedFile{File: files[1], Expiration: files[1].Date.Add(rule1.MinAge), Error: nil},
 						},
 					}
 					expectedState[rule2.GetID()] = manager.RuleState{
 						Rule: rule2,
 						Next: &expectedNext2,
 						Files: []manager.SelectedFile{
-							manager.SelectedFile{File: files[7], Expiration: files[7].Date.Add(time.Duration(rule2.MinAge) * 24 * time.Hour), Error: nil},
+							manager.SelectedFile{File: files[7], Expiration: files[7].Date.Add(rule2.MinAge), Error: nil},
 						},
 					}
 
@@ -322,7 +332,7 @@ func getProcessTestCases() []processTest {
 		}(),
 		func() processTest {
 			refDate := time.Date(2019, 03, 25, 8, 0, 0, 0, time.UTC)
-			rule := manager.Rule{Count: 3, MinAge: 1}
+			rule := manager.Rule{Count: 3, MinAge: manager.Day}
 			files := []manager.File{
 				manager.File{Path: "project1/file0.tar.gz", Date: time.Date(2019, 03, 20, 5, 0, 0, 0, time.UTC), Size: 300},
 				manager.File{Path: "project1/file1.tar.gz", Date: time.Date(2019, 03, 21, 5, 0, 0, 0, time.UTC), Size: 300},
@@ -393,7 +403,7 @@ func getProcessTestCases() []processTest {
 		}(),
 		func() processTest {
 			refDate := time.Date(2019, 03, 26, 8, 0, 0, 0, time.UTC)
-			rule := manager.Rule{Count: 3, MinAge: 1}
+			rule := manager.Rule{Count: 3, MinAge: manager.Day}
 			files := []manager.File{
 				manager.File{Path: "project1/file0.tar.gz", Date: time.Date(2019, 03, 20, 5, 0, 0, 0, time.UTC), Size: 300},
 				manager.File{Path: "project1/file1.tar.gz", Date: time.Date(2019, 03, 21, 5, 0, 0, 0, time.UTC), Size: 300},
@@ -471,7 +481,7 @@ func getProcessTestCases() []processTest {
 		}(),
 		func() processTest {
 			refDate := time.Date(2019, 04, 25, 8, 0, 0, 0, time.UTC)
-			rule := manager.Rule{Count: 3, MinAge: 1}
+			rule := manager.Rule{Count: 3, MinAge: manager.Day}
 			files := []manager.File{
 				manager.File{Path: "project1/file0.tar.gz", Date: time.Date(2019, 03, 20, 5, 0, 0, 0, time.UTC), Size: 300},
 				manager.File{Path: "project1/file1.tar.gz", Date: time.Date(2019, 03, 21, 5, 0, 0, 0, time.UTC), Size: 300},
@@ -544,7 +554,7 @@ func getProcessTestCases() []processTest {
 		}(),
 		func() processTest {
 			refDate := time.Date(2019, 03, 30, 8, 0, 0, 0, time.UTC)
-			rule := manager.Rule{Count: 3, MinAge: 1}
+			rule := manager.Rule{Count: 3, MinAge: manager.Day}
 			files := []manager.File{
 				manager.File{Path: "project1/file0.tar.gz", Date: time.Date(2019, 03, 22, 5, 0, 0, 0, time.UTC), Size: 300},
 				manager.File{Path: "project1/file1.tar.gz", Date: time.Date(2019, 03, 23, 5, 0, 0, 0, time.UTC), Size: 300},
@@ -609,7 +619,7 @@ func getProcessTestCases() []processTest {
 		}(),
 		func() processTest {
 			refDate := time.Date(2019, 03, 25, 8, 0, 0, 0, time.UTC)
-			rule := manager.Rule{Count: 3, MinAge: 1}
+			rule := manager.Rule{Count: 3, MinAge: manager.Day}
 			files := []manager.File{}
 
 			initialState := manager.ProjectState{}
@@ -660,7 +670,7 @@ func getProcessTestCases() []processTest {
 		}(),
 		func() processTest {
 			refDate := time.Date(2019, 03, 25, 8, 0, 0, 0, time.UTC)
-			rule := manager.Rule{Count: 3, MinAge: 1}
+			rule := manager.Rule{Count: 3, MinAge: manager.Day}
 			files := []manager.File{
 				manager.File{Path: "project1/file0.tar.gz", Date: time.Date(2019, 03, 25, 5, 0, 0, 0, time.UTC), Size: 300},
 			}
@@ -712,7 +722,7 @@ func getProcessTestCases() []processTest {
 		}(),
 		func() processTest {
 			refDate := time.Date(2019, 03, 25, 8, 0, 0, 0, time.UTC)
-			rule := manager.Rule{Count: 3, MinAge: 1}
+			rule := manager.Rule{Count: 3, MinAge: manager.Day}
 			files := []manager.File{
 				manager.File{Path: "project1/file1.tar.gz", Date: time.Date(2019, 03, 25, 7, 51, 0, 0, time.UTC), Size: 300},
 				manager.File{Path: "project1/file2.tar.gz", Date: time.Date(2019, 03, 25, 7, 54, 0, 0, time.UTC), Size: 300},
@@ -780,7 +790,8 @@ func newTestNotifier() *testNotifier {
 }
 
 type testNotifier struct {
-	sentNotifications []manager.ProjectErrorStatement
+	sentNotifications       []manager.ProjectErrorStatement
+	sentGlobalNotifications []manager.GlobalErrorStatement
 }
 
 func (not *testNotifier) Notify(stmt manager.ProjectErrorStatement) error {
@@ -788,6 +799,11 @@ func (not *testNotifier) Notify(stmt manager.ProjectErrorStatement) error {
 	return nil
 }
 
+func (not *testNotifier) NotifyGlobal(stmt manager.GlobalErrorStatement) error {
+	not.sentGlobalNotifications = append(not.sentGlobalNotifications, stmt)
+	return nil
+}
+
 func (not *testNotifier) checkSentNotifications(t *testing.T, expectedErrorStatement *manager.ProjectErrorStatement) {
 
 	if expectedErrorStatement != nil && len(not.sentNotifications) != 1 {
@@ -808,3 +824,633 @@ func (not *testNotifier) checkSentNotifications(t *testing.T, expectedErrorState
 		t.Errorf("unexpected error statement count: expected=%v got=%v", expectedStmt.Count, stmt.Count)
 	}
 }
+
+type testPublisher struct {
+	events []manager.Event
+}
+
+func (pub *testPublisher) Publish(event manager.Event) {
+	pub.events = append(pub.events, event)
+}
+
+// checkEvents ensures that the selected files are in the state (unless they are deleted afterwards),
+// and that the deleted files are removed
+func (pub *testPublisher) checkEvents(t *testing.T, projectRepo manager.ProjectRepository, fileRepo manager.FileRepository) {
+	files, _ := fileRepo.GetAll()
+	existingFiles := map[string]bool{}
+	for _, f := range files {
+		existingFiles[f.Path] = true
+	}
+	deletedFiles := map[string]bool{}
+	for _, event := range pub.events {
+		if event.Type == manager.EventFileDeleted {
+			deletedFiles[event.FilePath] = true
+		}
+	}
+
+	for _, event := range pub.events {
+		switch event.Type {
+		case manager.EventFileSelected:
+			project, _ := projectRepo.GetByName(event.ProjectName)
+			if project == nil {
+				t.Errorf("unknown project for event: %+v", event)
+				continue
+			}
+			found := deletedFiles[event.FilePath]
+			for _, f := range project.State[event.RuleID].Files {
+				if f.Path == event.FilePath {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("selected file is not in the state: %+v", event)
+			}
+		case manager.EventFileDeleted:
+			if existingFiles[event.FilePath] {
+				t.Errorf("deleted file is still in the repository: %+v", event)
+			}
+		}
+	}
+}
+
+func TestProcessSkipsCorruptedProjects(t *testing.T) {
+	refDate := time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)
+	projectRepo := corruptedProjectRepository{
+		ProjectRepository: newMockProjectRepository([]manager.Project{
+			{Name: "valid", Rules: []manager.Rule{{MinAge: manager.Day, Count: 3}}},
+		}),
+		corruptedKeys: []string{"corrupted"},
+	}
+	fileRepo := newMockFileRepository([]manager.File{})
+	publisher := &testPublisher{}
+
+	err := Execute(context.Background(), refDate, projectRepo, fileRepo, Options{Publisher: publisher})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	project, _ := projectRepo.GetByName("valid")
+	if len(project.State) == 0 {
+		t.Errorf("expected the valid project to be processed")
+	}
+
+	reported := false
+	for _, e := range publisher.events {
+		if e.Type == manager.EventProjectCorrupted && e.ProjectName == "corrupted" {
+			reported = true
+		}
+	}
+	if !reported {
+		t.Errorf("expected the corrupted project to be reported")
+	}
+}
+
+// corruptedProjectRepository simulates records which can't be decoded
+type corruptedProjectRepository struct {
+	manager.ProjectRepository
+	corruptedKeys []string
+}
+
+func (repo corruptedProjectRepository) GetAll() ([]manager.Project, error) {
+	projects, _ := repo.ProjectRepository.GetAll()
+	return projects, manager.NewCorruptedRecordError("get all projects", errors.New("invalid data"), repo.corruptedKeys...)
+}
+
+func TestProcessIsolatesFailingProjects(t *testing.T) {
+	refDate := time.Date(2019, 03, 25, 8, 0, 0, 0, time.UTC)
+	rule := manager.Rule{Count: 1, MinAge: manager.Day}
+
+	projects := []manager.Project{}
+	files := []manager.File{}
+	for _, name := range []string{"project1", "project2"} {
+		initialNext := refDate.Add(-24 * time.Hour)
+		projects = append(projects, manager.Project{
+			Name:  name,
+			Rules: []manager.Rule{rule},
+			State: manager.ProjectState{rule.GetID(): manager.RuleState{Rule: rule, Next: &initialNext}},
+		})
+		files = append(files,
+			manager.File{Path: name + "/file0.tar.gz", Date: time.Date(2019, 03, 20, 5, 0, 0, 0, time.UTC), Size: 300},
+			manager.File{Path: name + "/file1.tar.gz", Date: time.Date(2019, 03, 25, 5, 0, 0, 0, time.UTC), Size: 300},
+		)
+	}
+
+	projectRepo := newMockProjectRepository(projects)
+	fileRepo := failingFileRepository{FileRepository: newMockFileRepository(files), failingFolder: "project1"}
+
+	err := Execute(context.Background(), refDate, projectRepo, fileRepo, Options{})
+	var projectErrors ProjectErrors
+	if !errors.As(err, &projectErrors) {
+		t.Fatalf("expected project errors, got %v", err)
+	}
+	if _, ok := projectErrors["project1"]; !ok || len(projectErrors) != 1 {
+		t.Errorf("expected only project1 to fail, got %v", projectErrors)
+	}
+
+	// the other project is processed anyway
+	remainingFiles, _ := fileRepo.GetAllByFolder()
+	if len(remainingFiles["project2"]) != 1 {
+		t.Errorf("expected the obsolete file of project2 to be removed, got %v", remainingFiles["project2"])
+	}
+	if len(remainingFiles["project1"]) != 2 {
+		t.Errorf("expected the files of project1 to be kept, got %v", remainingFiles["project1"])
+	}
+}
+
+func TestTickNotifiesUnreachableStorage(t *testing.T) {
+	retryDelays = []time.Duration{0, 0}
+	defer func() { retryDelays = []time.Duration{1 * time.Second, 5 * time.Second, 15 * time.Second} }()
+
+	projectRepo := newMockProjectRepository([]manager.Project{})
+	fileRepo := failingFileRepository{FileRepository: newMockFileRepository([]manager.File{}), unreachable: true}
+	notifier := newTestNotifier()
+
+	result := Tick(context.Background(), time.Now(), projectRepo, fileRepo, notifier, Options{})
+	if !errors.Is(result.Err, ErrStorageUnreachable) {
+		t.Fatalf("expected the storage to be unreachable, got %v", result.Err)
+	}
+	if len(notifier.sentGlobalNotifications) != 1 || notifier.sentGlobalNotifications[0].Reason != manager.GlobalErrorStorageUnreachable {
+		t.Errorf("expected a global alert, got %v", notifier.sentGlobalNotifications)
+	}
+
+	health := &Health{}
+	health.Record(result)
+	last, lastSuccess := health.Last()
+	if last == nil || last.Err == nil || !lastSuccess.IsZero() {
+		t.Errorf("expected a failed tick to be recorded, got %v (last success: %v)", last, lastSuccess)
+	}
+}
+
+func TestRetryStopsWhenCancelled(t *testing.T) {
+	retryDelays = []time.Duration{time.Hour}
+	defer func() { retryDelays = []time.Duration{1 * time.Second, 5 * time.Second, 15 * time.Second} }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	attempts := 0
+	start := time.Now()
+	err := retry(ctx, "test", func() error {
+		attempts++
+		return errors.New("connection refused")
+	})
+	if err == nil || attempts != 1 {
+		t.Errorf("expected the retry to be cancelled after the first attempt, got %d attempt(s) (%v)", attempts, err)
+	}
+	if time.Since(start) > time.Minute {
+		t.Errorf("expected the wait to be interrupted")
+	}
+}
+
+// failingFileRepository simulates failures of the storage
+type failingFileRepository struct {
+	manager.FileRepository
+	// failingFolder is the folder where the files can't be removed
+	failingFolder string
+	// unreachable makes the listing fail
+	unreachable bool
+}
+
+func (repo failingFileRepository) GetAllByFolder() (manager.FilesByFolder, error) {
+	if repo.unreachable {
+		return nil, manager.NewStorageError("list files", errors.New("connection refused"))
+	}
+	return repo.FileRepository.GetAllByFolder()
+}
+
+func (repo failingFileRepository) RemoveFile(file manager.File) error {
+	folder, _ := repo.GetFolderForFile(file)
+	if folder == repo.failingFolder {
+		return manager.NewStorageError("remove file", errors.New("access denied"))
+	}
+	return repo.FileRepository.RemoveFile(file)
+}
+
+func TestProcessKeepsPinnedFiles(t *testing.T) {
+	refDate := time.Date(2019, 03, 25, 8, 0, 0, 0, time.UTC)
+	rule := manager.Rule{Count: 1, MinAge: manager.Day}
+	initialNext := refDate.Add(-24 * time.Hour)
+
+	projectRepo := newMockProjectRepository([]manager.Project{
+		{
+			Name:  "project1",
+			Rules: []manager.Rule{rule},
+			State: manager.ProjectState{rule.GetID(): manager.RuleState{Rule: rule, Next: &initialNext}},
+			Pins: []manager.Pin{
+				{Path: "project1/file0.tar.gz", Reason: "incident #42", Author: "john"},
+				{Path: "project1/file1.tar.gz", Reason: "audit", Author: "john", ExpiresAt: refDate.Add(-time.Hour)},
+			},
+		},
+	})
+	fileRepo := newMockFileRepository([]manager.File{
+		{Path: "project1/file0.tar.gz", Date: time.Date(2019, 03, 18, 5, 0, 0, 0, time.UTC), Size: 300},
+		{Path: "project1/file1.tar.gz", Date: time.Date(2019, 03, 20, 5, 0, 0, 0, time.UTC), Size: 300},
+		{Path: "project1/file2.tar.gz", Date: time.Date(2019, 03, 25, 5, 0, 0, 0, time.UTC), Size: 300},
+	})
+
+	err := Execute(context.Background(), refDate, projectRepo, fileRepo, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the expired pin is ignored
+	files, _ := fileRepo.GetAll()
+	paths := []string{}
+	for _, f := range files {
+		paths = append(paths, f.Path)
+	}
+	sort.Strings(paths)
+	expected := []string{"project1/file0.tar.gz", "project1/file2.tar.gz"}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("expected %v to be kept, got %v", expected, paths)
+	}
+}
+
+func TestProcessKeepsFilesPinnedDuringRun(t *testing.T) {
+	refDate := time.Date(2019, 03, 25, 8, 0, 0, 0, time.UTC)
+	rule := manager.Rule{Count: 1, MinAge: manager.Day}
+	initialNext := refDate.Add(-24 * time.Hour)
+
+	projectRepo := newMockProjectRepository([]manager.Project{
+		{
+			Name:  "project1",
+			Rules: []manager.Rule{rule},
+			State: manager.ProjectState{rule.GetID(): manager.RuleState{Rule: rule, Next: &initialNext}},
+		},
+	})
+	// file1 is pinned through the API while file0 is being removed
+	fileRepo := &concurrentFileRepository{
+		FileRepository: newMockFileRepository([]manager.File{
+			{Path: "project1/file0.tar.gz", Date: time.Date(2019, 03, 18, 5, 0, 0, 0, time.UTC), Size: 300},
+			{Path: "project1/file1.tar.gz", Date: time.Date(2019, 03, 20, 5, 0, 0, 0, time.UTC), Size: 300},
+			{Path: "project1/file2.tar.gz", Date: time.Date(2019, 03, 25, 5, 0, 0, 0, time.UTC), Size: 300},
+		}),
+		projectRepo: projectRepo,
+		update: func(project *manager.Project) {
+			project.AddPin(manager.Pin{Path: "project1/file1.tar.gz", Reason: "incident #42", Author: "john"})
+		},
+	}
+
+	err := Execute(context.Background(), refDate, projectRepo, fileRepo, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	files, _ := fileRepo.GetAll()
+	paths := []string{}
+	for _, f := range files {
+		paths = append(paths, f.Path)
+	}
+	sort.Strings(paths)
+	expected := []string{"project1/file1.tar.gz", "project1/file2.tar.gz"}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("expected %v to be kept, got %v", expected, paths)
+	}
+
+	// the pin is not overwritten by the state saved by the process
+	project, _ := projectRepo.GetByName("project1")
+	if project.GetPin("project1/file1.tar.gz", refDate) == nil {
+		t.Errorf("expected the pin to be kept, got %+v", project.Pins)
+	}
+	if len(project.State[rule.GetID()].Files) == 0 {
+		t.Errorf("expected the state to be saved, got %+v", project.State)
+	}
+}
+
+// concurrentFileRepository updates the project during the first removal, as the API would do during a run
+type concurrentFileRepository struct {
+	manager.FileRepository
+	projectRepo manager.ProjectRepository
+	update      func(project *manager.Project)
+	updated     bool
+}
+
+func (repo *concurrentFileRepository) RemoveFile(file manager.File) error {
+	if !repo.updated {
+		repo.updated = true
+		err := repo.projectRepo.Update("project1", func(project *manager.Project) error {
+			repo.update(project)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return repo.FileRepository.RemoveFile(file)
+}
+
+func TestProcessHonorsProjectPause(t *testing.T) {
+	refDate := time.Date(2019, 03, 25, 8, 0, 0, 0, time.UTC)
+	rule := manager.Rule{Count: 1, MinAge: manager.Day}
+
+	tests := []struct {
+		name             string
+		pause            *manager.Pause
+		expectedFiles    int
+		expectSelection  bool
+		expectNotified   bool
+		expectPauseReset bool
+	}{
+		{name: "active", pause: nil, expectedFiles: 1, expectSelection: true, expectNotified: true},
+		{name: "paused deletions", pause: &manager.Pause{Status: manager.ProjectStatusPausedDeletions, Reason: "migration"}, expectedFiles: 2, expectSelection: true, expectNotified: true},
+		{name: "paused all", pause: &manager.Pause{Status: manager.ProjectStatusPausedAll, Reason: "migration"}, expectedFiles: 2, expectSelection: false, expectNotified: false},
+		{
+			name:             "auto-resumed",
+			pause:            &manager.Pause{Status: manager.ProjectStatusPausedAll, Reason: "migration", ResumeAt: refDate.Add(-time.Hour)},
+			expectedFiles:    1,
+			expectSelection:  true,
+			expectNotified:   true,
+			expectPauseReset: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initialNext := refDate.Add(-24 * time.Hour)
+			projectRepo := newMockProjectRepository([]manager.Project{
+				{
+					Name:  "project1",
+					Rules: []manager.Rule{rule},
+					State: manager.ProjectState{rule.GetID(): manager.RuleState{Rule: rule, Next: &initialNext}},
+					Pause: tt.pause,
+				},
+			})
+			// no recent file: an alert is raised by the selection
+			fileRepo := newMockFileRepository([]manager.File{
+				{Path: "project1/file0.tar.gz", Date: time.Date(2019, 03, 20, 5, 0, 0, 0, time.UTC), Size: 300},
+				{Path: "project1/file1.tar.gz", Date: time.Date(2019, 03, 21, 5, 0, 0, 0, time.UTC), Size: 300},
+			})
+
+			err := Execute(context.Background(), refDate, projectRepo, fileRepo, Options{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			files, _ := fileRepo.GetAll()
+			if len(files) != tt.expectedFiles {
+				t.Errorf("expected %d files, got %v", tt.expectedFiles, files)
+			}
+
+			project, _ := projectRepo.GetByName("project1")
+			selected := len(project.State[rule.GetID()].Files) > 0
+			if selected != tt.expectSelection {
+				t.Errorf("expected selection to be %v, got state %+v", tt.expectSelection, project.State)
+			}
+			if tt.expectPauseReset && project.Pause != nil {
+				t.Errorf("expected the project to be resumed, got %+v", project.Pause)
+			}
+
+			notifier := newTestNotifier()
+			Notify(projectRepo, notifier)
+			if notified := len(notifier.sentNotifications) > 0; notified != tt.expectNotified {
+				t.Errorf("expected notified to be %v, got %v", tt.expectNotified, notifier.sentNotifications)
+			}
+		})
+	}
+}
+
+func TestProcessStopsDeletionsPausedDuringRun(t *testing.T) {
+	refDate := time.Date(2019, 03, 25, 8, 0, 0, 0, time.UTC)
+	rule := manager.Rule{Count: 1, MinAge: manager.Day}
+	initialNext := refDate.Add(-24 * time.Hour)
+
+	projectRepo := newMockProjectRepository([]manager.Project{
+		{
+			Name:  "project1",
+			Rules: []manager.Rule{rule},
+			State: manager.ProjectState{rule.GetID(): manager.RuleState{Rule: rule, Next: &initialNext}},
+		},
+	})
+	// the deletions are paused through the API while file0 is being removed
+	pause := manager.Pause{Status: manager.ProjectStatusPausedDeletions, Reason: "migration", Author: "john"}
+	fileRepo := &concurrentFileRepository{
+		FileRepository: newMockFileRepository([]manager.File{
+			{Path: "project1/file0.tar.gz", Date: time.Date(2019, 03, 18, 5, 0, 0, 0, time.UTC), Size: 300},
+			{Path: "project1/file1.tar.gz", Date: time.Date(2019, 03, 20, 5, 0, 0, 0, time.UTC), Size: 300},
+			{Path: "project1/file2.tar.gz", Date: time.Date(2019, 03, 25, 5, 0, 0, 0, time.UTC), Size: 300},
+		}),
+		projectRepo: projectRepo,
+		update: func(project *manager.Project) {
+			p := pause
+			project.Pause = &p
+		},
+	}
+
+	err := Execute(context.Background(), refDate, projectRepo, fileRepo, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	files, _ := fileRepo.GetAll()
+	if len(files) != 2 {
+		t.Errorf("expected only file0 to be removed, got %v", files)
+	}
+
+	// the pause is not overwritten by the state saved by the process
+	project, _ := projectRepo.GetByName("project1")
+	if project.GetStatus(refDate) != manager.ProjectStatusPausedDeletions {
+		t.Errorf("expected the deletions to be paused, got %+v", project.Pause)
+	}
+}
+
+func TestTickSkippedDuringMaintenance(t *testing.T) {
+	refDate := time.Date(2019, 03, 25, 8, 0, 0, 0, time.UTC)
+	rule := manager.Rule{Count: 1, MinAge: manager.Day}
+	initialNext := refDate.Add(-24 * time.Hour)
+
+	projectRepo := newMockProjectRepository([]manager.Project{
+		{
+			Name:  "project1",
+			Rules: []manager.Rule{rule},
+			State: manager.ProjectState{rule.GetID(): manager.RuleState{Rule: rule, Next: &initialNext}},
+		},
+	})
+	fileRepo := newMockFileRepository([]manager.File{
+		{Path: "project1/file0.tar.gz", Date: time.Date(2019, 03, 20, 5, 0, 0, 0, time.UTC), Size: 300},
+		{Path: "project1/file1.tar.gz", Date: time.Date(2019, 03, 21, 5, 0, 0, 0, time.UTC), Size: 300},
+	})
+	notifier := newTestNotifier()
+	maintenanceRepo := &testMaintenanceRepository{maintenance: &manager.Maintenance{Reason: "bucket migration", Until: refDate.Add(time.Hour)}}
+	options := Options{Maintenance: maintenanceRepo}
+
+	result := Tick(context.Background(), refDate, projectRepo, fileRepo, notifier, options)
+	if result.Err != nil || result.Maintenance == nil {
+		t.Fatalf("expected the tick to be skipped, got %+v", result)
+	}
+	files, _ := fileRepo.GetAll()
+	if len(files) != 2 || len(notifier.sentNotifications) != 0 {
+		t.Errorf("expected no deletion and no alert, got files %v and alerts %v", files, notifier.sentNotifications)
+	}
+
+	// the maintenance mode is over
+	result = Tick(context.Background(), refDate.Add(2*time.Hour), projectRepo, fileRepo, notifier, options)
+	if result.Maintenance != nil || maintenanceRepo.maintenance != nil {
+		t.Fatalf("expected the maintenance mode to be disabled, got %+v", result)
+	}
+	files, _ = fileRepo.GetAll()
+	if len(files) != 1 || len(notifier.sentNotifications) != 1 {
+		t.Errorf("expected the process to run, got files %v and alerts %v", files, notifier.sentNotifications)
+	}
+}
+
+type testMaintenanceRepository struct {
+	maintenance *manager.Maintenance
+}
+
+func (repo *testMaintenanceRepository) Get() (*manager.Maintenance, error) {
+	return repo.maintenance, nil
+}
+
+func (repo *testMaintenanceRepository) Set(m *manager.Maintenance) error {
+	repo.maintenance = m
+	return nil
+}
+
+func TestProcessEnforcesCaps(t *testing.T) {
+	refDate := time.Date(2019, 03, 25, 8, 0, 0, 0, time.UTC)
+	rule := manager.Rule{Count: 3, MinAge: manager.Day}
+
+	tests := []struct {
+		name              string
+		caps              manager.Caps
+		expected          []string
+		expectedConflicts int
+	}{
+		{name: "no caps", caps: manager.Caps{}, expected: []string{"file3", "file4", "file5"}},
+		{name: "max files", caps: manager.Caps{MaxFiles: 2}, expected: []string{"file4", "file5"}, expectedConflicts: 1},
+		{name: "max bytes", caps: manager.Caps{MaxBytes: 600}, expected: []string{"file4", "file5"}, expectedConflicts: 1},
+		{name: "max age", caps: manager.Caps{MaxAge: 36 * time.Hour}, expected: []string{"file4", "file5"}, expectedConflicts: 1},
+		{name: "keep newest", caps: manager.Caps{KeepNewest: 5}, expected: []string{"file1", "file2", "file3", "file4", "file5"}},
+		// the newest files are kept, even if they exceed the caps
+		{name: "keep newest over caps", caps: manager.Caps{MaxFiles: 1, KeepNewest: 2}, expected: []string{"file4", "file5"}, expectedConflicts: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initialNext := refDate.Add(-24 * time.Hour)
+			projectRepo := newMockProjectRepository([]manager.Project{
+				{
+					Name:  "project1",
+					Rules: []manager.Rule{rule},
+					State: manager.ProjectState{rule.GetID(): manager.RuleState{Rule: rule, Next: &initialNext}},
+					Caps:  tt.caps,
+				},
+			})
+			files := []manager.File{}
+			for i := 1; i <= 5; i++ {
+				files = append(files, manager.File{
+					Path: fmt.Sprintf("project1/file%d", i),
+					Date: time.Date(2019, 03, 20+i, 5, 0, 0, 0, time.UTC),
+					Size: 300,
+				})
+			}
+			fileRepo := newMockFileRepository(files)
+
+			err := Execute(context.Background(), refDate, projectRepo, fileRepo, Options{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			remaining, _ := fileRepo.GetAll()
+			paths := []string{}
+			for _, f := range remaining {
+				paths = append(paths, strings.TrimPrefix(f.Path, "project1/"))
+			}
+			sort.Strings(paths)
+			if !reflect.DeepEqual(paths, tt.expected) {
+				t.Errorf("expected %v to be kept, got %v", tt.expected, paths)
+			}
+
+			project, _ := projectRepo.GetByName("project1")
+			if len(project.CapConflicts) != tt.expectedConflicts {
+				t.Errorf("expected %d conflicts, got %v", tt.expectedConflicts, project.CapConflicts)
+			}
+		})
+	}
+}
+
+func TestProcessHourRules(t *testing.T) {
+	refDate := time.Date(2019, 03, 25, 8, 0, 0, 0, time.UTC)
+	rule := manager.Rule{Count: 2, MinAge: 6 * time.Hour, Tolerance: 30 * time.Minute}
+	initialNext := refDate.Add(-time.Hour)
+
+	projectRepo := newMockProjectRepository([]manager.Project{
+		{
+			Name:  "project1",
+			Rules: []manager.Rule{rule},
+			State: manager.ProjectState{rule.GetID(): manager.RuleState{Rule: rule, Next: &initialNext}},
+		},
+	})
+	files := []manager.File{}
+	for i := 0; i < 4; i++ {
+		files = append(files, manager.File{
+			Path: fmt.Sprintf("project1/file%d", i),
+			Date: refDate.Add(-time.Duration(i)*6*time.Hour - time.Hour),
+			Size: 300,
+		})
+	}
+	fileRepo := newMockFileRepository(files)
+
+	err := Execute(context.Background(), refDate, projectRepo, fileRepo, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	remaining, _ := fileRepo.GetAll()
+	if len(remaining) != 2 {
+		t.Errorf("expected 2 files to be kept, got %v", remaining)
+	}
+
+	project, _ := projectRepo.GetByName("project1")
+	state := project.State[rule.GetID()]
+	expectedNext := files[0].Date.Add(6*time.Hour + 30*time.Minute)
+	if state.Next == nil || !state.Next.Equal(expectedNext) {
+		t.Errorf("expected next date %v, got %v", expectedNext, state.Next)
+	}
+	if state.Error != nil {
+		t.Errorf("unexpected error: %v", state.Error)
+	}
+}
+
+func TestProcessUsesDateSource(t *testing.T) {
+	refDate := time.Date(2019, 03, 25, 8, 0, 0, 0, time.UTC)
+	rule := manager.Rule{Count: 1, MinAge: manager.Day}
+	initialNext := refDate.Add(-time.Hour)
+
+	projectRepo := newMockProjectRepository([]manager.Project{
+		{
+			Name:       "project1",
+			Rules:      []manager.Rule{rule},
+			State:      manager.ProjectState{rule.GetID(): manager.RuleState{Rule: rule, Next: &initialNext}},
+			DateSource: manager.DateSource{Type: manager.DateSourceFilename, Pattern: `backup-(\d{8}-\d{4})`, Layout: "20060102-1504"},
+		},
+	})
+
+	// the files have been copied to a new bucket: their last modification dates are the same
+	files := []manager.File{}
+	for i := 1; i <= 5; i++ {
+		files = append(files, manager.File{
+			Path: fmt.Sprintf("project1/backup-201903%02d-0500.tar.gz", 20+i),
+			Date: refDate.Add(-time.Hour),
+			Size: 300,
+		})
+	}
+	fileRepo := newMockFileRepository(files)
+
+	err := Execute(context.Background(), refDate, projectRepo, fileRepo, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	remaining, _ := fileRepo.GetAll()
+	if len(remaining) != 1 || remaining[0].Path != "project1/backup-20190325-0500.tar.gz" {
+		t.Errorf("expected the newest file to be kept, got %v", remaining)
+	}
+
+	project, _ := projectRepo.GetByName("project1")
+	selected := project.State[rule.GetID()].Files
+	expectedDate := time.Date(2019, 03, 25, 5, 0, 0, 0, time.UTC)
+	if len(selected) != 1 || !selected[0].Date.Equal(expectedDate) {
+		t.Errorf("expected the file to be selected with the date of its filename, got %v", selected)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
 	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
 	math "math"
 )
 
@@ -204,12 +206,16 @@ func (m *ProjectsListResponse) GetTotal() int32 {
 }
 
 type CreateProjectRequest struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Rules                []*Rule  `protobuf:"bytes,2,rep,name=rules,proto3" json:"rules,omitempty"`
-	ProcessImmediately   bool     `protobuf:"varint,3,opt,name=process_immediately,json=processImmediately,proto3" json:"process_immediately,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Name               string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Rules              []*Rule `protobuf:"bytes,2,rep,name=rules,proto3" json:"rules,omitempty"`
+	ProcessImmediately bool    `protobuf:"varint,3,opt,name=process_immediately,json=processImmediately,proto3" json:"process_immediately,omitempty"`
+	// declared delay between two uploads, in seconds, used to check the rules (optional)
+	UploadInterval       int64        `protobuf:"varint,4,opt,name=upload_interval,json=uploadInterval,proto3" json:"upload_interval,omitempty"`
+	Caps                 *ProjectCaps `protobuf:"bytes,5,opt,name=caps,proto3" json:"caps,omitempty"`
+	DateSource           *DateSource  `protobuf:"bytes,6,opt,name=date_source,json=dateSource,proto3" json:"date_source,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *CreateProjectRequest) Reset()         { *m = CreateProjectRequest{} }
@@ -258,11 +264,33 @@ func (m *CreateProjectRequest) GetProcessImmediately() bool {
 	return false
 }
 
+func (m *CreateProjectRequest) GetUploadInterval() int64 {
+	if m != nil {
+		return m.UploadInterval
+	}
+	return 0
+}
+
+func (m *CreateProjectRequest) GetCaps() *ProjectCaps {
+	if m != nil {
+		return m.Caps
+	}
+	return nil
+}
+
+func (m *CreateProjectRequest) GetDateSource() *DateSource {
+	if m != nil {
+		return m.DateSource
+	}
+	return nil
+}
+
 type CreateProjectResponse struct {
-	Project              *Project `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Project              *Project     `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Report               *RulesReport `protobuf:"bytes,2,opt,name=report,proto3" json:"report,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *CreateProjectResponse) Reset()         { *m = CreateProjectResponse{} }
@@ -297,1088 +325,4509 @@ func (m *CreateProjectResponse) GetProject() *Project {
 	return nil
 }
 
-type GetProjectRequest struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *CreateProjectResponse) GetReport() *RulesReport {
+	if m != nil {
+		return m.Report
+	}
+	return nil
 }
 
-func (m *GetProjectRequest) Reset()         { *m = GetProjectRequest{} }
-func (m *GetProjectRequest) String() string { return proto.CompactTextString(m) }
-func (*GetProjectRequest) ProtoMessage()    {}
-func (*GetProjectRequest) Descriptor() ([]byte, []int) {
+// the rules, the upload interval, the caps and the date source replace the current ones
+type UpdateProjectRequest struct {
+	Name                 string       `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Rules                []*Rule      `protobuf:"bytes,2,rep,name=rules,proto3" json:"rules,omitempty"`
+	UploadInterval       int64        `protobuf:"varint,3,opt,name=upload_interval,json=uploadInterval,proto3" json:"upload_interval,omitempty"`
+	Caps                 *ProjectCaps `protobuf:"bytes,4,opt,name=caps,proto3" json:"caps,omitempty"`
+	DateSource           *DateSource  `protobuf:"bytes,5,opt,name=date_source,json=dateSource,proto3" json:"date_source,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *UpdateProjectRequest) Reset()         { *m = UpdateProjectRequest{} }
+func (m *UpdateProjectRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateProjectRequest) ProtoMessage()    {}
+func (*UpdateProjectRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_00212fb1f9d3bf1c, []int{4}
 }
 
-func (m *GetProjectRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetProjectRequest.Unmarshal(m, b)
+func (m *UpdateProjectRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateProjectRequest.Unmarshal(m, b)
 }
-func (m *GetProjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetProjectRequest.Marshal(b, m, deterministic)
+func (m *UpdateProjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateProjectRequest.Marshal(b, m, deterministic)
 }
-func (m *GetProjectRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetProjectRequest.Merge(m, src)
+func (m *UpdateProjectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateProjectRequest.Merge(m, src)
 }
-func (m *GetProjectRequest) XXX_Size() int {
-	return xxx_messageInfo_GetProjectRequest.Size(m)
+func (m *UpdateProjectRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateProjectRequest.Size(m)
 }
-func (m *GetProjectRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetProjectRequest.DiscardUnknown(m)
+func (m *UpdateProjectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateProjectRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetProjectRequest proto.InternalMessageInfo
+var xxx_messageInfo_UpdateProjectRequest proto.InternalMessageInfo
 
-func (m *GetProjectRequest) GetName() string {
+func (m *UpdateProjectRequest) GetName() string {
 	if m != nil {
 		return m.Name
 	}
 	return ""
 }
 
-type ProjectResponse struct {
-	Project              *Project `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *UpdateProjectRequest) GetRules() []*Rule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
 }
 
-func (m *ProjectResponse) Reset()         { *m = ProjectResponse{} }
-func (m *ProjectResponse) String() string { return proto.CompactTextString(m) }
-func (*ProjectResponse) ProtoMessage()    {}
-func (*ProjectResponse) Descriptor() ([]byte, []int) {
+func (m *UpdateProjectRequest) GetUploadInterval() int64 {
+	if m != nil {
+		return m.UploadInterval
+	}
+	return 0
+}
+
+func (m *UpdateProjectRequest) GetCaps() *ProjectCaps {
+	if m != nil {
+		return m.Caps
+	}
+	return nil
+}
+
+func (m *UpdateProjectRequest) GetDateSource() *DateSource {
+	if m != nil {
+		return m.DateSource
+	}
+	return nil
+}
+
+type UpdateProjectResponse struct {
+	Project              *Project     `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Report               *RulesReport `protobuf:"bytes,2,opt,name=report,proto3" json:"report,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *UpdateProjectResponse) Reset()         { *m = UpdateProjectResponse{} }
+func (m *UpdateProjectResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateProjectResponse) ProtoMessage()    {}
+func (*UpdateProjectResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_00212fb1f9d3bf1c, []int{5}
 }
 
-func (m *ProjectResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ProjectResponse.Unmarshal(m, b)
+func (m *UpdateProjectResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateProjectResponse.Unmarshal(m, b)
 }
-func (m *ProjectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ProjectResponse.Marshal(b, m, deterministic)
+func (m *UpdateProjectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateProjectResponse.Marshal(b, m, deterministic)
 }
-func (m *ProjectResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ProjectResponse.Merge(m, src)
+func (m *UpdateProjectResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateProjectResponse.Merge(m, src)
 }
-func (m *ProjectResponse) XXX_Size() int {
-	return xxx_messageInfo_ProjectResponse.Size(m)
+func (m *UpdateProjectResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateProjectResponse.Size(m)
 }
-func (m *ProjectResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ProjectResponse.DiscardUnknown(m)
+func (m *UpdateProjectResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateProjectResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ProjectResponse proto.InternalMessageInfo
+var xxx_messageInfo_UpdateProjectResponse proto.InternalMessageInfo
 
-func (m *ProjectResponse) GetProject() *Project {
+func (m *UpdateProjectResponse) GetProject() *Project {
 	if m != nil {
 		return m.Project
 	}
 	return nil
 }
 
-type GetFilesRequest struct {
-	ProjectName          string   `protobuf:"bytes,1,opt,name=project_name,json=projectName,proto3" json:"project_name,omitempty"`
-	Limit                int32    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+func (m *UpdateProjectResponse) GetReport() *RulesReport {
+	if m != nil {
+		return m.Report
+	}
+	return nil
+}
+
+type RulesReport struct {
+	Warnings []*RuleWarning `protobuf:"bytes,1,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	// age reached by the oldest kept file before its removal, in seconds
+	MaxRetention int64 `protobuf:"varint,2,opt,name=max_retention,json=maxRetention,proto3" json:"max_retention,omitempty"`
+	// count of files kept at the same time, once the rules are fulfilled
+	ExpectedFileCount    int32    `protobuf:"varint,3,opt,name=expected_file_count,json=expectedFileCount,proto3" json:"expected_file_count,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetFilesRequest) Reset()         { *m = GetFilesRequest{} }
-func (m *GetFilesRequest) String() string { return proto.CompactTextString(m) }
-func (*GetFilesRequest) ProtoMessage()    {}
-func (*GetFilesRequest) Descriptor() ([]byte, []int) {
+func (m *RulesReport) Reset()         { *m = RulesReport{} }
+func (m *RulesReport) String() string { return proto.CompactTextString(m) }
+func (*RulesReport) ProtoMessage()    {}
+func (*RulesReport) Descriptor() ([]byte, []int) {
 	return fileDescriptor_00212fb1f9d3bf1c, []int{6}
 }
 
-func (m *GetFilesRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetFilesRequest.Unmarshal(m, b)
+func (m *RulesReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RulesReport.Unmarshal(m, b)
 }
-func (m *GetFilesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetFilesRequest.Marshal(b, m, deterministic)
+func (m *RulesReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RulesReport.Marshal(b, m, deterministic)
 }
-func (m *GetFilesRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetFilesRequest.Merge(m, src)
+func (m *RulesReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RulesReport.Merge(m, src)
 }
-func (m *GetFilesRequest) XXX_Size() int {
-	return xxx_messageInfo_GetFilesRequest.Size(m)
+func (m *RulesReport) XXX_Size() int {
+	return xxx_messageInfo_RulesReport.Size(m)
 }
-func (m *GetFilesRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetFilesRequest.DiscardUnknown(m)
+func (m *RulesReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_RulesReport.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetFilesRequest proto.InternalMessageInfo
+var xxx_messageInfo_RulesReport proto.InternalMessageInfo
 
-func (m *GetFilesRequest) GetProjectName() string {
+func (m *RulesReport) GetWarnings() []*RuleWarning {
 	if m != nil {
-		return m.ProjectName
+		return m.Warnings
 	}
-	return ""
+	return nil
 }
 
-func (m *GetFilesRequest) GetLimit() int32 {
+func (m *RulesReport) GetMaxRetention() int64 {
 	if m != nil {
-		return m.Limit
+		return m.MaxRetention
 	}
 	return 0
 }
 
-type GetFilesResponse struct {
-	Files                []*File  `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+func (m *RulesReport) GetExpectedFileCount() int32 {
+	if m != nil {
+		return m.ExpectedFileCount
+	}
+	return 0
+}
+
+type RuleWarning struct {
+	// subsumed, unsatisfiable or cap_conflict
+	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Rule                 *Rule    `protobuf:"bytes,2,opt,name=rule,proto3" json:"rule,omitempty"`
+	Message              string   `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetFilesResponse) Reset()         { *m = GetFilesResponse{} }
-func (m *GetFilesResponse) String() string { return proto.CompactTextString(m) }
-func (*GetFilesResponse) ProtoMessage()    {}
-func (*GetFilesResponse) Descriptor() ([]byte, []int) {
+func (m *RuleWarning) Reset()         { *m = RuleWarning{} }
+func (m *RuleWarning) String() string { return proto.CompactTextString(m) }
+func (*RuleWarning) ProtoMessage()    {}
+func (*RuleWarning) Descriptor() ([]byte, []int) {
 	return fileDescriptor_00212fb1f9d3bf1c, []int{7}
 }
 
-func (m *GetFilesResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetFilesResponse.Unmarshal(m, b)
+func (m *RuleWarning) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RuleWarning.Unmarshal(m, b)
 }
-func (m *GetFilesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetFilesResponse.Marshal(b, m, deterministic)
+func (m *RuleWarning) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RuleWarning.Marshal(b, m, deterministic)
 }
-func (m *GetFilesResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetFilesResponse.Merge(m, src)
+func (m *RuleWarning) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RuleWarning.Merge(m, src)
 }
-func (m *GetFilesResponse) XXX_Size() int {
-	return xxx_messageInfo_GetFilesResponse.Size(m)
+func (m *RuleWarning) XXX_Size() int {
+	return xxx_messageInfo_RuleWarning.Size(m)
 }
-func (m *GetFilesResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetFilesResponse.DiscardUnknown(m)
+func (m *RuleWarning) XXX_DiscardUnknown() {
+	xxx_messageInfo_RuleWarning.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetFilesResponse proto.InternalMessageInfo
+var xxx_messageInfo_RuleWarning proto.InternalMessageInfo
 
-func (m *GetFilesResponse) GetFiles() []*File {
+func (m *RuleWarning) GetType() string {
 	if m != nil {
-		return m.Files
+		return m.Type
+	}
+	return ""
+}
+
+func (m *RuleWarning) GetRule() *Rule {
+	if m != nil {
+		return m.Rule
 	}
 	return nil
 }
 
-type GetFileURLRequest struct {
-	Filepath             string   `protobuf:"bytes,1,opt,name=filepath,proto3" json:"filepath,omitempty"`
+func (m *RuleWarning) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type ApproveDeletionPlanRequest struct {
+	ProjectName string `protobuf:"bytes,1,opt,name=project_name,json=projectName,proto3" json:"project_name,omitempty"`
+	// the ID of the blocked plan: if the files to remove have changed since, a new approval is required
+	PlanId               string   `protobuf:"bytes,2,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetFileURLRequest) Reset()         { *m = GetFileURLRequest{} }
-func (m *GetFileURLRequest) String() string { return proto.CompactTextString(m) }
-func (*GetFileURLRequest) ProtoMessage()    {}
-func (*GetFileURLRequest) Descriptor() ([]byte, []int) {
+func (m *ApproveDeletionPlanRequest) Reset()         { *m = ApproveDeletionPlanRequest{} }
+func (m *ApproveDeletionPlanRequest) String() string { return proto.CompactTextString(m) }
+func (*ApproveDeletionPlanRequest) ProtoMessage()    {}
+func (*ApproveDeletionPlanRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_00212fb1f9d3bf1c, []int{8}
 }
 
-func (m *GetFileURLRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetFileURLRequest.Unmarshal(m, b)
+func (m *ApproveDeletionPlanRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApproveDeletionPlanRequest.Unmarshal(m, b)
 }
-func (m *GetFileURLRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetFileURLRequest.Marshal(b, m, deterministic)
+func (m *ApproveDeletionPlanRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApproveDeletionPlanRequest.Marshal(b, m, deterministic)
 }
-func (m *GetFileURLRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetFileURLRequest.Merge(m, src)
+func (m *ApproveDeletionPlanRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApproveDeletionPlanRequest.Merge(m, src)
 }
-func (m *GetFileURLRequest) XXX_Size() int {
-	return xxx_messageInfo_GetFileURLRequest.Size(m)
+func (m *ApproveDeletionPlanRequest) XXX_Size() int {
+	return xxx_messageInfo_ApproveDeletionPlanRequest.Size(m)
 }
-func (m *GetFileURLRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetFileURLRequest.DiscardUnknown(m)
+func (m *ApproveDeletionPlanRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApproveDeletionPlanRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetFileURLRequest proto.InternalMessageInfo
+var xxx_messageInfo_ApproveDeletionPlanRequest proto.InternalMessageInfo
 
-func (m *GetFileURLRequest) GetFilepath() string {
+func (m *ApproveDeletionPlanRequest) GetProjectName() string {
 	if m != nil {
-		return m.Filepath
+		return m.ProjectName
 	}
 	return ""
 }
 
-type GetFileURLResponse struct {
-	Url                  string   `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+func (m *ApproveDeletionPlanRequest) GetPlanId() string {
+	if m != nil {
+		return m.PlanId
+	}
+	return ""
+}
+
+type SetProjectStatusRequest struct {
+	ProjectName string `protobuf:"bytes,1,opt,name=project_name,json=projectName,proto3" json:"project_name,omitempty"`
+	// active, paused-deletions (the files are monitored but not removed) or paused-all
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// required to pause the project
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// date at which the project is automatically resumed (never if 0)
+	ResumeAt             int64    `protobuf:"varint,4,opt,name=resume_at,json=resumeAt,proto3" json:"resume_at,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetFileURLResponse) Reset()         { *m = GetFileURLResponse{} }
-func (m *GetFileURLResponse) String() string { return proto.CompactTextString(m) }
-func (*GetFileURLResponse) ProtoMessage()    {}
-func (*GetFileURLResponse) Descriptor() ([]byte, []int) {
+func (m *SetProjectStatusRequest) Reset()         { *m = SetProjectStatusRequest{} }
+func (m *SetProjectStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*SetProjectStatusRequest) ProtoMessage()    {}
+func (*SetProjectStatusRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_00212fb1f9d3bf1c, []int{9}
 }
 
-func (m *GetFileURLResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetFileURLResponse.Unmarshal(m, b)
+func (m *SetProjectStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetProjectStatusRequest.Unmarshal(m, b)
 }
-func (m *GetFileURLResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetFileURLResponse.Marshal(b, m, deterministic)
+func (m *SetProjectStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetProjectStatusRequest.Marshal(b, m, deterministic)
 }
-func (m *GetFileURLResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetFileURLResponse.Merge(m, src)
+func (m *SetProjectStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetProjectStatusRequest.Merge(m, src)
 }
-func (m *GetFileURLResponse) XXX_Size() int {
-	return xxx_messageInfo_GetFileURLResponse.Size(m)
+func (m *SetProjectStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_SetProjectStatusRequest.Size(m)
 }
-func (m *GetFileURLResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetFileURLResponse.DiscardUnknown(m)
+func (m *SetProjectStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetProjectStatusRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetFileURLResponse proto.InternalMessageInfo
+var xxx_messageInfo_SetProjectStatusRequest proto.InternalMessageInfo
 
-func (m *GetFileURLResponse) GetUrl() string {
+func (m *SetProjectStatusRequest) GetProjectName() string {
 	if m != nil {
-		return m.Url
+		return m.ProjectName
 	}
 	return ""
 }
 
-type CreateAccountRequest struct {
-	Username             string   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *SetProjectStatusRequest) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
 }
 
-func (m *CreateAccountRequest) Reset()         { *m = CreateAccountRequest{} }
-func (m *CreateAccountRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateAccountRequest) ProtoMessage()    {}
-func (*CreateAccountRequest) Descriptor() ([]byte, []int) {
+func (m *SetProjectStatusRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *SetProjectStatusRequest) GetResumeAt() int64 {
+	if m != nil {
+		return m.ResumeAt
+	}
+	return 0
+}
+
+type SimulateRulesRequest struct {
+	Rules []*Rule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	// count of simulated days, the process runs once a day (or every hour for the rules below a day)
+	Days int32 `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"`
+	// starts with the current files of this project (no file if empty)
+	ProjectName string `protobuf:"bytes,3,opt,name=project_name,json=projectName,proto3" json:"project_name,omitempty"`
+	// synthetic uploads during the simulation (no upload if not set)
+	Schedule             *UploadSchedule `protobuf:"bytes,4,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *SimulateRulesRequest) Reset()         { *m = SimulateRulesRequest{} }
+func (m *SimulateRulesRequest) String() string { return proto.CompactTextString(m) }
+func (*SimulateRulesRequest) ProtoMessage()    {}
+func (*SimulateRulesRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_00212fb1f9d3bf1c, []int{10}
 }
 
-func (m *CreateAccountRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateAccountRequest.Unmarshal(m, b)
+func (m *SimulateRulesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SimulateRulesRequest.Unmarshal(m, b)
 }
-func (m *CreateAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateAccountRequest.Marshal(b, m, deterministic)
+func (m *SimulateRulesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SimulateRulesRequest.Marshal(b, m, deterministic)
 }
-func (m *CreateAccountRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateAccountRequest.Merge(m, src)
+func (m *SimulateRulesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SimulateRulesRequest.Merge(m, src)
 }
-func (m *CreateAccountRequest) XXX_Size() int {
-	return xxx_messageInfo_CreateAccountRequest.Size(m)
+func (m *SimulateRulesRequest) XXX_Size() int {
+	return xxx_messageInfo_SimulateRulesRequest.Size(m)
 }
-func (m *CreateAccountRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateAccountRequest.DiscardUnknown(m)
+func (m *SimulateRulesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SimulateRulesRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateAccountRequest proto.InternalMessageInfo
+var xxx_messageInfo_SimulateRulesRequest proto.InternalMessageInfo
 
-func (m *CreateAccountRequest) GetUsername() string {
+func (m *SimulateRulesRequest) GetRules() []*Rule {
 	if m != nil {
-		return m.Username
+		return m.Rules
+	}
+	return nil
+}
+
+func (m *SimulateRulesRequest) GetDays() int32 {
+	if m != nil {
+		return m.Days
+	}
+	return 0
+}
+
+func (m *SimulateRulesRequest) GetProjectName() string {
+	if m != nil {
+		return m.ProjectName
 	}
 	return ""
 }
 
-type AccountResponse struct {
-	Account              *Account `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
-	Password             string   `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+func (m *SimulateRulesRequest) GetSchedule() *UploadSchedule {
+	if m != nil {
+		return m.Schedule
+	}
+	return nil
+}
+
+type SimulateRulesResponse struct {
+	Days []*SimulatedDay `protobuf:"bytes,1,rep,name=days,proto3" json:"days,omitempty"`
+	// files kept at the end of the simulation, newest first
+	Survivors            []*File  `protobuf:"bytes,2,rep,name=survivors,proto3" json:"survivors,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AccountResponse) Reset()         { *m = AccountResponse{} }
-func (m *AccountResponse) String() string { return proto.CompactTextString(m) }
-func (*AccountResponse) ProtoMessage()    {}
-func (*AccountResponse) Descriptor() ([]byte, []int) {
+func (m *SimulateRulesResponse) Reset()         { *m = SimulateRulesResponse{} }
+func (m *SimulateRulesResponse) String() string { return proto.CompactTextString(m) }
+func (*SimulateRulesResponse) ProtoMessage()    {}
+func (*SimulateRulesResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_00212fb1f9d3bf1c, []int{11}
 }
 
-func (m *AccountResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AccountResponse.Unmarshal(m, b)
+func (m *SimulateRulesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SimulateRulesResponse.Unmarshal(m, b)
 }
-func (m *AccountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AccountResponse.Marshal(b, m, deterministic)
+func (m *SimulateRulesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SimulateRulesResponse.Marshal(b, m, deterministic)
 }
-func (m *AccountResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AccountResponse.Merge(m, src)
+func (m *SimulateRulesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SimulateRulesResponse.Merge(m, src)
 }
-func (m *AccountResponse) XXX_Size() int {
-	return xxx_messageInfo_AccountResponse.Size(m)
+func (m *SimulateRulesResponse) XXX_Size() int {
+	return xxx_messageInfo_SimulateRulesResponse.Size(m)
 }
-func (m *AccountResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_AccountResponse.DiscardUnknown(m)
+func (m *SimulateRulesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SimulateRulesResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AccountResponse proto.InternalMessageInfo
+var xxx_messageInfo_SimulateRulesResponse proto.InternalMessageInfo
 
-func (m *AccountResponse) GetAccount() *Account {
+func (m *SimulateRulesResponse) GetDays() []*SimulatedDay {
 	if m != nil {
-		return m.Account
+		return m.Days
 	}
 	return nil
 }
 
-func (m *AccountResponse) GetPassword() string {
+func (m *SimulateRulesResponse) GetSurvivors() []*File {
 	if m != nil {
-		return m.Password
+		return m.Survivors
 	}
-	return ""
+	return nil
 }
 
-type ListAccountsRequest struct {
+type UploadSchedule struct {
+	// delay between two uploads, in seconds (1 day if 0)
+	Interval int64 `protobuf:"varint,1,opt,name=interval,proto3" json:"interval,omitempty"`
+	Size     int64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// periods without upload
+	Gaps []*DayRange `protobuf:"bytes,3,rep,name=gaps,proto3" json:"gaps,omitempty"`
+	// the size is multiplied by shrink_ratio from this day (no shrink if 0)
+	ShrinkFrom           int32    `protobuf:"varint,4,opt,name=shrink_from,json=shrinkFrom,proto3" json:"shrink_from,omitempty"`
+	ShrinkRatio          float64  `protobuf:"fixed64,5,opt,name=shrink_ratio,json=shrinkRatio,proto3" json:"shrink_ratio,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ListAccountsRequest) Reset()         { *m = ListAccountsRequest{} }
-func (m *ListAccountsRequest) String() string { return proto.CompactTextString(m) }
-func (*ListAccountsRequest) ProtoMessage()    {}
-func (*ListAccountsRequest) Descriptor() ([]byte, []int) {
+func (m *UploadSchedule) Reset()         { *m = UploadSchedule{} }
+func (m *UploadSchedule) String() string { return proto.CompactTextString(m) }
+func (*UploadSchedule) ProtoMessage()    {}
+func (*UploadSchedule) Descriptor() ([]byte, []int) {
 	return fileDescriptor_00212fb1f9d3bf1c, []int{12}
 }
 
-func (m *ListAccountsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListAccountsRequest.Unmarshal(m, b)
+func (m *UploadSchedule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UploadSchedule.Unmarshal(m, b)
 }
-func (m *ListAccountsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListAccountsRequest.Marshal(b, m, deterministic)
+func (m *UploadSchedule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UploadSchedule.Marshal(b, m, deterministic)
 }
-func (m *ListAccountsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListAccountsRequest.Merge(m, src)
+func (m *UploadSchedule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UploadSchedule.Merge(m, src)
 }
-func (m *ListAccountsRequest) XXX_Size() int {
-	return xxx_messageInfo_ListAccountsRequest.Size(m)
+func (m *UploadSchedule) XXX_Size() int {
+	return xxx_messageInfo_UploadSchedule.Size(m)
 }
-func (m *ListAccountsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListAccountsRequest.DiscardUnknown(m)
+func (m *UploadSchedule) XXX_DiscardUnknown() {
+	xxx_messageInfo_UploadSchedule.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListAccountsRequest proto.InternalMessageInfo
+var xxx_messageInfo_UploadSchedule proto.InternalMessageInfo
 
-type AccountsListResponse struct {
-	Accounts             []*Account `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+func (m *UploadSchedule) GetInterval() int64 {
+	if m != nil {
+		return m.Interval
+	}
+	return 0
 }
 
-func (m *AccountsListResponse) Reset()         { *m = AccountsListResponse{} }
-func (m *AccountsListResponse) String() string { return proto.CompactTextString(m) }
-func (*AccountsListResponse) ProtoMessage()    {}
-func (*AccountsListResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{13}
+func (m *UploadSchedule) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
 }
 
-func (m *AccountsListResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AccountsListResponse.Unmarshal(m, b)
-}
-func (m *AccountsListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AccountsListResponse.Marshal(b, m, deterministic)
-}
-func (m *AccountsListResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AccountsListResponse.Merge(m, src)
-}
-func (m *AccountsListResponse) XXX_Size() int {
-	return xxx_messageInfo_AccountsListResponse.Size(m)
-}
-func (m *AccountsListResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_AccountsListResponse.DiscardUnknown(m)
+func (m *UploadSchedule) GetGaps() []*DayRange {
+	if m != nil {
+		return m.Gaps
+	}
+	return nil
 }
 
-var xxx_messageInfo_AccountsListResponse proto.InternalMessageInfo
+func (m *UploadSchedule) GetShrinkFrom() int32 {
+	if m != nil {
+		return m.ShrinkFrom
+	}
+	return 0
+}
 
-func (m *AccountsListResponse) GetAccounts() []*Account {
+func (m *UploadSchedule) GetShrinkRatio() float64 {
 	if m != nil {
-		return m.Accounts
+		return m.ShrinkRatio
 	}
-	return nil
+	return 0
 }
 
-type AuthenticateAccountRequest struct {
-	Username             string   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Password             string   `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+// DayRange is a range of days since the start of the simulation, to being excluded
+type DayRange struct {
+	From                 int32    `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	To                   int32    `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AuthenticateAccountRequest) Reset()         { *m = AuthenticateAccountRequest{} }
-func (m *AuthenticateAccountRequest) String() string { return proto.CompactTextString(m) }
-func (*AuthenticateAccountRequest) ProtoMessage()    {}
-func (*AuthenticateAccountRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{14}
+func (m *DayRange) Reset()         { *m = DayRange{} }
+func (m *DayRange) String() string { return proto.CompactTextString(m) }
+func (*DayRange) ProtoMessage()    {}
+func (*DayRange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{13}
 }
 
-func (m *AuthenticateAccountRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AuthenticateAccountRequest.Unmarshal(m, b)
+func (m *DayRange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DayRange.Unmarshal(m, b)
 }
-func (m *AuthenticateAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AuthenticateAccountRequest.Marshal(b, m, deterministic)
+func (m *DayRange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DayRange.Marshal(b, m, deterministic)
 }
-func (m *AuthenticateAccountRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AuthenticateAccountRequest.Merge(m, src)
+func (m *DayRange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DayRange.Merge(m, src)
 }
-func (m *AuthenticateAccountRequest) XXX_Size() int {
-	return xxx_messageInfo_AuthenticateAccountRequest.Size(m)
+func (m *DayRange) XXX_Size() int {
+	return xxx_messageInfo_DayRange.Size(m)
 }
-func (m *AuthenticateAccountRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_AuthenticateAccountRequest.DiscardUnknown(m)
+func (m *DayRange) XXX_DiscardUnknown() {
+	xxx_messageInfo_DayRange.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AuthenticateAccountRequest proto.InternalMessageInfo
+var xxx_messageInfo_DayRange proto.InternalMessageInfo
 
-func (m *AuthenticateAccountRequest) GetUsername() string {
+func (m *DayRange) GetFrom() int32 {
 	if m != nil {
-		return m.Username
+		return m.From
 	}
-	return ""
+	return 0
 }
 
-func (m *AuthenticateAccountRequest) GetPassword() string {
+func (m *DayRange) GetTo() int32 {
 	if m != nil {
-		return m.Password
+		return m.To
 	}
-	return ""
+	return 0
 }
 
-type AuthenticateAccountResponse struct {
-	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+type SimulatedDay struct {
+	Date     int64   `protobuf:"varint,1,opt,name=date,proto3" json:"date,omitempty"`
+	Uploaded []*File `protobuf:"bytes,2,rep,name=uploaded,proto3" json:"uploaded,omitempty"`
+	Removed  []*File `protobuf:"bytes,3,rep,name=removed,proto3" json:"removed,omitempty"`
+	// storage used at the end of the day
+	FileCount int32 `protobuf:"varint,4,opt,name=file_count,json=fileCount,proto3" json:"file_count,omitempty"`
+	TotalSize int64 `protobuf:"varint,5,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	// reasons of the alerts raised by the state of the project (none if healthy)
+	Alerts               []string `protobuf:"bytes,6,rep,name=alerts,proto3" json:"alerts,omitempty"`
+	AlertLevel           string   `protobuf:"bytes,7,opt,name=alert_level,json=alertLevel,proto3" json:"alert_level,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AuthenticateAccountResponse) Reset()         { *m = AuthenticateAccountResponse{} }
-func (m *AuthenticateAccountResponse) String() string { return proto.CompactTextString(m) }
-func (*AuthenticateAccountResponse) ProtoMessage()    {}
-func (*AuthenticateAccountResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{15}
+func (m *SimulatedDay) Reset()         { *m = SimulatedDay{} }
+func (m *SimulatedDay) String() string { return proto.CompactTextString(m) }
+func (*SimulatedDay) ProtoMessage()    {}
+func (*SimulatedDay) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{14}
 }
 
-func (m *AuthenticateAccountResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AuthenticateAccountResponse.Unmarshal(m, b)
+func (m *SimulatedDay) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SimulatedDay.Unmarshal(m, b)
 }
-func (m *AuthenticateAccountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AuthenticateAccountResponse.Marshal(b, m, deterministic)
+func (m *SimulatedDay) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SimulatedDay.Marshal(b, m, deterministic)
 }
-func (m *AuthenticateAccountResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AuthenticateAccountResponse.Merge(m, src)
+func (m *SimulatedDay) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SimulatedDay.Merge(m, src)
 }
-func (m *AuthenticateAccountResponse) XXX_Size() int {
-	return xxx_messageInfo_AuthenticateAccountResponse.Size(m)
+func (m *SimulatedDay) XXX_Size() int {
+	return xxx_messageInfo_SimulatedDay.Size(m)
 }
-func (m *AuthenticateAccountResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_AuthenticateAccountResponse.DiscardUnknown(m)
+func (m *SimulatedDay) XXX_DiscardUnknown() {
+	xxx_messageInfo_SimulatedDay.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AuthenticateAccountResponse proto.InternalMessageInfo
+var xxx_messageInfo_SimulatedDay proto.InternalMessageInfo
 
-func (m *AuthenticateAccountResponse) GetToken() string {
+func (m *SimulatedDay) GetDate() int64 {
 	if m != nil {
-		return m.Token
+		return m.Date
 	}
-	return ""
+	return 0
 }
 
-type ChangeAccountPasswordRequest struct {
-	Username             string   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *SimulatedDay) GetUploaded() []*File {
+	if m != nil {
+		return m.Uploaded
+	}
+	return nil
 }
 
-func (m *ChangeAccountPasswordRequest) Reset()         { *m = ChangeAccountPasswordRequest{} }
-func (m *ChangeAccountPasswordRequest) String() string { return proto.CompactTextString(m) }
-func (*ChangeAccountPasswordRequest) ProtoMessage()    {}
-func (*ChangeAccountPasswordRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{16}
+func (m *SimulatedDay) GetRemoved() []*File {
+	if m != nil {
+		return m.Removed
+	}
+	return nil
 }
 
-func (m *ChangeAccountPasswordRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ChangeAccountPasswordRequest.Unmarshal(m, b)
-}
-func (m *ChangeAccountPasswordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ChangeAccountPasswordRequest.Marshal(b, m, deterministic)
-}
-func (m *ChangeAccountPasswordRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ChangeAccountPasswordRequest.Merge(m, src)
-}
-func (m *ChangeAccountPasswordRequest) XXX_Size() int {
-	return xxx_messageInfo_ChangeAccountPasswordRequest.Size(m)
+func (m *SimulatedDay) GetFileCount() int32 {
+	if m != nil {
+		return m.FileCount
+	}
+	return 0
 }
-func (m *ChangeAccountPasswordRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ChangeAccountPasswordRequest.DiscardUnknown(m)
+
+func (m *SimulatedDay) GetTotalSize() int64 {
+	if m != nil {
+		return m.TotalSize
+	}
+	return 0
 }
 
-var xxx_messageInfo_ChangeAccountPasswordRequest proto.InternalMessageInfo
+func (m *SimulatedDay) GetAlerts() []string {
+	if m != nil {
+		return m.Alerts
+	}
+	return nil
+}
 
-func (m *ChangeAccountPasswordRequest) GetUsername() string {
+func (m *SimulatedDay) GetAlertLevel() string {
 	if m != nil {
-		return m.Username
+		return m.AlertLevel
 	}
 	return ""
 }
 
-type Project struct {
+type GetProjectRequest struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Rules                []*Rule  `protobuf:"bytes,2,rep,name=rules,proto3" json:"rules,omitempty"`
-	CreatedAt            int64    `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	IssuesCount          int32    `protobuf:"varint,4,opt,name=issues_count,json=issuesCount,proto3" json:"issues_count,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Project) Reset()         { *m = Project{} }
-func (m *Project) String() string { return proto.CompactTextString(m) }
-func (*Project) ProtoMessage()    {}
-func (*Project) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{17}
+func (m *GetProjectRequest) Reset()         { *m = GetProjectRequest{} }
+func (m *GetProjectRequest) String() string { return proto.CompactTextString(m) }
+func (*GetProjectRequest) ProtoMessage()    {}
+func (*GetProjectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{15}
 }
 
-func (m *Project) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Project.Unmarshal(m, b)
+func (m *GetProjectRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetProjectRequest.Unmarshal(m, b)
 }
-func (m *Project) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Project.Marshal(b, m, deterministic)
+func (m *GetProjectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetProjectRequest.Marshal(b, m, deterministic)
 }
-func (m *Project) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Project.Merge(m, src)
+func (m *GetProjectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetProjectRequest.Merge(m, src)
 }
-func (m *Project) XXX_Size() int {
-	return xxx_messageInfo_Project.Size(m)
+func (m *GetProjectRequest) XXX_Size() int {
+	return xxx_messageInfo_GetProjectRequest.Size(m)
 }
-func (m *Project) XXX_DiscardUnknown() {
-	xxx_messageInfo_Project.DiscardUnknown(m)
+func (m *GetProjectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetProjectRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Project proto.InternalMessageInfo
+var xxx_messageInfo_GetProjectRequest proto.InternalMessageInfo
 
-func (m *Project) GetName() string {
+func (m *GetProjectRequest) GetName() string {
 	if m != nil {
 		return m.Name
 	}
 	return ""
 }
 
-func (m *Project) GetRules() []*Rule {
-	if m != nil {
-		return m.Rules
-	}
-	return nil
-}
-
-func (m *Project) GetCreatedAt() int64 {
-	if m != nil {
-		return m.CreatedAt
-	}
-	return 0
-}
-
-func (m *Project) GetIssuesCount() int32 {
-	if m != nil {
-		return m.IssuesCount
-	}
-	return 0
-}
-
-type Rule struct {
-	MinAge int32 `protobuf:"varint,1,opt,name=min_age,json=minAge,proto3" json:"min_age,omitempty"`
-	Count  int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
-	// state (readonly)
-	Files                []*File  `protobuf:"bytes,3,rep,name=files,proto3" json:"files,omitempty"`
-	NextDate             int64    `protobuf:"varint,4,opt,name=next_date,json=nextDate,proto3" json:"next_date,omitempty"`
-	Error                Error    `protobuf:"varint,5,opt,name=error,proto3,enum=Error" json:"error,omitempty"`
+type ProjectResponse struct {
+	Project              *Project `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Rule) Reset()         { *m = Rule{} }
-func (m *Rule) String() string { return proto.CompactTextString(m) }
-func (*Rule) ProtoMessage()    {}
-func (*Rule) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{18}
+func (m *ProjectResponse) Reset()         { *m = ProjectResponse{} }
+func (m *ProjectResponse) String() string { return proto.CompactTextString(m) }
+func (*ProjectResponse) ProtoMessage()    {}
+func (*ProjectResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{16}
 }
 
-func (m *Rule) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Rule.Unmarshal(m, b)
+func (m *ProjectResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProjectResponse.Unmarshal(m, b)
 }
-func (m *Rule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Rule.Marshal(b, m, deterministic)
+func (m *ProjectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProjectResponse.Marshal(b, m, deterministic)
 }
-func (m *Rule) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Rule.Merge(m, src)
+func (m *ProjectResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProjectResponse.Merge(m, src)
 }
-func (m *Rule) XXX_Size() int {
-	return xxx_messageInfo_Rule.Size(m)
+func (m *ProjectResponse) XXX_Size() int {
+	return xxx_messageInfo_ProjectResponse.Size(m)
 }
-func (m *Rule) XXX_DiscardUnknown() {
-	xxx_messageInfo_Rule.DiscardUnknown(m)
+func (m *ProjectResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProjectResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Rule proto.InternalMessageInfo
+var xxx_messageInfo_ProjectResponse proto.InternalMessageInfo
 
-func (m *Rule) GetMinAge() int32 {
+func (m *ProjectResponse) GetProject() *Project {
 	if m != nil {
-		return m.MinAge
+		return m.Project
 	}
-	return 0
+	return nil
 }
 
-func (m *Rule) GetCount() int32 {
-	if m != nil {
-		return m.Count
-	}
-	return 0
+type GetFilesRequest struct {
+	ProjectName          string   `protobuf:"bytes,1,opt,name=project_name,json=projectName,proto3" json:"project_name,omitempty"`
+	Limit                int32    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Rule) GetFiles() []*File {
+func (m *GetFilesRequest) Reset()         { *m = GetFilesRequest{} }
+func (m *GetFilesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetFilesRequest) ProtoMessage()    {}
+func (*GetFilesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{17}
+}
+
+func (m *GetFilesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFilesRequest.Unmarshal(m, b)
+}
+func (m *GetFilesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFilesRequest.Marshal(b, m, deterministic)
+}
+func (m *GetFilesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFilesRequest.Merge(m, src)
+}
+func (m *GetFilesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetFilesRequest.Size(m)
+}
+func (m *GetFilesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFilesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFilesRequest proto.InternalMessageInfo
+
+func (m *GetFilesRequest) GetProjectName() string {
 	if m != nil {
-		return m.Files
+		return m.ProjectName
 	}
-	return nil
+	return ""
 }
 
-func (m *Rule) GetNextDate() int64 {
+func (m *GetFilesRequest) GetLimit() int32 {
 	if m != nil {
-		return m.NextDate
+		return m.Limit
 	}
 	return 0
 }
 
-func (m *Rule) GetError() Error {
+type GetFilesResponse struct {
+	Files                []*File  `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetFilesResponse) Reset()         { *m = GetFilesResponse{} }
+func (m *GetFilesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetFilesResponse) ProtoMessage()    {}
+func (*GetFilesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{18}
+}
+
+func (m *GetFilesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFilesResponse.Unmarshal(m, b)
+}
+func (m *GetFilesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFilesResponse.Marshal(b, m, deterministic)
+}
+func (m *GetFilesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFilesResponse.Merge(m, src)
+}
+func (m *GetFilesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetFilesResponse.Size(m)
+}
+func (m *GetFilesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFilesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFilesResponse proto.InternalMessageInfo
+
+func (m *GetFilesResponse) GetFiles() []*File {
 	if m != nil {
-		return m.Error
+		return m.Files
 	}
-	return Error_NO_ERROR
+	return nil
 }
 
-type File struct {
-	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
-	Date                 int64    `protobuf:"varint,2,opt,name=date,proto3" json:"date,omitempty"`
-	Size                 int64    `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
-	Expiration           int64    `protobuf:"varint,4,opt,name=expiration,proto3" json:"expiration,omitempty"`
-	Error                Error    `protobuf:"varint,5,opt,name=error,proto3,enum=Error" json:"error,omitempty"`
+type GetFileURLRequest struct {
+	Filepath string `protobuf:"bytes,1,opt,name=filepath,proto3" json:"filepath,omitempty"`
+	// lifetime of the URL in seconds (15 minutes if zero), capped by the server
+	Expiry int64 `protobuf:"varint,2,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	// forces the browser to download the file, saved with this name
+	DownloadFilename string `protobuf:"bytes,3,opt,name=download_filename,json=downloadFilename,proto3" json:"download_filename,omitempty"`
+	// returns a URL of the daemon, invalidated after the first download
+	OneTime              bool     `protobuf:"varint,4,opt,name=one_time,json=oneTime,proto3" json:"one_time,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *File) Reset()         { *m = File{} }
-func (m *File) String() string { return proto.CompactTextString(m) }
-func (*File) ProtoMessage()    {}
-func (*File) Descriptor() ([]byte, []int) {
+func (m *GetFileURLRequest) Reset()         { *m = GetFileURLRequest{} }
+func (m *GetFileURLRequest) String() string { return proto.CompactTextString(m) }
+func (*GetFileURLRequest) ProtoMessage()    {}
+func (*GetFileURLRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_00212fb1f9d3bf1c, []int{19}
 }
 
-func (m *File) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_File.Unmarshal(m, b)
+func (m *GetFileURLRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFileURLRequest.Unmarshal(m, b)
 }
-func (m *File) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_File.Marshal(b, m, deterministic)
+func (m *GetFileURLRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFileURLRequest.Marshal(b, m, deterministic)
 }
-func (m *File) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_File.Merge(m, src)
+func (m *GetFileURLRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFileURLRequest.Merge(m, src)
 }
-func (m *File) XXX_Size() int {
-	return xxx_messageInfo_File.Size(m)
+func (m *GetFileURLRequest) XXX_Size() int {
+	return xxx_messageInfo_GetFileURLRequest.Size(m)
 }
-func (m *File) XXX_DiscardUnknown() {
-	xxx_messageInfo_File.DiscardUnknown(m)
+func (m *GetFileURLRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFileURLRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_File proto.InternalMessageInfo
+var xxx_messageInfo_GetFileURLRequest proto.InternalMessageInfo
 
-func (m *File) GetPath() string {
+func (m *GetFileURLRequest) GetFilepath() string {
 	if m != nil {
-		return m.Path
+		return m.Filepath
 	}
 	return ""
 }
 
-func (m *File) GetDate() int64 {
+func (m *GetFileURLRequest) GetExpiry() int64 {
 	if m != nil {
-		return m.Date
+		return m.Expiry
 	}
 	return 0
 }
 
-func (m *File) GetSize() int64 {
+func (m *GetFileURLRequest) GetDownloadFilename() string {
 	if m != nil {
-		return m.Size
+		return m.DownloadFilename
 	}
-	return 0
+	return ""
 }
 
-func (m *File) GetExpiration() int64 {
+func (m *GetFileURLRequest) GetOneTime() bool {
 	if m != nil {
-		return m.Expiration
+		return m.OneTime
 	}
-	return 0
+	return false
 }
 
-func (m *File) GetError() Error {
+type GetFileURLResponse struct {
+	Url                  string   `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	ExpiresAt            int64    `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetFileURLResponse) Reset()         { *m = GetFileURLResponse{} }
+func (m *GetFileURLResponse) String() string { return proto.CompactTextString(m) }
+func (*GetFileURLResponse) ProtoMessage()    {}
+func (*GetFileURLResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{20}
+}
+
+func (m *GetFileURLResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFileURLResponse.Unmarshal(m, b)
+}
+func (m *GetFileURLResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFileURLResponse.Marshal(b, m, deterministic)
+}
+func (m *GetFileURLResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFileURLResponse.Merge(m, src)
+}
+func (m *GetFileURLResponse) XXX_Size() int {
+	return xxx_messageInfo_GetFileURLResponse.Size(m)
+}
+func (m *GetFileURLResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFileURLResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFileURLResponse proto.InternalMessageInfo
+
+func (m *GetFileURLResponse) GetUrl() string {
 	if m != nil {
-		return m.Error
+		return m.Url
 	}
-	return Error_NO_ERROR
+	return ""
 }
 
-type Account struct {
-	Username             string   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+func (m *GetFileURLResponse) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+type DownloadFileRequest struct {
+	Filepath string `protobuf:"bytes,1,opt,name=filepath,proto3" json:"filepath,omitempty"`
+	// position in the file to start the download from, to resume an interrupted download
+	Offset               int64    `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Account) Reset()         { *m = Account{} }
-func (m *Account) String() string { return proto.CompactTextString(m) }
-func (*Account) ProtoMessage()    {}
-func (*Account) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{20}
+func (m *DownloadFileRequest) Reset()         { *m = DownloadFileRequest{} }
+func (m *DownloadFileRequest) String() string { return proto.CompactTextString(m) }
+func (*DownloadFileRequest) ProtoMessage()    {}
+func (*DownloadFileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{21}
 }
 
-func (m *Account) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Account.Unmarshal(m, b)
+func (m *DownloadFileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DownloadFileRequest.Unmarshal(m, b)
 }
-func (m *Account) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Account.Marshal(b, m, deterministic)
+func (m *DownloadFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DownloadFileRequest.Marshal(b, m, deterministic)
 }
-func (m *Account) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Account.Merge(m, src)
+func (m *DownloadFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DownloadFileRequest.Merge(m, src)
 }
-func (m *Account) XXX_Size() int {
-	return xxx_messageInfo_Account.Size(m)
+func (m *DownloadFileRequest) XXX_Size() int {
+	return xxx_messageInfo_DownloadFileRequest.Size(m)
 }
-func (m *Account) XXX_DiscardUnknown() {
-	xxx_messageInfo_Account.DiscardUnknown(m)
+func (m *DownloadFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DownloadFileRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Account proto.InternalMessageInfo
+var xxx_messageInfo_DownloadFileRequest proto.InternalMessageInfo
 
-func (m *Account) GetUsername() string {
+func (m *DownloadFileRequest) GetFilepath() string {
 	if m != nil {
-		return m.Username
+		return m.Filepath
 	}
 	return ""
 }
 
-func init() {
-	proto.RegisterEnum("Error", Error_name, Error_value)
-	proto.RegisterEnum("GetProjectsRequest_OrderBy", GetProjectsRequest_OrderBy_name, GetProjectsRequest_OrderBy_value)
-	proto.RegisterEnum("GetProjectsRequest_OrderDirection", GetProjectsRequest_OrderDirection_name, GetProjectsRequest_OrderDirection_value)
-	proto.RegisterType((*GetProjectsRequest)(nil), "GetProjectsRequest")
-	proto.RegisterType((*ProjectsListResponse)(nil), "ProjectsListResponse")
-	proto.RegisterType((*CreateProjectRequest)(nil), "CreateProjectRequest")
-	proto.RegisterType((*CreateProjectResponse)(nil), "CreateProjectResponse")
-	proto.RegisterType((*GetProjectRequest)(nil), "GetProjectRequest")
-	proto.RegisterType((*ProjectResponse)(nil), "ProjectResponse")
-	proto.RegisterType((*GetFilesRequest)(nil), "GetFilesRequest")
-	proto.RegisterType((*GetFilesResponse)(nil), "GetFilesResponse")
-	proto.RegisterType((*GetFileURLRequest)(nil), "GetFileURLRequest")
-	proto.RegisterType((*GetFileURLResponse)(nil), "GetFileURLResponse")
-	proto.RegisterType((*CreateAccountRequest)(nil), "CreateAccountRequest")
-	proto.RegisterType((*AccountResponse)(nil), "AccountResponse")
-	proto.RegisterType((*ListAccountsRequest)(nil), "ListAccountsRequest")
-	proto.RegisterType((*AccountsListResponse)(nil), "AccountsListResponse")
-	proto.RegisterType((*AuthenticateAccountRequest)(nil), "AuthenticateAccountRequest")
-	proto.RegisterType((*AuthenticateAccountResponse)(nil), "AuthenticateAccountResponse")
-	proto.RegisterType((*ChangeAccountPasswordRequest)(nil), "ChangeAccountPasswordRequest")
-	proto.RegisterType((*Project)(nil), "Project")
-	proto.RegisterType((*Rule)(nil), "Rule")
-	proto.RegisterType((*File)(nil), "File")
-	proto.RegisterType((*Account)(nil), "Account")
+func (m *DownloadFileRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
 }
 
-func init() { proto.RegisterFile("api.proto", fileDescriptor_00212fb1f9d3bf1c) }
+type FileChunk struct {
+	// position of the data in the file
+	Offset int64  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Data   []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// total size of the file, set on the first chunk
+	Size int64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	// hex-encoded SHA-256 checksum of the whole file, set on the last chunk (without data)
+	Sha256               string   `protobuf:"bytes,4,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
 
-var fileDescriptor_00212fb1f9d3bf1c = []byte{
-	// 952 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xdb, 0x6e, 0xe3, 0x44,
-	0x18, 0xae, 0x93, 0xb8, 0x71, 0xfe, 0xb4, 0x5b, 0x77, 0x92, 0x40, 0xe4, 0x74, 0x51, 0x19, 0x4e,
-	0x15, 0x17, 0x53, 0x29, 0xab, 0x45, 0x0b, 0x8b, 0x40, 0x6e, 0xe2, 0x5d, 0x0a, 0xd9, 0xb8, 0x4c,
-	0x52, 0x21, 0x71, 0x63, 0x79, 0x93, 0xa1, 0x35, 0x9b, 0xd8, 0xc1, 0x9e, 0x88, 0x16, 0x71, 0xc1,
-	0x0b, 0xf0, 0x36, 0x3c, 0x16, 0x0f, 0x81, 0x66, 0x3c, 0x76, 0xd2, 0xc4, 0x2d, 0x2c, 0x57, 0x99,
-	0xf9, 0xfe, 0xf3, 0x61, 0x3e, 0x07, 0x6a, 0xfe, 0x22, 0x20, 0x8b, 0x38, 0xe2, 0x11, 0xfe, 0x5b,
-	0x03, 0xf4, 0x92, 0xf1, 0x8b, 0x38, 0xfa, 0x99, 0x4d, 0x78, 0x42, 0xd9, 0x2f, 0x4b, 0x96, 0x70,
-	0xf4, 0x19, 0x18, 0x51, 0x3c, 0x65, 0xb1, 0xf7, 0xfa, 0xb6, 0xad, 0x1d, 0x6b, 0x27, 0x8f, 0xba,
-	0x1d, 0xb2, 0xad, 0x46, 0x5c, 0xa1, 0x73, 0x76, 0x4b, 0xab, 0x51, 0x7a, 0x40, 0x5f, 0x43, 0x2d,
-	0xb5, 0x9b, 0x06, 0x71, 0xbb, 0x24, 0x0d, 0xf1, 0xbd, 0x86, 0xfd, 0x20, 0x66, 0x13, 0x1e, 0x44,
-	0x21, 0x4d, 0x83, 0xf5, 0x83, 0x18, 0x3f, 0x83, 0xaa, 0x72, 0x8a, 0x0c, 0xa8, 0x0c, 0xed, 0x57,
-	0x8e, 0xb9, 0x83, 0x0e, 0x61, 0xbf, 0x47, 0x1d, 0x7b, 0x7c, 0xee, 0x0e, 0xbd, 0xbe, 0x3d, 0x76,
-	0x4c, 0x0d, 0x99, 0xb0, 0x77, 0x3e, 0x1a, 0x5d, 0x3a, 0x23, 0xaf, 0xe7, 0x5e, 0x0e, 0xc7, 0x66,
-	0x09, 0x7f, 0x00, 0x8f, 0xee, 0x7a, 0x45, 0x55, 0x28, 0xdb, 0xa3, 0x9e, 0xb9, 0x23, 0x3c, 0xf5,
-	0x9d, 0x51, 0xcf, 0xd4, 0x30, 0x85, 0x66, 0x96, 0xca, 0x20, 0x48, 0x38, 0x65, 0xc9, 0x22, 0x0a,
-	0x13, 0x86, 0x3e, 0x04, 0x63, 0xa1, 0xf0, 0xb6, 0x76, 0x5c, 0x3e, 0xa9, 0x77, 0x0d, 0xa2, 0x14,
-	0x69, 0x2e, 0x41, 0x4d, 0xd0, 0x79, 0xc4, 0xfd, 0x99, 0xac, 0x4c, 0xa7, 0xe9, 0x05, 0xdf, 0x40,
-	0xb3, 0x17, 0x33, 0x9f, 0xb3, 0xcc, 0x40, 0xf5, 0x10, 0x41, 0x25, 0xf4, 0xe7, 0x4c, 0xf6, 0xaf,
-	0x46, 0xe5, 0x19, 0x75, 0x40, 0x8f, 0x97, 0x33, 0x96, 0xb4, 0x4b, 0x32, 0x88, 0x4e, 0xe8, 0x72,
-	0xc6, 0x68, 0x8a, 0xa1, 0x53, 0x68, 0x2c, 0xe2, 0x68, 0xc2, 0x92, 0xc4, 0x0b, 0xe6, 0x73, 0x36,
-	0x0d, 0x7c, 0xce, 0x66, 0xb7, 0xed, 0xf2, 0xb1, 0x76, 0x62, 0x50, 0xa4, 0x44, 0xe7, 0x2b, 0x09,
-	0x7e, 0x0e, 0xad, 0x8d, 0xc8, 0xaa, 0x1c, 0x0c, 0x55, 0x95, 0xb4, 0x8c, 0xbe, 0x5e, 0x4d, 0x26,
-	0xc0, 0x9f, 0xc0, 0xe1, 0x6a, 0x30, 0x0f, 0xe4, 0x8c, 0x9f, 0xc2, 0xc1, 0xff, 0xf1, 0xff, 0x2d,
-	0x1c, 0xbc, 0x64, 0xfc, 0x45, 0x30, 0x63, 0xf9, 0x56, 0xbd, 0x0f, 0x7b, 0x4a, 0xea, 0xad, 0x45,
-	0xa9, 0x2b, 0x6c, 0x28, 0x1a, 0xd4, 0x04, 0x7d, 0x16, 0xcc, 0x03, 0x9e, 0xb5, 0x58, 0x5e, 0xf0,
-	0x29, 0x98, 0x2b, 0x5f, 0x2a, 0x87, 0x0e, 0xe8, 0x3f, 0x09, 0x40, 0xcd, 0x4b, 0x27, 0x42, 0x4c,
-	0x53, 0x0c, 0x9f, 0xca, 0xe2, 0x04, 0x72, 0x49, 0x07, 0x59, 0x78, 0x0b, 0x0c, 0x21, 0x5d, 0xf8,
-	0xfc, 0x5a, 0x85, 0xce, 0xef, 0xf8, 0x63, 0xf9, 0x0c, 0x72, 0x03, 0x15, 0xc3, 0x84, 0xf2, 0x32,
-	0x9e, 0x29, 0x65, 0x71, 0xc4, 0xdd, 0x6c, 0xd8, 0xf6, 0x64, 0x12, 0x2d, 0x43, 0xbe, 0xe6, 0x7b,
-	0x99, 0xb0, 0x78, 0xad, 0xac, 0xfc, 0x8e, 0xbf, 0x87, 0x83, 0x5c, 0x7b, 0xd5, 0x40, 0x3f, 0x85,
-	0xf2, 0x06, 0x66, 0x2a, 0x99, 0x40, 0xb8, 0x5c, 0xf8, 0x49, 0xf2, 0x6b, 0x14, 0x4f, 0x65, 0x37,
-	0x6a, 0x34, 0xbf, 0xe3, 0x16, 0x34, 0xc4, 0xfe, 0x2a, 0x9b, 0xac, 0xc1, 0xf8, 0x4b, 0x68, 0x66,
-	0xd0, 0xe6, 0x7a, 0x2b, 0xaf, 0xab, 0xf5, 0xce, 0xe2, 0xe5, 0x12, 0x3c, 0x06, 0xcb, 0x5e, 0xf2,
-	0x6b, 0x16, 0xf2, 0x60, 0xf2, 0x56, 0x15, 0x3e, 0x98, 0xea, 0x13, 0xe8, 0x14, 0x7a, 0x55, 0xa9,
-	0xc9, 0x37, 0xf5, 0x86, 0x85, 0xca, 0x67, 0x7a, 0xc1, 0x5f, 0xc0, 0x51, 0xef, 0xda, 0x0f, 0xaf,
-	0x32, 0xf5, 0x0b, 0xe5, 0xed, 0xbf, 0xb4, 0xfb, 0x77, 0xa8, 0xaa, 0x65, 0x7c, 0xfb, 0x27, 0xf8,
-	0x18, 0x60, 0x22, 0xc7, 0x3b, 0xf5, 0x7c, 0x2e, 0x5f, 0x5e, 0x99, 0xd6, 0x14, 0x62, 0xcb, 0x05,
-	0x0e, 0x92, 0x64, 0xc9, 0x12, 0x2f, 0x9d, 0x5d, 0x45, 0x2e, 0x69, 0x3d, 0xc5, 0x7a, 0x02, 0xc2,
-	0x7f, 0x6a, 0x50, 0x11, 0x1e, 0xd1, 0xbb, 0x50, 0x9d, 0x07, 0xa1, 0xe7, 0x5f, 0xa5, 0xe1, 0x75,
-	0xba, 0x3b, 0x0f, 0x42, 0xfb, 0x4a, 0x56, 0x9c, 0x5a, 0xab, 0x15, 0x4f, 0xa7, 0x9d, 0xaf, 0x73,
-	0x79, 0x7b, 0x9d, 0x51, 0x07, 0x6a, 0x21, 0xbb, 0xe1, 0xde, 0xd4, 0xe7, 0x4c, 0x06, 0x2d, 0x53,
-	0x43, 0x00, 0x7d, 0x9f, 0x33, 0x74, 0x04, 0x3a, 0x8b, 0xe3, 0x28, 0x6e, 0xeb, 0x92, 0x6f, 0x77,
-	0x89, 0x23, 0x6e, 0x34, 0x05, 0xf1, 0x1f, 0x1a, 0x54, 0x84, 0x2b, 0xd1, 0x8b, 0xb5, 0xcd, 0x97,
-	0x67, 0x81, 0x49, 0x97, 0x25, 0xe9, 0x52, 0x9e, 0x05, 0x96, 0x04, 0xbf, 0x31, 0x55, 0xbc, 0x3c,
-	0xa3, 0xf7, 0x00, 0xd8, 0xcd, 0x22, 0x88, 0x7d, 0xc1, 0xab, 0x2a, 0x81, 0x35, 0xe4, 0x5f, 0x52,
-	0xf8, 0x08, 0xaa, 0xf6, 0x6a, 0xa7, 0xef, 0x9b, 0xdb, 0xa7, 0x03, 0xd0, 0xa5, 0x19, 0xda, 0x03,
-	0x63, 0xe8, 0x7a, 0x0e, 0xa5, 0x2e, 0x35, 0x77, 0x50, 0x1d, 0xaa, 0x97, 0xc3, 0xef, 0x86, 0xee,
-	0x0f, 0x43, 0x53, 0x13, 0x22, 0xf7, 0x6c, 0xe4, 0x0e, 0x9c, 0xb1, 0x63, 0x96, 0xd0, 0x3e, 0xd4,
-	0xc6, 0xae, 0xeb, 0x8d, 0x5e, 0xd9, 0x83, 0x81, 0x59, 0x16, 0x9a, 0x43, 0xd7, 0x7b, 0x71, 0x3e,
-	0x70, 0xcc, 0x4a, 0xf7, 0xaf, 0x0a, 0x18, 0x67, 0xfe, 0xe4, 0x4d, 0x6c, 0x2f, 0x02, 0xf4, 0x39,
-	0xd4, 0xd7, 0x3e, 0x42, 0xa8, 0x51, 0xf0, 0x49, 0xb2, 0x5a, 0xa4, 0xf0, 0xcb, 0xd0, 0x05, 0x58,
-	0x29, 0x23, 0x44, 0xb6, 0x38, 0xd3, 0x32, 0xc9, 0x26, 0x3d, 0x7e, 0x05, 0xfb, 0x77, 0x78, 0x19,
-	0xb5, 0x48, 0xd1, 0x17, 0xc2, 0x7a, 0x87, 0x14, 0xd3, 0xf7, 0x29, 0x18, 0x19, 0xdd, 0x21, 0x93,
-	0x6c, 0xb0, 0xa8, 0x75, 0x48, 0xb6, 0xb8, 0xf0, 0xa9, 0x4c, 0x52, 0xb1, 0x57, 0x9a, 0xe4, 0x5d,
-	0xee, 0xb3, 0x1a, 0xa4, 0x80, 0xde, 0x9e, 0x65, 0x79, 0x66, 0xe3, 0xc9, 0xf2, 0xbc, 0xfb, 0xf4,
-	0x2d, 0x93, 0x6c, 0xbe, 0xda, 0xe7, 0xb0, 0xb7, 0xce, 0x3f, 0xa8, 0x49, 0x0a, 0xe8, 0xc8, 0x6a,
-	0x91, 0x42, 0x36, 0xba, 0x80, 0x46, 0x01, 0x23, 0xa0, 0x0e, 0xb9, 0x9f, 0x7d, 0xac, 0x23, 0xf2,
-	0x10, 0x89, 0x7c, 0x03, 0xad, 0x42, 0xba, 0x40, 0x8f, 0xc9, 0x43, 0x34, 0xb2, 0x5d, 0xd8, 0x59,
-	0xf5, 0x47, 0x5d, 0xfe, 0x31, 0x7a, 0xbd, 0x2b, 0x7f, 0x9e, 0xfc, 0x13, 0x00, 0x00, 0xff, 0xff,
-	0x00, 0xb7, 0x93, 0xe0, 0x2c, 0x09, 0x00, 0x00,
+func (m *FileChunk) Reset()         { *m = FileChunk{} }
+func (m *FileChunk) String() string { return proto.CompactTextString(m) }
+func (*FileChunk) ProtoMessage()    {}
+func (*FileChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{22}
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *FileChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileChunk.Unmarshal(m, b)
+}
+func (m *FileChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileChunk.Marshal(b, m, deterministic)
+}
+func (m *FileChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileChunk.Merge(m, src)
+}
+func (m *FileChunk) XXX_Size() int {
+	return xxx_messageInfo_FileChunk.Size(m)
+}
+func (m *FileChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileChunk proto.InternalMessageInfo
+
+func (m *FileChunk) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *FileChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *FileChunk) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *FileChunk) GetSha256() string {
+	if m != nil {
+		return m.Sha256
+	}
+	return ""
+}
+
+// UploadFileRequest is sent in a stream: the first message describes the file,
+// the next ones contain the data of the file
+type UploadFileRequest struct {
+	ProjectName string `protobuf:"bytes,1,opt,name=project_name,json=projectName,proto3" json:"project_name,omitempty"`
+	// name of the file in the folder of the project
+	Filename string `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	// expected size of the file
+	Size int64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	// expected hex-encoded SHA-256 checksum of the file (optional)
+	Sha256               string   `protobuf:"bytes,4,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	Data                 []byte   `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UploadFileRequest) Reset()         { *m = UploadFileRequest{} }
+func (m *UploadFileRequest) String() string { return proto.CompactTextString(m) }
+func (*UploadFileRequest) ProtoMessage()    {}
+func (*UploadFileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{23}
+}
+
+func (m *UploadFileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UploadFileRequest.Unmarshal(m, b)
+}
+func (m *UploadFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UploadFileRequest.Marshal(b, m, deterministic)
+}
+func (m *UploadFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UploadFileRequest.Merge(m, src)
+}
+func (m *UploadFileRequest) XXX_Size() int {
+	return xxx_messageInfo_UploadFileRequest.Size(m)
+}
+func (m *UploadFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UploadFileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UploadFileRequest proto.InternalMessageInfo
+
+func (m *UploadFileRequest) GetProjectName() string {
+	if m != nil {
+		return m.ProjectName
+	}
+	return ""
+}
+
+func (m *UploadFileRequest) GetFilename() string {
+	if m != nil {
+		return m.Filename
+	}
+	return ""
+}
+
+func (m *UploadFileRequest) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *UploadFileRequest) GetSha256() string {
+	if m != nil {
+		return m.Sha256
+	}
+	return ""
+}
+
+func (m *UploadFileRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type UploadFileResponse struct {
+	File                 *File    `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Sha256               string   `protobuf:"bytes,2,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UploadFileResponse) Reset()         { *m = UploadFileResponse{} }
+func (m *UploadFileResponse) String() string { return proto.CompactTextString(m) }
+func (*UploadFileResponse) ProtoMessage()    {}
+func (*UploadFileResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{24}
+}
+
+func (m *UploadFileResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UploadFileResponse.Unmarshal(m, b)
+}
+func (m *UploadFileResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UploadFileResponse.Marshal(b, m, deterministic)
+}
+func (m *UploadFileResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UploadFileResponse.Merge(m, src)
+}
+func (m *UploadFileResponse) XXX_Size() int {
+	return xxx_messageInfo_UploadFileResponse.Size(m)
+}
+func (m *UploadFileResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UploadFileResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UploadFileResponse proto.InternalMessageInfo
+
+func (m *UploadFileResponse) GetFile() *File {
+	if m != nil {
+		return m.File
+	}
+	return nil
+}
+
+func (m *UploadFileResponse) GetSha256() string {
+	if m != nil {
+		return m.Sha256
+	}
+	return ""
+}
+
+type PinFileRequest struct {
+	Filepath string `protobuf:"bytes,1,opt,name=filepath,proto3" json:"filepath,omitempty"`
+	Reason   string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// date after which the file is not pinned anymore (never if 0)
+	ExpiresAt            int64    `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PinFileRequest) Reset()         { *m = PinFileRequest{} }
+func (m *PinFileRequest) String() string { return proto.CompactTextString(m) }
+func (*PinFileRequest) ProtoMessage()    {}
+func (*PinFileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{25}
+}
+
+func (m *PinFileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PinFileRequest.Unmarshal(m, b)
+}
+func (m *PinFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PinFileRequest.Marshal(b, m, deterministic)
+}
+func (m *PinFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PinFileRequest.Merge(m, src)
+}
+func (m *PinFileRequest) XXX_Size() int {
+	return xxx_messageInfo_PinFileRequest.Size(m)
+}
+func (m *PinFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PinFileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PinFileRequest proto.InternalMessageInfo
+
+func (m *PinFileRequest) GetFilepath() string {
+	if m != nil {
+		return m.Filepath
+	}
+	return ""
+}
+
+func (m *PinFileRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *PinFileRequest) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+type PinFileResponse struct {
+	Pin                  *Pin     `protobuf:"bytes,1,opt,name=pin,proto3" json:"pin,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PinFileResponse) Reset()         { *m = PinFileResponse{} }
+func (m *PinFileResponse) String() string { return proto.CompactTextString(m) }
+func (*PinFileResponse) ProtoMessage()    {}
+func (*PinFileResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{26}
+}
+
+func (m *PinFileResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PinFileResponse.Unmarshal(m, b)
+}
+func (m *PinFileResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PinFileResponse.Marshal(b, m, deterministic)
+}
+func (m *PinFileResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PinFileResponse.Merge(m, src)
+}
+func (m *PinFileResponse) XXX_Size() int {
+	return xxx_messageInfo_PinFileResponse.Size(m)
+}
+func (m *PinFileResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PinFileResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PinFileResponse proto.InternalMessageInfo
+
+func (m *PinFileResponse) GetPin() *Pin {
+	if m != nil {
+		return m.Pin
+	}
+	return nil
+}
+
+type UnpinFileRequest struct {
+	Filepath             string   `protobuf:"bytes,1,opt,name=filepath,proto3" json:"filepath,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnpinFileRequest) Reset()         { *m = UnpinFileRequest{} }
+func (m *UnpinFileRequest) String() string { return proto.CompactTextString(m) }
+func (*UnpinFileRequest) ProtoMessage()    {}
+func (*UnpinFileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{27}
+}
+
+func (m *UnpinFileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnpinFileRequest.Unmarshal(m, b)
+}
+func (m *UnpinFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnpinFileRequest.Marshal(b, m, deterministic)
+}
+func (m *UnpinFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnpinFileRequest.Merge(m, src)
+}
+func (m *UnpinFileRequest) XXX_Size() int {
+	return xxx_messageInfo_UnpinFileRequest.Size(m)
+}
+func (m *UnpinFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnpinFileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnpinFileRequest proto.InternalMessageInfo
+
+func (m *UnpinFileRequest) GetFilepath() string {
+	if m != nil {
+		return m.Filepath
+	}
+	return ""
+}
+
+type UnpinFileResponse struct {
+	Pin                  *Pin     `protobuf:"bytes,1,opt,name=pin,proto3" json:"pin,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnpinFileResponse) Reset()         { *m = UnpinFileResponse{} }
+func (m *UnpinFileResponse) String() string { return proto.CompactTextString(m) }
+func (*UnpinFileResponse) ProtoMessage()    {}
+func (*UnpinFileResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{28}
+}
+
+func (m *UnpinFileResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnpinFileResponse.Unmarshal(m, b)
+}
+func (m *UnpinFileResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnpinFileResponse.Marshal(b, m, deterministic)
+}
+func (m *UnpinFileResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnpinFileResponse.Merge(m, src)
+}
+func (m *UnpinFileResponse) XXX_Size() int {
+	return xxx_messageInfo_UnpinFileResponse.Size(m)
+}
+func (m *UnpinFileResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnpinFileResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnpinFileResponse proto.InternalMessageInfo
+
+func (m *UnpinFileResponse) GetPin() *Pin {
+	if m != nil {
+		return m.Pin
+	}
+	return nil
+}
+
+type ListTrashRequest struct {
+	// restricts the files to a project (all projects if empty)
+	ProjectName          string   `protobuf:"bytes,1,opt,name=project_name,json=projectName,proto3" json:"project_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListTrashRequest) Reset()         { *m = ListTrashRequest{} }
+func (m *ListTrashRequest) String() string { return proto.CompactTextString(m) }
+func (*ListTrashRequest) ProtoMessage()    {}
+func (*ListTrashRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{29}
+}
+
+func (m *ListTrashRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTrashRequest.Unmarshal(m, b)
+}
+func (m *ListTrashRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTrashRequest.Marshal(b, m, deterministic)
+}
+func (m *ListTrashRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTrashRequest.Merge(m, src)
+}
+func (m *ListTrashRequest) XXX_Size() int {
+	return xxx_messageInfo_ListTrashRequest.Size(m)
+}
+func (m *ListTrashRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTrashRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTrashRequest proto.InternalMessageInfo
+
+func (m *ListTrashRequest) GetProjectName() string {
+	if m != nil {
+		return m.ProjectName
+	}
+	return ""
+}
+
+type ListTrashResponse struct {
+	Files                []*TrashedFile `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ListTrashResponse) Reset()         { *m = ListTrashResponse{} }
+func (m *ListTrashResponse) String() string { return proto.CompactTextString(m) }
+func (*ListTrashResponse) ProtoMessage()    {}
+func (*ListTrashResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{30}
+}
+
+func (m *ListTrashResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTrashResponse.Unmarshal(m, b)
+}
+func (m *ListTrashResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTrashResponse.Marshal(b, m, deterministic)
+}
+func (m *ListTrashResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTrashResponse.Merge(m, src)
+}
+func (m *ListTrashResponse) XXX_Size() int {
+	return xxx_messageInfo_ListTrashResponse.Size(m)
+}
+func (m *ListTrashResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTrashResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTrashResponse proto.InternalMessageInfo
+
+func (m *ListTrashResponse) GetFiles() []*TrashedFile {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+type RestoreFileRequest struct {
+	Filepath             string   `protobuf:"bytes,1,opt,name=filepath,proto3" json:"filepath,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreFileRequest) Reset()         { *m = RestoreFileRequest{} }
+func (m *RestoreFileRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreFileRequest) ProtoMessage()    {}
+func (*RestoreFileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{31}
+}
+
+func (m *RestoreFileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreFileRequest.Unmarshal(m, b)
+}
+func (m *RestoreFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreFileRequest.Marshal(b, m, deterministic)
+}
+func (m *RestoreFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreFileRequest.Merge(m, src)
+}
+func (m *RestoreFileRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreFileRequest.Size(m)
+}
+func (m *RestoreFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreFileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreFileRequest proto.InternalMessageInfo
+
+func (m *RestoreFileRequest) GetFilepath() string {
+	if m != nil {
+		return m.Filepath
+	}
+	return ""
+}
+
+type RestoreFileResponse struct {
+	File                 *File    `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreFileResponse) Reset()         { *m = RestoreFileResponse{} }
+func (m *RestoreFileResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreFileResponse) ProtoMessage()    {}
+func (*RestoreFileResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{32}
+}
+
+func (m *RestoreFileResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreFileResponse.Unmarshal(m, b)
+}
+func (m *RestoreFileResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreFileResponse.Marshal(b, m, deterministic)
+}
+func (m *RestoreFileResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreFileResponse.Merge(m, src)
+}
+func (m *RestoreFileResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreFileResponse.Size(m)
+}
+func (m *RestoreFileResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreFileResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreFileResponse proto.InternalMessageInfo
+
+func (m *RestoreFileResponse) GetFile() *File {
+	if m != nil {
+		return m.File
+	}
+	return nil
+}
+
+type PurgeTrashRequest struct {
+	// permanently deletes this file, or the files whose grace period is over if empty
+	Filepath             string   `protobuf:"bytes,1,opt,name=filepath,proto3" json:"filepath,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PurgeTrashRequest) Reset()         { *m = PurgeTrashRequest{} }
+func (m *PurgeTrashRequest) String() string { return proto.CompactTextString(m) }
+func (*PurgeTrashRequest) ProtoMessage()    {}
+func (*PurgeTrashRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{33}
+}
+
+func (m *PurgeTrashRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PurgeTrashRequest.Unmarshal(m, b)
+}
+func (m *PurgeTrashRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PurgeTrashRequest.Marshal(b, m, deterministic)
+}
+func (m *PurgeTrashRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PurgeTrashRequest.Merge(m, src)
+}
+func (m *PurgeTrashRequest) XXX_Size() int {
+	return xxx_messageInfo_PurgeTrashRequest.Size(m)
+}
+func (m *PurgeTrashRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PurgeTrashRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PurgeTrashRequest proto.InternalMessageInfo
+
+func (m *PurgeTrashRequest) GetFilepath() string {
+	if m != nil {
+		return m.Filepath
+	}
+	return ""
+}
+
+type PurgeTrashResponse struct {
+	Filepaths            []string `protobuf:"bytes,1,rep,name=filepaths,proto3" json:"filepaths,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PurgeTrashResponse) Reset()         { *m = PurgeTrashResponse{} }
+func (m *PurgeTrashResponse) String() string { return proto.CompactTextString(m) }
+func (*PurgeTrashResponse) ProtoMessage()    {}
+func (*PurgeTrashResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{34}
+}
+
+func (m *PurgeTrashResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PurgeTrashResponse.Unmarshal(m, b)
+}
+func (m *PurgeTrashResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PurgeTrashResponse.Marshal(b, m, deterministic)
+}
+func (m *PurgeTrashResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PurgeTrashResponse.Merge(m, src)
+}
+func (m *PurgeTrashResponse) XXX_Size() int {
+	return xxx_messageInfo_PurgeTrashResponse.Size(m)
+}
+func (m *PurgeTrashResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PurgeTrashResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PurgeTrashResponse proto.InternalMessageInfo
+
+func (m *PurgeTrashResponse) GetFilepaths() []string {
+	if m != nil {
+		return m.Filepaths
+	}
+	return nil
+}
+
+type GetMaintenanceRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetMaintenanceRequest) Reset()         { *m = GetMaintenanceRequest{} }
+func (m *GetMaintenanceRequest) String() string { return proto.CompactTextString(m) }
+func (*GetMaintenanceRequest) ProtoMessage()    {}
+func (*GetMaintenanceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{35}
+}
+
+func (m *GetMaintenanceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMaintenanceRequest.Unmarshal(m, b)
+}
+func (m *GetMaintenanceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMaintenanceRequest.Marshal(b, m, deterministic)
+}
+func (m *GetMaintenanceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMaintenanceRequest.Merge(m, src)
+}
+func (m *GetMaintenanceRequest) XXX_Size() int {
+	return xxx_messageInfo_GetMaintenanceRequest.Size(m)
+}
+func (m *GetMaintenanceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMaintenanceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMaintenanceRequest proto.InternalMessageInfo
+
+type SetMaintenanceRequest struct {
+	// enables the maintenance mode, or disables it if false
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// required to enable the maintenance mode
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// date at which the maintenance mode ends (never if 0)
+	Until                int64    `protobuf:"varint,3,opt,name=until,proto3" json:"until,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetMaintenanceRequest) Reset()         { *m = SetMaintenanceRequest{} }
+func (m *SetMaintenanceRequest) String() string { return proto.CompactTextString(m) }
+func (*SetMaintenanceRequest) ProtoMessage()    {}
+func (*SetMaintenanceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{36}
+}
+
+func (m *SetMaintenanceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetMaintenanceRequest.Unmarshal(m, b)
+}
+func (m *SetMaintenanceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetMaintenanceRequest.Marshal(b, m, deterministic)
+}
+func (m *SetMaintenanceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetMaintenanceRequest.Merge(m, src)
+}
+func (m *SetMaintenanceRequest) XXX_Size() int {
+	return xxx_messageInfo_SetMaintenanceRequest.Size(m)
+}
+func (m *SetMaintenanceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetMaintenanceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetMaintenanceRequest proto.InternalMessageInfo
+
+func (m *SetMaintenanceRequest) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+func (m *SetMaintenanceRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *SetMaintenanceRequest) GetUntil() int64 {
+	if m != nil {
+		return m.Until
+	}
+	return 0
+}
+
+type MaintenanceResponse struct {
+	// not set if the maintenance mode is disabled
+	Maintenance          *Maintenance `protobuf:"bytes,1,opt,name=maintenance,proto3" json:"maintenance,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *MaintenanceResponse) Reset()         { *m = MaintenanceResponse{} }
+func (m *MaintenanceResponse) String() string { return proto.CompactTextString(m) }
+func (*MaintenanceResponse) ProtoMessage()    {}
+func (*MaintenanceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{37}
+}
+
+func (m *MaintenanceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MaintenanceResponse.Unmarshal(m, b)
+}
+func (m *MaintenanceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MaintenanceResponse.Marshal(b, m, deterministic)
+}
+func (m *MaintenanceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MaintenanceResponse.Merge(m, src)
+}
+func (m *MaintenanceResponse) XXX_Size() int {
+	return xxx_messageInfo_MaintenanceResponse.Size(m)
+}
+func (m *MaintenanceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MaintenanceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MaintenanceResponse proto.InternalMessageInfo
+
+func (m *MaintenanceResponse) GetMaintenance() *Maintenance {
+	if m != nil {
+		return m.Maintenance
+	}
+	return nil
+}
+
+type CreateAccountRequest struct {
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Role     string `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	// projects restricts the account to these projects (all the projects if empty)
+	Projects             []string `protobuf:"bytes,3,rep,name=projects,proto3" json:"projects,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateAccountRequest) Reset()         { *m = CreateAccountRequest{} }
+func (m *CreateAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateAccountRequest) ProtoMessage()    {}
+func (*CreateAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{38}
+}
+
+func (m *CreateAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateAccountRequest.Unmarshal(m, b)
+}
+func (m *CreateAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateAccountRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateAccountRequest.Merge(m, src)
+}
+func (m *CreateAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateAccountRequest.Size(m)
+}
+func (m *CreateAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateAccountRequest proto.InternalMessageInfo
+
+func (m *CreateAccountRequest) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *CreateAccountRequest) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *CreateAccountRequest) GetProjects() []string {
+	if m != nil {
+		return m.Projects
+	}
+	return nil
+}
+
+type AccountResponse struct {
+	Account              *Account `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Password             string   `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccountResponse) Reset()         { *m = AccountResponse{} }
+func (m *AccountResponse) String() string { return proto.CompactTextString(m) }
+func (*AccountResponse) ProtoMessage()    {}
+func (*AccountResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{39}
+}
+
+func (m *AccountResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountResponse.Unmarshal(m, b)
+}
+func (m *AccountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountResponse.Marshal(b, m, deterministic)
+}
+func (m *AccountResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountResponse.Merge(m, src)
+}
+func (m *AccountResponse) XXX_Size() int {
+	return xxx_messageInfo_AccountResponse.Size(m)
+}
+func (m *AccountResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountResponse proto.InternalMessageInfo
+
+func (m *AccountResponse) GetAccount() *Account {
+	if m != nil {
+		return m.Account
+	}
+	return nil
+}
+
+func (m *AccountResponse) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+type ListAccountsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAccountsRequest) Reset()         { *m = ListAccountsRequest{} }
+func (m *ListAccountsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAccountsRequest) ProtoMessage()    {}
+func (*ListAccountsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{40}
+}
+
+func (m *ListAccountsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAccountsRequest.Unmarshal(m, b)
+}
+func (m *ListAccountsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAccountsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListAccountsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAccountsRequest.Merge(m, src)
+}
+func (m *ListAccountsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAccountsRequest.Size(m)
+}
+func (m *ListAccountsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAccountsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAccountsRequest proto.InternalMessageInfo
+
+type AccountsListResponse struct {
+	Accounts             []*Account `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *AccountsListResponse) Reset()         { *m = AccountsListResponse{} }
+func (m *AccountsListResponse) String() string { return proto.CompactTextString(m) }
+func (*AccountsListResponse) ProtoMessage()    {}
+func (*AccountsListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{41}
+}
+
+func (m *AccountsListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountsListResponse.Unmarshal(m, b)
+}
+func (m *AccountsListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountsListResponse.Marshal(b, m, deterministic)
+}
+func (m *AccountsListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountsListResponse.Merge(m, src)
+}
+func (m *AccountsListResponse) XXX_Size() int {
+	return xxx_messageInfo_AccountsListResponse.Size(m)
+}
+func (m *AccountsListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountsListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountsListResponse proto.InternalMessageInfo
+
+func (m *AccountsListResponse) GetAccounts() []*Account {
+	if m != nil {
+		return m.Accounts
+	}
+	return nil
+}
+
+type AuthenticateAccountRequest struct {
+	Username             string   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password             string   `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuthenticateAccountRequest) Reset()         { *m = AuthenticateAccountRequest{} }
+func (m *AuthenticateAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*AuthenticateAccountRequest) ProtoMessage()    {}
+func (*AuthenticateAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{42}
+}
+
+func (m *AuthenticateAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuthenticateAccountRequest.Unmarshal(m, b)
+}
+func (m *AuthenticateAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuthenticateAccountRequest.Marshal(b, m, deterministic)
+}
+func (m *AuthenticateAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuthenticateAccountRequest.Merge(m, src)
+}
+func (m *AuthenticateAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_AuthenticateAccountRequest.Size(m)
+}
+func (m *AuthenticateAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuthenticateAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuthenticateAccountRequest proto.InternalMessageInfo
+
+func (m *AuthenticateAccountRequest) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *AuthenticateAccountRequest) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+type AuthenticateAccountResponse struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuthenticateAccountResponse) Reset()         { *m = AuthenticateAccountResponse{} }
+func (m *AuthenticateAccountResponse) String() string { return proto.CompactTextString(m) }
+func (*AuthenticateAccountResponse) ProtoMessage()    {}
+func (*AuthenticateAccountResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{43}
+}
+
+func (m *AuthenticateAccountResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuthenticateAccountResponse.Unmarshal(m, b)
+}
+func (m *AuthenticateAccountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuthenticateAccountResponse.Marshal(b, m, deterministic)
+}
+func (m *AuthenticateAccountResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuthenticateAccountResponse.Merge(m, src)
+}
+func (m *AuthenticateAccountResponse) XXX_Size() int {
+	return xxx_messageInfo_AuthenticateAccountResponse.Size(m)
+}
+func (m *AuthenticateAccountResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuthenticateAccountResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuthenticateAccountResponse proto.InternalMessageInfo
+
+func (m *AuthenticateAccountResponse) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+type ChangeAccountPasswordRequest struct {
+	Username             string   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChangeAccountPasswordRequest) Reset()         { *m = ChangeAccountPasswordRequest{} }
+func (m *ChangeAccountPasswordRequest) String() string { return proto.CompactTextString(m) }
+func (*ChangeAccountPasswordRequest) ProtoMessage()    {}
+func (*ChangeAccountPasswordRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{44}
+}
+
+func (m *ChangeAccountPasswordRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChangeAccountPasswordRequest.Unmarshal(m, b)
+}
+func (m *ChangeAccountPasswordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChangeAccountPasswordRequest.Marshal(b, m, deterministic)
+}
+func (m *ChangeAccountPasswordRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChangeAccountPasswordRequest.Merge(m, src)
+}
+func (m *ChangeAccountPasswordRequest) XXX_Size() int {
+	return xxx_messageInfo_ChangeAccountPasswordRequest.Size(m)
+}
+func (m *ChangeAccountPasswordRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChangeAccountPasswordRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChangeAccountPasswordRequest proto.InternalMessageInfo
+
+func (m *ChangeAccountPasswordRequest) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+type GetAuthConfigRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAuthConfigRequest) Reset()         { *m = GetAuthConfigRequest{} }
+func (m *GetAuthConfigRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAuthConfigRequest) ProtoMessage()    {}
+func (*GetAuthConfigRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{45}
+}
+
+func (m *GetAuthConfigRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAuthConfigRequest.Unmarshal(m, b)
+}
+func (m *GetAuthConfigRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAuthConfigRequest.Marshal(b, m, deterministic)
+}
+func (m *GetAuthConfigRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAuthConfigRequest.Merge(m, src)
+}
+func (m *GetAuthConfigRequest) XXX_Size() int {
+	return xxx_messageInfo_GetAuthConfigRequest.Size(m)
+}
+func (m *GetAuthConfigRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAuthConfigRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAuthConfigRequest proto.InternalMessageInfo
+
+type AuthConfigResponse struct {
+	OidcIssuer           string   `protobuf:"bytes,1,opt,name=oidc_issuer,json=oidcIssuer,proto3" json:"oidc_issuer,omitempty"`
+	OidcClientId         string   `protobuf:"bytes,2,opt,name=oidc_client_id,json=oidcClientId,proto3" json:"oidc_client_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuthConfigResponse) Reset()         { *m = AuthConfigResponse{} }
+func (m *AuthConfigResponse) String() string { return proto.CompactTextString(m) }
+func (*AuthConfigResponse) ProtoMessage()    {}
+func (*AuthConfigResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{46}
+}
+
+func (m *AuthConfigResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuthConfigResponse.Unmarshal(m, b)
+}
+func (m *AuthConfigResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuthConfigResponse.Marshal(b, m, deterministic)
+}
+func (m *AuthConfigResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuthConfigResponse.Merge(m, src)
+}
+func (m *AuthConfigResponse) XXX_Size() int {
+	return xxx_messageInfo_AuthConfigResponse.Size(m)
+}
+func (m *AuthConfigResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuthConfigResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuthConfigResponse proto.InternalMessageInfo
+
+func (m *AuthConfigResponse) GetOidcIssuer() string {
+	if m != nil {
+		return m.OidcIssuer
+	}
+	return ""
+}
+
+func (m *AuthConfigResponse) GetOidcClientId() string {
+	if m != nil {
+		return m.OidcClientId
+	}
+	return ""
+}
+
+type ListAuditEventsRequest struct {
+	Actor                string   `protobuf:"bytes,1,opt,name=actor,proto3" json:"actor,omitempty"`
+	Since                int64    `protobuf:"varint,2,opt,name=since,proto3" json:"since,omitempty"`
+	Limit                int32    `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAuditEventsRequest) Reset()         { *m = ListAuditEventsRequest{} }
+func (m *ListAuditEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAuditEventsRequest) ProtoMessage()    {}
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{47}
+}
+
+func (m *ListAuditEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAuditEventsRequest.Unmarshal(m, b)
+}
+func (m *ListAuditEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAuditEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListAuditEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAuditEventsRequest.Merge(m, src)
+}
+func (m *ListAuditEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAuditEventsRequest.Size(m)
+}
+func (m *ListAuditEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAuditEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAuditEventsRequest proto.InternalMessageInfo
+
+func (m *ListAuditEventsRequest) GetActor() string {
+	if m != nil {
+		return m.Actor
+	}
+	return ""
+}
+
+func (m *ListAuditEventsRequest) GetSince() int64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+func (m *ListAuditEventsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type AuditEventsListResponse struct {
+	Events               []*AuditEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *AuditEventsListResponse) Reset()         { *m = AuditEventsListResponse{} }
+func (m *AuditEventsListResponse) String() string { return proto.CompactTextString(m) }
+func (*AuditEventsListResponse) ProtoMessage()    {}
+func (*AuditEventsListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{48}
+}
+
+func (m *AuditEventsListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditEventsListResponse.Unmarshal(m, b)
+}
+func (m *AuditEventsListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditEventsListResponse.Marshal(b, m, deterministic)
+}
+func (m *AuditEventsListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditEventsListResponse.Merge(m, src)
+}
+func (m *AuditEventsListResponse) XXX_Size() int {
+	return xxx_messageInfo_AuditEventsListResponse.Size(m)
+}
+func (m *AuditEventsListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditEventsListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditEventsListResponse proto.InternalMessageInfo
+
+func (m *AuditEventsListResponse) GetEvents() []*AuditEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+type ListNotificationsRequest struct {
+	Limit                int32    `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListNotificationsRequest) Reset()         { *m = ListNotificationsRequest{} }
+func (m *ListNotificationsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListNotificationsRequest) ProtoMessage()    {}
+func (*ListNotificationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{49}
+}
+
+func (m *ListNotificationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListNotificationsRequest.Unmarshal(m, b)
+}
+func (m *ListNotificationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListNotificationsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListNotificationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListNotificationsRequest.Merge(m, src)
+}
+func (m *ListNotificationsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListNotificationsRequest.Size(m)
+}
+func (m *ListNotificationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListNotificationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListNotificationsRequest proto.InternalMessageInfo
+
+func (m *ListNotificationsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type NotificationsListResponse struct {
+	Notifications        []*Notification `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *NotificationsListResponse) Reset()         { *m = NotificationsListResponse{} }
+func (m *NotificationsListResponse) String() string { return proto.CompactTextString(m) }
+func (*NotificationsListResponse) ProtoMessage()    {}
+func (*NotificationsListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{50}
+}
+
+func (m *NotificationsListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NotificationsListResponse.Unmarshal(m, b)
+}
+func (m *NotificationsListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NotificationsListResponse.Marshal(b, m, deterministic)
+}
+func (m *NotificationsListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NotificationsListResponse.Merge(m, src)
+}
+func (m *NotificationsListResponse) XXX_Size() int {
+	return xxx_messageInfo_NotificationsListResponse.Size(m)
+}
+func (m *NotificationsListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NotificationsListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NotificationsListResponse proto.InternalMessageInfo
+
+func (m *NotificationsListResponse) GetNotifications() []*Notification {
+	if m != nil {
+		return m.Notifications
+	}
+	return nil
+}
+
+type WatchEventsRequest struct {
+	// only the events of this project (all projects if empty)
+	ProjectName string `protobuf:"bytes,1,opt,name=project_name,json=projectName,proto3" json:"project_name,omitempty"`
+	// only the events of these types (all types if empty)
+	Types []string `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
+	// count of recent events to send first
+	Replay int32 `protobuf:"varint,3,opt,name=replay,proto3" json:"replay,omitempty"`
+	// resume the stream after this event, replaying the missed events still in the backlog
+	SinceId uint64 `protobuf:"varint,4,opt,name=since_id,json=sinceId,proto3" json:"since_id,omitempty"`
+	// keep the stream open to receive the next events, otherwise it ends after the replay
+	Follow               bool     `protobuf:"varint,5,opt,name=follow,proto3" json:"follow,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchEventsRequest) Reset()         { *m = WatchEventsRequest{} }
+func (m *WatchEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchEventsRequest) ProtoMessage()    {}
+func (*WatchEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{51}
+}
+
+func (m *WatchEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchEventsRequest.Unmarshal(m, b)
+}
+func (m *WatchEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchEventsRequest.Merge(m, src)
+}
+func (m *WatchEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchEventsRequest.Size(m)
+}
+func (m *WatchEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchEventsRequest proto.InternalMessageInfo
+
+func (m *WatchEventsRequest) GetProjectName() string {
+	if m != nil {
+		return m.ProjectName
+	}
+	return ""
+}
+
+func (m *WatchEventsRequest) GetTypes() []string {
+	if m != nil {
+		return m.Types
+	}
+	return nil
+}
+
+func (m *WatchEventsRequest) GetReplay() int32 {
+	if m != nil {
+		return m.Replay
+	}
+	return 0
+}
+
+func (m *WatchEventsRequest) GetSinceId() uint64 {
+	if m != nil {
+		return m.SinceId
+	}
+	return 0
+}
+
+func (m *WatchEventsRequest) GetFollow() bool {
+	if m != nil {
+		return m.Follow
+	}
+	return false
+}
+
+type Project struct {
+	Name        string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Rules       []*Rule `protobuf:"bytes,2,rep,name=rules,proto3" json:"rules,omitempty"`
+	CreatedAt   int64   `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	IssuesCount int32   `protobuf:"varint,4,opt,name=issues_count,json=issuesCount,proto3" json:"issues_count,omitempty"`
+	// removal of files blocked by the deletion guard, waiting for an approval (readonly)
+	DeletionPlan *DeletionPlan `protobuf:"bytes,5,opt,name=deletion_plan,json=deletionPlan,proto3" json:"deletion_plan,omitempty"`
+	// files kept regardless of the rules (readonly, see PinFile)
+	Pins []*Pin `protobuf:"bytes,6,rep,name=pins,proto3" json:"pins,omitempty"`
+	// processing status (readonly, see SetProjectStatus)
+	Status *ProjectStatus `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	// declared delay between two uploads, in seconds (0 if unknown)
+	UploadInterval int64        `protobuf:"varint,8,opt,name=upload_interval,json=uploadInterval,proto3" json:"upload_interval,omitempty"`
+	Caps           *ProjectCaps `protobuf:"bytes,9,opt,name=caps,proto3" json:"caps,omitempty"`
+	// files kept by the rules but removed because of the caps, during the last process (readonly)
+	CapConflicts         []string    `protobuf:"bytes,10,rep,name=cap_conflicts,json=capConflicts,proto3" json:"cap_conflicts,omitempty"`
+	DateSource           *DateSource `protobuf:"bytes,11,opt,name=date_source,json=dateSource,proto3" json:"date_source,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *Project) Reset()         { *m = Project{} }
+func (m *Project) String() string { return proto.CompactTextString(m) }
+func (*Project) ProtoMessage()    {}
+func (*Project) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{52}
+}
+
+func (m *Project) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Project.Unmarshal(m, b)
+}
+func (m *Project) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Project.Marshal(b, m, deterministic)
+}
+func (m *Project) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Project.Merge(m, src)
+}
+func (m *Project) XXX_Size() int {
+	return xxx_messageInfo_Project.Size(m)
+}
+func (m *Project) XXX_DiscardUnknown() {
+	xxx_messageInfo_Project.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Project proto.InternalMessageInfo
+
+func (m *Project) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Project) GetRules() []*Rule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+func (m *Project) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *Project) GetIssuesCount() int32 {
+	if m != nil {
+		return m.IssuesCount
+	}
+	return 0
+}
+
+func (m *Project) GetDeletionPlan() *DeletionPlan {
+	if m != nil {
+		return m.DeletionPlan
+	}
+	return nil
+}
+
+func (m *Project) GetPins() []*Pin {
+	if m != nil {
+		return m.Pins
+	}
+	return nil
+}
+
+func (m *Project) GetStatus() *ProjectStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *Project) GetUploadInterval() int64 {
+	if m != nil {
+		return m.UploadInterval
+	}
+	return 0
+}
+
+func (m *Project) GetCaps() *ProjectCaps {
+	if m != nil {
+		return m.Caps
+	}
+	return nil
+}
+
+func (m *Project) GetCapConflicts() []string {
+	if m != nil {
+		return m.CapConflicts
+	}
+	return nil
+}
+
+func (m *Project) GetDateSource() *DateSource {
+	if m != nil {
+		return m.DateSource
+	}
+	return nil
+}
+
+// the source of the date of the files of a project
+type DateSource struct {
+	// last_modified (default), filename or metadata
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// regexp extracting the date from the filename: the first group is parsed if any, the whole match otherwise
+	Pattern string `protobuf:"bytes,2,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	// metadata header (i.e X-Amz-Meta-Backup-Date)
+	Header string `protobuf:"bytes,3,opt,name=header,proto3" json:"header,omitempty"`
+	// format of the date (see the Go time package), RFC3339 if empty
+	Layout               string   `protobuf:"bytes,4,opt,name=layout,proto3" json:"layout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DateSource) Reset()         { *m = DateSource{} }
+func (m *DateSource) String() string { return proto.CompactTextString(m) }
+func (*DateSource) ProtoMessage()    {}
+func (*DateSource) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{53}
+}
+
+func (m *DateSource) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DateSource.Unmarshal(m, b)
+}
+func (m *DateSource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DateSource.Marshal(b, m, deterministic)
+}
+func (m *DateSource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DateSource.Merge(m, src)
+}
+func (m *DateSource) XXX_Size() int {
+	return xxx_messageInfo_DateSource.Size(m)
+}
+func (m *DateSource) XXX_DiscardUnknown() {
+	xxx_messageInfo_DateSource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DateSource proto.InternalMessageInfo
+
+func (m *DateSource) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *DateSource) GetPattern() string {
+	if m != nil {
+		return m.Pattern
+	}
+	return ""
+}
+
+func (m *DateSource) GetHeader() string {
+	if m != nil {
+		return m.Header
+	}
+	return ""
+}
+
+func (m *DateSource) GetLayout() string {
+	if m != nil {
+		return m.Layout
+	}
+	return ""
+}
+
+// the caps limit the files kept by the rules (0 disables a cap)
+// precedence: pinned files, then the newest files (keep_newest), then the caps, then the rules
+type ProjectCaps struct {
+	MaxFiles int32 `protobuf:"varint,1,opt,name=max_files,json=maxFiles,proto3" json:"max_files,omitempty"`
+	MaxBytes int64 `protobuf:"varint,2,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	// in seconds, the older files are removed
+	MaxAge int64 `protobuf:"varint,3,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	// count of the newest files always kept, regardless of their errors and of the caps
+	KeepNewest           int32    `protobuf:"varint,4,opt,name=keep_newest,json=keepNewest,proto3" json:"keep_newest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProjectCaps) Reset()         { *m = ProjectCaps{} }
+func (m *ProjectCaps) String() string { return proto.CompactTextString(m) }
+func (*ProjectCaps) ProtoMessage()    {}
+func (*ProjectCaps) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{54}
+}
+
+func (m *ProjectCaps) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProjectCaps.Unmarshal(m, b)
+}
+func (m *ProjectCaps) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProjectCaps.Marshal(b, m, deterministic)
+}
+func (m *ProjectCaps) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProjectCaps.Merge(m, src)
+}
+func (m *ProjectCaps) XXX_Size() int {
+	return xxx_messageInfo_ProjectCaps.Size(m)
+}
+func (m *ProjectCaps) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProjectCaps.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProjectCaps proto.InternalMessageInfo
+
+func (m *ProjectCaps) GetMaxFiles() int32 {
+	if m != nil {
+		return m.MaxFiles
+	}
+	return 0
+}
+
+func (m *ProjectCaps) GetMaxBytes() int64 {
+	if m != nil {
+		return m.MaxBytes
+	}
+	return 0
+}
+
+func (m *ProjectCaps) GetMaxAge() int64 {
+	if m != nil {
+		return m.MaxAge
+	}
+	return 0
+}
+
+func (m *ProjectCaps) GetKeepNewest() int32 {
+	if m != nil {
+		return m.KeepNewest
+	}
+	return 0
+}
+
+type ProjectStatus struct {
+	// active, paused-deletions or paused-all
+	Status   string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Reason   string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Author   string `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	PausedAt int64  `protobuf:"varint,4,opt,name=paused_at,json=pausedAt,proto3" json:"paused_at,omitempty"`
+	// date at which the project is automatically resumed (never if 0)
+	ResumeAt             int64    `protobuf:"varint,5,opt,name=resume_at,json=resumeAt,proto3" json:"resume_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProjectStatus) Reset()         { *m = ProjectStatus{} }
+func (m *ProjectStatus) String() string { return proto.CompactTextString(m) }
+func (*ProjectStatus) ProtoMessage()    {}
+func (*ProjectStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{55}
+}
+
+func (m *ProjectStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProjectStatus.Unmarshal(m, b)
+}
+func (m *ProjectStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProjectStatus.Marshal(b, m, deterministic)
+}
+func (m *ProjectStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProjectStatus.Merge(m, src)
+}
+func (m *ProjectStatus) XXX_Size() int {
+	return xxx_messageInfo_ProjectStatus.Size(m)
+}
+func (m *ProjectStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProjectStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProjectStatus proto.InternalMessageInfo
+
+func (m *ProjectStatus) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *ProjectStatus) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *ProjectStatus) GetAuthor() string {
+	if m != nil {
+		return m.Author
+	}
+	return ""
+}
+
+func (m *ProjectStatus) GetPausedAt() int64 {
+	if m != nil {
+		return m.PausedAt
+	}
+	return 0
+}
+
+func (m *ProjectStatus) GetResumeAt() int64 {
+	if m != nil {
+		return m.ResumeAt
+	}
+	return 0
+}
+
+type DeletionPlan struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Files                []*File  `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
+	Reasons              []string `protobuf:"bytes,3,rep,name=reasons,proto3" json:"reasons,omitempty"`
+	CreatedAt            int64    `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ApprovedBy           string   `protobuf:"bytes,5,opt,name=approved_by,json=approvedBy,proto3" json:"approved_by,omitempty"`
+	ApprovedAt           int64    `protobuf:"varint,6,opt,name=approved_at,json=approvedAt,proto3" json:"approved_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeletionPlan) Reset()         { *m = DeletionPlan{} }
+func (m *DeletionPlan) String() string { return proto.CompactTextString(m) }
+func (*DeletionPlan) ProtoMessage()    {}
+func (*DeletionPlan) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{56}
+}
+
+func (m *DeletionPlan) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeletionPlan.Unmarshal(m, b)
+}
+func (m *DeletionPlan) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeletionPlan.Marshal(b, m, deterministic)
+}
+func (m *DeletionPlan) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeletionPlan.Merge(m, src)
+}
+func (m *DeletionPlan) XXX_Size() int {
+	return xxx_messageInfo_DeletionPlan.Size(m)
+}
+func (m *DeletionPlan) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeletionPlan.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeletionPlan proto.InternalMessageInfo
+
+func (m *DeletionPlan) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DeletionPlan) GetFiles() []*File {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *DeletionPlan) GetReasons() []string {
+	if m != nil {
+		return m.Reasons
+	}
+	return nil
+}
+
+func (m *DeletionPlan) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *DeletionPlan) GetApprovedBy() string {
+	if m != nil {
+		return m.ApprovedBy
+	}
+	return ""
+}
+
+func (m *DeletionPlan) GetApprovedAt() int64 {
+	if m != nil {
+		return m.ApprovedAt
+	}
+	return 0
+}
+
+type Rule struct {
+	// in days, for compatibility (whole count of days of min_age_seconds)
+	MinAge int32 `protobuf:"varint,1,opt,name=min_age,json=minAge,proto3" json:"min_age,omitempty"`
+	Count  int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// in seconds, takes precedence over min_age when it's set
+	MinAgeSeconds int64 `protobuf:"varint,6,opt,name=min_age_seconds,json=minAgeSeconds,proto3" json:"min_age_seconds,omitempty"`
+	// delay granted to the uploads after the min age, before checking the rule, in seconds (2 hours if 0)
+	Tolerance int64 `protobuf:"varint,7,opt,name=tolerance,proto3" json:"tolerance,omitempty"`
+	// state (readonly)
+	Files                []*File  `protobuf:"bytes,3,rep,name=files,proto3" json:"files,omitempty"`
+	NextDate             int64    `protobuf:"varint,4,opt,name=next_date,json=nextDate,proto3" json:"next_date,omitempty"`
+	Error                Error    `protobuf:"varint,5,opt,name=error,proto3,enum=Error" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Rule) Reset()         { *m = Rule{} }
+func (m *Rule) String() string { return proto.CompactTextString(m) }
+func (*Rule) ProtoMessage()    {}
+func (*Rule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{57}
+}
+
+func (m *Rule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Rule.Unmarshal(m, b)
+}
+func (m *Rule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Rule.Marshal(b, m, deterministic)
+}
+func (m *Rule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Rule.Merge(m, src)
+}
+func (m *Rule) XXX_Size() int {
+	return xxx_messageInfo_Rule.Size(m)
+}
+func (m *Rule) XXX_DiscardUnknown() {
+	xxx_messageInfo_Rule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Rule proto.InternalMessageInfo
+
+func (m *Rule) GetMinAge() int32 {
+	if m != nil {
+		return m.MinAge
+	}
+	return 0
+}
+
+func (m *Rule) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *Rule) GetMinAgeSeconds() int64 {
+	if m != nil {
+		return m.MinAgeSeconds
+	}
+	return 0
+}
+
+func (m *Rule) GetTolerance() int64 {
+	if m != nil {
+		return m.Tolerance
+	}
+	return 0
+}
+
+func (m *Rule) GetFiles() []*File {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *Rule) GetNextDate() int64 {
+	if m != nil {
+		return m.NextDate
+	}
+	return 0
+}
+
+func (m *Rule) GetError() Error {
+	if m != nil {
+		return m.Error
+	}
+	return Error_NO_ERROR
+}
+
+type File struct {
+	Path       string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Date       int64  `protobuf:"varint,2,opt,name=date,proto3" json:"date,omitempty"`
+	Size       int64  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Expiration int64  `protobuf:"varint,4,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	Error      Error  `protobuf:"varint,5,opt,name=error,proto3,enum=Error" json:"error,omitempty"`
+	// set if the file is pinned
+	Pin *Pin `protobuf:"bytes,6,opt,name=pin,proto3" json:"pin,omitempty"`
+	// set if the date is not the last modification date (see DateSource)
+	LastModified         int64    `protobuf:"varint,7,opt,name=last_modified,json=lastModified,proto3" json:"last_modified,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *File) Reset()         { *m = File{} }
+func (m *File) String() string { return proto.CompactTextString(m) }
+func (*File) ProtoMessage()    {}
+func (*File) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{58}
+}
+
+func (m *File) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_File.Unmarshal(m, b)
+}
+func (m *File) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_File.Marshal(b, m, deterministic)
+}
+func (m *File) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_File.Merge(m, src)
+}
+func (m *File) XXX_Size() int {
+	return xxx_messageInfo_File.Size(m)
+}
+func (m *File) XXX_DiscardUnknown() {
+	xxx_messageInfo_File.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_File proto.InternalMessageInfo
+
+func (m *File) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *File) GetDate() int64 {
+	if m != nil {
+		return m.Date
+	}
+	return 0
+}
+
+func (m *File) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *File) GetExpiration() int64 {
+	if m != nil {
+		return m.Expiration
+	}
+	return 0
+}
+
+func (m *File) GetError() Error {
+	if m != nil {
+		return m.Error
+	}
+	return Error_NO_ERROR
+}
+
+func (m *File) GetPin() *Pin {
+	if m != nil {
+		return m.Pin
+	}
+	return nil
+}
+
+func (m *File) GetLastModified() int64 {
+	if m != nil {
+		return m.LastModified
+	}
+	return 0
+}
+
+// Maintenance freezes all the deletions and alerts of the daemon
+type Maintenance struct {
+	Reason    string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	Author    string `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	StartedAt int64  `protobuf:"varint,3,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// never ends if 0
+	Until                int64    `protobuf:"varint,4,opt,name=until,proto3" json:"until,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Maintenance) Reset()         { *m = Maintenance{} }
+func (m *Maintenance) String() string { return proto.CompactTextString(m) }
+func (*Maintenance) ProtoMessage()    {}
+func (*Maintenance) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{59}
+}
+
+func (m *Maintenance) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Maintenance.Unmarshal(m, b)
+}
+func (m *Maintenance) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Maintenance.Marshal(b, m, deterministic)
+}
+func (m *Maintenance) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Maintenance.Merge(m, src)
+}
+func (m *Maintenance) XXX_Size() int {
+	return xxx_messageInfo_Maintenance.Size(m)
+}
+func (m *Maintenance) XXX_DiscardUnknown() {
+	xxx_messageInfo_Maintenance.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Maintenance proto.InternalMessageInfo
+
+func (m *Maintenance) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *Maintenance) GetAuthor() string {
+	if m != nil {
+		return m.Author
+	}
+	return ""
+}
+
+func (m *Maintenance) GetStartedAt() int64 {
+	if m != nil {
+		return m.StartedAt
+	}
+	return 0
+}
+
+func (m *Maintenance) GetUntil() int64 {
+	if m != nil {
+		return m.Until
+	}
+	return 0
+}
+
+type Pin struct {
+	Path      string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Reason    string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Author    string `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	CreatedAt int64  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// never expires if 0
+	ExpiresAt            int64    `protobuf:"varint,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Pin) Reset()         { *m = Pin{} }
+func (m *Pin) String() string { return proto.CompactTextString(m) }
+func (*Pin) ProtoMessage()    {}
+func (*Pin) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{60}
+}
+
+func (m *Pin) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Pin.Unmarshal(m, b)
+}
+func (m *Pin) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Pin.Marshal(b, m, deterministic)
+}
+func (m *Pin) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Pin.Merge(m, src)
+}
+func (m *Pin) XXX_Size() int {
+	return xxx_messageInfo_Pin.Size(m)
+}
+func (m *Pin) XXX_DiscardUnknown() {
+	xxx_messageInfo_Pin.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Pin proto.InternalMessageInfo
+
+func (m *Pin) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *Pin) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *Pin) GetAuthor() string {
+	if m != nil {
+		return m.Author
+	}
+	return ""
+}
+
+func (m *Pin) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *Pin) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+type TrashedFile struct {
+	File      *File `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	TrashedAt int64 `protobuf:"varint,2,opt,name=trashed_at,json=trashedAt,proto3" json:"trashed_at,omitempty"`
+	// date after which the file is permanently deleted
+	PurgeAt              int64    `protobuf:"varint,3,opt,name=purge_at,json=purgeAt,proto3" json:"purge_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TrashedFile) Reset()         { *m = TrashedFile{} }
+func (m *TrashedFile) String() string { return proto.CompactTextString(m) }
+func (*TrashedFile) ProtoMessage()    {}
+func (*TrashedFile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{61}
+}
+
+func (m *TrashedFile) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TrashedFile.Unmarshal(m, b)
+}
+func (m *TrashedFile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TrashedFile.Marshal(b, m, deterministic)
+}
+func (m *TrashedFile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TrashedFile.Merge(m, src)
+}
+func (m *TrashedFile) XXX_Size() int {
+	return xxx_messageInfo_TrashedFile.Size(m)
+}
+func (m *TrashedFile) XXX_DiscardUnknown() {
+	xxx_messageInfo_TrashedFile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TrashedFile proto.InternalMessageInfo
+
+func (m *TrashedFile) GetFile() *File {
+	if m != nil {
+		return m.File
+	}
+	return nil
+}
+
+func (m *TrashedFile) GetTrashedAt() int64 {
+	if m != nil {
+		return m.TrashedAt
+	}
+	return 0
+}
+
+func (m *TrashedFile) GetPurgeAt() int64 {
+	if m != nil {
+		return m.PurgeAt
+	}
+	return 0
+}
+
+type Account struct {
+	Username             string   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Role                 string   `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	Projects             []string `protobuf:"bytes,3,rep,name=projects,proto3" json:"projects,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Account) Reset()         { *m = Account{} }
+func (m *Account) String() string { return proto.CompactTextString(m) }
+func (*Account) ProtoMessage()    {}
+func (*Account) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{62}
+}
+
+func (m *Account) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Account.Unmarshal(m, b)
+}
+func (m *Account) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Account.Marshal(b, m, deterministic)
+}
+func (m *Account) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Account.Merge(m, src)
+}
+func (m *Account) XXX_Size() int {
+	return xxx_messageInfo_Account.Size(m)
+}
+func (m *Account) XXX_DiscardUnknown() {
+	xxx_messageInfo_Account.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Account proto.InternalMessageInfo
+
+func (m *Account) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *Account) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *Account) GetProjects() []string {
+	if m != nil {
+		return m.Projects
+	}
+	return nil
+}
+
+type AuditEvent struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Date                 int64    `protobuf:"varint,2,opt,name=date,proto3" json:"date,omitempty"`
+	Actor                string   `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	Action               string   `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	Target               string   `protobuf:"bytes,5,opt,name=target,proto3" json:"target,omitempty"`
+	Ip                   string   `protobuf:"bytes,6,opt,name=ip,proto3" json:"ip,omitempty"`
+	Success              bool     `protobuf:"varint,7,opt,name=success,proto3" json:"success,omitempty"`
+	Message              string   `protobuf:"bytes,8,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuditEvent) Reset()         { *m = AuditEvent{} }
+func (m *AuditEvent) String() string { return proto.CompactTextString(m) }
+func (*AuditEvent) ProtoMessage()    {}
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{63}
+}
+
+func (m *AuditEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditEvent.Unmarshal(m, b)
+}
+func (m *AuditEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditEvent.Marshal(b, m, deterministic)
+}
+func (m *AuditEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditEvent.Merge(m, src)
+}
+func (m *AuditEvent) XXX_Size() int {
+	return xxx_messageInfo_AuditEvent.Size(m)
+}
+func (m *AuditEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditEvent proto.InternalMessageInfo
+
+func (m *AuditEvent) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *AuditEvent) GetDate() int64 {
+	if m != nil {
+		return m.Date
+	}
+	return 0
+}
+
+func (m *AuditEvent) GetActor() string {
+	if m != nil {
+		return m.Actor
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetIp() string {
+	if m != nil {
+		return m.Ip
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *AuditEvent) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type Notification struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProjectName          string   `protobuf:"bytes,2,opt,name=project_name,json=projectName,proto3" json:"project_name,omitempty"`
+	Level                string   `protobuf:"bytes,3,opt,name=level,proto3" json:"level,omitempty"`
+	Count                int32    `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	Reasons              []string `protobuf:"bytes,5,rep,name=reasons,proto3" json:"reasons,omitempty"`
+	SentAt               int64    `protobuf:"varint,6,opt,name=sent_at,json=sentAt,proto3" json:"sent_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Notification) Reset()         { *m = Notification{} }
+func (m *Notification) String() string { return proto.CompactTextString(m) }
+func (*Notification) ProtoMessage()    {}
+func (*Notification) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{64}
+}
+
+func (m *Notification) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Notification.Unmarshal(m, b)
+}
+func (m *Notification) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Notification.Marshal(b, m, deterministic)
+}
+func (m *Notification) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Notification.Merge(m, src)
+}
+func (m *Notification) XXX_Size() int {
+	return xxx_messageInfo_Notification.Size(m)
+}
+func (m *Notification) XXX_DiscardUnknown() {
+	xxx_messageInfo_Notification.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Notification proto.InternalMessageInfo
+
+func (m *Notification) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Notification) GetProjectName() string {
+	if m != nil {
+		return m.ProjectName
+	}
+	return ""
+}
+
+func (m *Notification) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
+func (m *Notification) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *Notification) GetReasons() []string {
+	if m != nil {
+		return m.Reasons
+	}
+	return nil
+}
+
+func (m *Notification) GetSentAt() int64 {
+	if m != nil {
+		return m.SentAt
+	}
+	return 0
+}
+
+type Event struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Date                 int64    `protobuf:"varint,3,opt,name=date,proto3" json:"date,omitempty"`
+	ProjectName          string   `protobuf:"bytes,4,opt,name=project_name,json=projectName,proto3" json:"project_name,omitempty"`
+	RuleId               string   `protobuf:"bytes,5,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	FilePath             string   `protobuf:"bytes,6,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
+	Message              string   `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Event) Reset()         { *m = Event{} }
+func (m *Event) String() string { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()    {}
+func (*Event) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{65}
+}
+
+func (m *Event) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Event.Unmarshal(m, b)
+}
+func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Event.Marshal(b, m, deterministic)
+}
+func (m *Event) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Event.Merge(m, src)
+}
+func (m *Event) XXX_Size() int {
+	return xxx_messageInfo_Event.Size(m)
+}
+func (m *Event) XXX_DiscardUnknown() {
+	xxx_messageInfo_Event.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Event proto.InternalMessageInfo
+
+func (m *Event) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Event) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Event) GetDate() int64 {
+	if m != nil {
+		return m.Date
+	}
+	return 0
+}
+
+func (m *Event) GetProjectName() string {
+	if m != nil {
+		return m.ProjectName
+	}
+	return ""
+}
+
+func (m *Event) GetRuleId() string {
+	if m != nil {
+		return m.RuleId
+	}
+	return ""
+}
+
+func (m *Event) GetFilePath() string {
+	if m != nil {
+		return m.FilePath
+	}
+	return ""
+}
+
+func (m *Event) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterEnum("Error", Error_name, Error_value)
+	proto.RegisterEnum("GetProjectsRequest_OrderBy", GetProjectsRequest_OrderBy_name, GetProjectsRequest_OrderBy_value)
+	proto.RegisterEnum("GetProjectsRequest_OrderDirection", GetProjectsRequest_OrderDirection_name, GetProjectsRequest_OrderDirection_value)
+	proto.RegisterType((*GetProjectsRequest)(nil), "GetProjectsRequest")
+	proto.RegisterType((*ProjectsListResponse)(nil), "ProjectsListResponse")
+	proto.RegisterType((*CreateProjectRequest)(nil), "CreateProjectRequest")
+	proto.RegisterType((*CreateProjectResponse)(nil), "CreateProjectResponse")
+	proto.RegisterType((*UpdateProjectRequest)(nil), "UpdateProjectRequest")
+	proto.RegisterType((*UpdateProjectResponse)(nil), "UpdateProjectResponse")
+	proto.RegisterType((*RulesReport)(nil), "RulesReport")
+	proto.RegisterType((*RuleWarning)(nil), "RuleWarning")
+	proto.RegisterType((*ApproveDeletionPlanRequest)(nil), "ApproveDeletionPlanRequest")
+	proto.RegisterType((*SetProjectStatusRequest)(nil), "SetProjectStatusRequest")
+	proto.RegisterType((*SimulateRulesRequest)(nil), "SimulateRulesRequest")
+	proto.RegisterType((*SimulateRulesResponse)(nil), "SimulateRulesResponse")
+	proto.RegisterType((*UploadSchedule)(nil), "UploadSchedule")
+	proto.RegisterType((*DayRange)(nil), "DayRange")
+	proto.RegisterType((*SimulatedDay)(nil), "SimulatedDay")
+	proto.RegisterType((*GetProjectRequest)(nil), "GetProjectRequest")
+	proto.RegisterType((*ProjectResponse)(nil), "ProjectResponse")
+	proto.RegisterType((*GetFilesRequest)(nil), "GetFilesRequest")
+	proto.RegisterType((*GetFilesResponse)(nil), "GetFilesResponse")
+	proto.RegisterType((*GetFileURLRequest)(nil), "GetFileURLRequest")
+	proto.RegisterType((*GetFileURLResponse)(nil), "GetFileURLResponse")
+	proto.RegisterType((*DownloadFileRequest)(nil), "DownloadFileRequest")
+	proto.RegisterType((*FileChunk)(nil), "FileChunk")
+	proto.RegisterType((*UploadFileRequest)(nil), "UploadFileRequest")
+	proto.RegisterType((*UploadFileResponse)(nil), "UploadFileResponse")
+	proto.RegisterType((*PinFileRequest)(nil), "PinFileRequest")
+	proto.RegisterType((*PinFileResponse)(nil), "PinFileResponse")
+	proto.RegisterType((*UnpinFileRequest)(nil), "UnpinFileRequest")
+	proto.RegisterType((*UnpinFileResponse)(nil), "UnpinFileResponse")
+	proto.RegisterType((*ListTrashRequest)(nil), "ListTrashRequest")
+	proto.RegisterType((*ListTrashResponse)(nil), "ListTrashResponse")
+	proto.RegisterType((*RestoreFileRequest)(nil), "RestoreFileRequest")
+	proto.RegisterType((*RestoreFileResponse)(nil), "RestoreFileResponse")
+	proto.RegisterType((*PurgeTrashRequest)(nil), "PurgeTrashRequest")
+	proto.RegisterType((*PurgeTrashResponse)(nil), "PurgeTrashResponse")
+	proto.RegisterType((*GetMaintenanceRequest)(nil), "GetMaintenanceRequest")
+	proto.RegisterType((*SetMaintenanceRequest)(nil), "SetMaintenanceRequest")
+	proto.RegisterType((*MaintenanceResponse)(nil), "MaintenanceResponse")
+	proto.RegisterType((*CreateAccountRequest)(nil), "CreateAccountRequest")
+	proto.RegisterType((*AccountResponse)(nil), "AccountResponse")
+	proto.RegisterType((*ListAccountsRequest)(nil), "ListAccountsRequest")
+	proto.RegisterType((*AccountsListResponse)(nil), "AccountsListResponse")
+	proto.RegisterType((*AuthenticateAccountRequest)(nil), "AuthenticateAccountRequest")
+	proto.RegisterType((*AuthenticateAccountResponse)(nil), "AuthenticateAccountResponse")
+	proto.RegisterType((*ChangeAccountPasswordRequest)(nil), "ChangeAccountPasswordRequest")
+	proto.RegisterType((*GetAuthConfigRequest)(nil), "GetAuthConfigRequest")
+	proto.RegisterType((*AuthConfigResponse)(nil), "AuthConfigResponse")
+	proto.RegisterType((*ListAuditEventsRequest)(nil), "ListAuditEventsRequest")
+	proto.RegisterType((*AuditEventsListResponse)(nil), "AuditEventsListResponse")
+	proto.RegisterType((*ListNotificationsRequest)(nil), "ListNotificationsRequest")
+	proto.RegisterType((*NotificationsListResponse)(nil), "NotificationsListResponse")
+	proto.RegisterType((*WatchEventsRequest)(nil), "WatchEventsRequest")
+	proto.RegisterType((*Project)(nil), "Project")
+	proto.RegisterType((*DateSource)(nil), "DateSource")
+	proto.RegisterType((*ProjectCaps)(nil), "ProjectCaps")
+	proto.RegisterType((*ProjectStatus)(nil), "ProjectStatus")
+	proto.RegisterType((*DeletionPlan)(nil), "DeletionPlan")
+	proto.RegisterType((*Rule)(nil), "Rule")
+	proto.RegisterType((*File)(nil), "File")
+	proto.RegisterType((*Maintenance)(nil), "Maintenance")
+	proto.RegisterType((*Pin)(nil), "Pin")
+	proto.RegisterType((*TrashedFile)(nil), "TrashedFile")
+	proto.RegisterType((*Account)(nil), "Account")
+	proto.RegisterType((*AuditEvent)(nil), "AuditEvent")
+	proto.RegisterType((*Notification)(nil), "Notification")
+	proto.RegisterType((*Event)(nil), "Event")
+}
+
+func init() { proto.RegisterFile("api.proto", fileDescriptor_00212fb1f9d3bf1c) }
+
+var fileDescriptor_00212fb1f9d3bf1c = []byte{
+	// 2993 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0xcb, 0x6f, 0xdb, 0xc8,
+	0x19, 0x5f, 0xea, 0xad, 0x4f, 0xb2, 0x2d, 0x8f, 0x64, 0x47, 0x91, 0x93, 0xae, 0x97, 0x59, 0xec,
+	0xba, 0xdd, 0xc5, 0x24, 0xf0, 0x22, 0xbb, 0xdb, 0x07, 0x52, 0x28, 0xb6, 0x93, 0x7a, 0xeb, 0xd8,
+	0x2e, 0x65, 0x77, 0x8b, 0xed, 0x81, 0x98, 0x88, 0x63, 0x9b, 0x1b, 0x8a, 0x64, 0xc9, 0x91, 0x63,
+	0xf7, 0xd6, 0x53, 0x51, 0xa0, 0x40, 0x1f, 0x97, 0xf6, 0x58, 0xa0, 0xc7, 0xde, 0x7a, 0xe8, 0xa1,
+	0x40, 0x81, 0xfe, 0x0f, 0x3d, 0xf7, 0x3f, 0xe8, 0xa5, 0xff, 0x41, 0x31, 0x0f, 0x92, 0x43, 0x89,
+	0x76, 0xec, 0x22, 0x27, 0xeb, 0xfb, 0xe6, 0xf5, 0x3d, 0x67, 0xbe, 0xef, 0x47, 0x43, 0x93, 0x84,
+	0x2e, 0x0e, 0xa3, 0x80, 0x05, 0xe6, 0x7f, 0x0c, 0x40, 0xcf, 0x29, 0x3b, 0x8c, 0x82, 0xaf, 0xe9,
+	0x98, 0xc5, 0x16, 0xfd, 0xd9, 0x94, 0xc6, 0x0c, 0x7d, 0x0a, 0x8d, 0x20, 0x72, 0x68, 0x64, 0xbf,
+	0xbc, 0xec, 0x1b, 0xeb, 0xc6, 0xc6, 0xe2, 0xe6, 0x1a, 0x9e, 0x9f, 0x86, 0x0f, 0xf8, 0x9c, 0xa7,
+	0x97, 0x56, 0x3d, 0x90, 0x3f, 0xd0, 0xf7, 0xa1, 0x29, 0xd7, 0x39, 0x6e, 0xd4, 0x2f, 0x89, 0x85,
+	0xe6, 0x95, 0x0b, 0xb7, 0xdd, 0x88, 0x8e, 0x99, 0x1b, 0xf8, 0x96, 0x3c, 0x6c, 0xdb, 0x8d, 0xcc,
+	0xcf, 0xa1, 0xae, 0x36, 0x45, 0x0d, 0xa8, 0xec, 0x0f, 0x5f, 0xec, 0x74, 0xde, 0x41, 0xcb, 0xb0,
+	0xb0, 0x65, 0xed, 0x0c, 0x8f, 0x76, 0x0f, 0xf6, 0xed, 0xed, 0xe1, 0xd1, 0x4e, 0xc7, 0x40, 0x1d,
+	0x68, 0xef, 0x8e, 0x46, 0xc7, 0x3b, 0x23, 0x7b, 0xeb, 0xe0, 0x78, 0xff, 0xa8, 0x53, 0x32, 0x1f,
+	0xc0, 0x62, 0x7e, 0x57, 0x54, 0x87, 0xf2, 0x70, 0xb4, 0xd5, 0x79, 0x87, 0xef, 0xb4, 0xbd, 0x33,
+	0xda, 0xea, 0x18, 0xa6, 0x05, 0xbd, 0x44, 0x94, 0x3d, 0x37, 0x66, 0x16, 0x8d, 0xc3, 0xc0, 0x8f,
+	0x29, 0x7a, 0x1f, 0x1a, 0xa1, 0xe2, 0xf7, 0x8d, 0xf5, 0xf2, 0x46, 0x6b, 0xb3, 0x81, 0xd5, 0x44,
+	0x2b, 0x1d, 0x41, 0x3d, 0xa8, 0xb2, 0x80, 0x11, 0x4f, 0x68, 0x56, 0xb5, 0x24, 0x61, 0xfe, 0xd7,
+	0x80, 0xde, 0x56, 0x44, 0x09, 0xa3, 0xc9, 0x0a, 0x65, 0x44, 0x04, 0x15, 0x9f, 0x4c, 0xa8, 0x30,
+	0x60, 0xd3, 0x12, 0xbf, 0xd1, 0x1a, 0x54, 0xa3, 0xa9, 0x47, 0xe3, 0x7e, 0x49, 0x9c, 0x52, 0xc5,
+	0xd6, 0xd4, 0xa3, 0x96, 0xe4, 0xa1, 0x87, 0xd0, 0x0d, 0xa3, 0x60, 0x4c, 0xe3, 0xd8, 0x76, 0x27,
+	0x13, 0xea, 0xb8, 0x84, 0x51, 0xef, 0xb2, 0x5f, 0x5e, 0x37, 0x36, 0x1a, 0x16, 0x52, 0x43, 0xbb,
+	0xd9, 0x08, 0xfa, 0x10, 0x96, 0xa6, 0xa1, 0x17, 0x10, 0xc7, 0x76, 0x7d, 0x46, 0xa3, 0x73, 0xe2,
+	0xf5, 0x2b, 0xeb, 0xc6, 0x46, 0xd9, 0x5a, 0x94, 0xec, 0x5d, 0xc5, 0x45, 0xeb, 0x50, 0x19, 0x93,
+	0x30, 0xee, 0x57, 0xd7, 0x8d, 0x8d, 0xd6, 0x66, 0x3b, 0xd1, 0x6d, 0x8b, 0x84, 0xb1, 0x25, 0x46,
+	0xd0, 0xc7, 0xd0, 0x72, 0x08, 0xa3, 0x76, 0x1c, 0x4c, 0xa3, 0x31, 0xed, 0xd7, 0xc4, 0xc4, 0x16,
+	0xde, 0x26, 0x8c, 0x8e, 0x04, 0xcb, 0x02, 0x27, 0xfd, 0x6d, 0x12, 0x58, 0x99, 0x51, 0x59, 0x19,
+	0xd2, 0x84, 0xba, 0x32, 0x97, 0x50, 0x5b, 0xb7, 0x63, 0x32, 0x80, 0xde, 0x87, 0x5a, 0x44, 0xc3,
+	0x20, 0x62, 0xfd, 0x92, 0x12, 0x87, 0x1b, 0x21, 0xb6, 0x04, 0xcf, 0x52, 0x63, 0xe6, 0x3f, 0x0d,
+	0xe8, 0x1d, 0x87, 0xce, 0x5b, 0x30, 0x6b, 0x81, 0x95, 0xca, 0xd7, 0x5a, 0xa9, 0x72, 0x53, 0x2b,
+	0x55, 0xdf, 0x68, 0xa5, 0x19, 0x0d, 0xde, 0xba, 0x95, 0x7e, 0x6d, 0x40, 0x4b, 0xe3, 0xa3, 0x0d,
+	0x68, 0xbc, 0x26, 0x91, 0xef, 0xfa, 0xa7, 0x49, 0x20, 0xcb, 0x75, 0x5f, 0x4a, 0xa6, 0x95, 0x8e,
+	0xa2, 0x07, 0xb0, 0x30, 0x21, 0x17, 0x76, 0x44, 0x19, 0xf5, 0x79, 0xba, 0x88, 0x63, 0xca, 0x56,
+	0x7b, 0x42, 0x2e, 0xac, 0x84, 0x87, 0x30, 0x74, 0xe9, 0x45, 0x48, 0xc7, 0x8c, 0x3a, 0xf6, 0x89,
+	0xeb, 0x51, 0x7b, 0x1c, 0x4c, 0x7d, 0x26, 0xcc, 0x57, 0xb5, 0x96, 0x93, 0xa1, 0x67, 0xae, 0x47,
+	0xb7, 0xf8, 0x80, 0xf9, 0x63, 0x68, 0x69, 0xa7, 0x71, 0x57, 0xb1, 0xcb, 0x30, 0x75, 0x15, 0xff,
+	0x8d, 0xee, 0x42, 0x85, 0xbb, 0x45, 0x69, 0xa5, 0x3c, 0x25, 0x58, 0xa8, 0x0f, 0xf5, 0x09, 0x8d,
+	0x63, 0x72, 0x4a, 0xc5, 0x09, 0x4d, 0x2b, 0x21, 0xcd, 0x9f, 0xc0, 0x60, 0x18, 0x86, 0x51, 0x70,
+	0x4e, 0xb7, 0xa9, 0x47, 0xb9, 0x68, 0x87, 0x1e, 0xf1, 0x93, 0x88, 0x78, 0x0f, 0xda, 0xca, 0x6a,
+	0xb6, 0x16, 0x19, 0x2d, 0xc5, 0xdb, 0xe7, 0x01, 0x72, 0x07, 0xea, 0xa1, 0x47, 0x7c, 0xdb, 0x75,
+	0xc4, 0xc1, 0x4d, 0xab, 0xc6, 0xc9, 0x5d, 0xc7, 0xfc, 0x95, 0x01, 0x77, 0x46, 0xe9, 0x05, 0x35,
+	0x62, 0x84, 0x4d, 0xe3, 0x5b, 0xec, 0xbb, 0x0a, 0xb5, 0x58, 0xac, 0x49, 0xb6, 0x95, 0x14, 0xe7,
+	0x47, 0x94, 0xc4, 0x81, 0xaf, 0x34, 0x51, 0x14, 0x5a, 0x83, 0x66, 0x44, 0xe3, 0xe9, 0x84, 0xda,
+	0x84, 0xa9, 0x5c, 0x6d, 0x48, 0xc6, 0x90, 0x99, 0x7f, 0x30, 0xa0, 0x37, 0x72, 0x27, 0x53, 0x8f,
+	0x30, 0xaa, 0x9c, 0x2a, 0x05, 0x49, 0xc3, 0xdb, 0x28, 0x08, 0x6f, 0x04, 0x15, 0x87, 0x5c, 0xc6,
+	0xea, 0x52, 0x12, 0xbf, 0xe7, 0x24, 0x2f, 0xcf, 0x4b, 0xfe, 0x11, 0x34, 0xe2, 0xf1, 0x19, 0x75,
+	0xb8, 0x2f, 0x64, 0xc0, 0x2f, 0xe1, 0x63, 0x91, 0x0f, 0x23, 0xc5, 0xb6, 0xd2, 0x09, 0xa6, 0x0d,
+	0x2b, 0x33, 0x82, 0xa9, 0x48, 0x7e, 0x4f, 0x1d, 0x2e, 0x05, 0x5b, 0xc0, 0xc9, 0x2c, 0x67, 0x9b,
+	0x5c, 0x2a, 0x59, 0x1e, 0x40, 0x33, 0x9e, 0x46, 0xe7, 0xee, 0x79, 0x10, 0x65, 0xf9, 0xc9, 0x43,
+	0xc6, 0xca, 0xf8, 0xe6, 0x9f, 0x0d, 0x58, 0xcc, 0x9f, 0x8e, 0x06, 0xd0, 0x48, 0xf3, 0xd5, 0x90,
+	0x96, 0x4a, 0x68, 0xae, 0x73, 0xec, 0xfe, 0x9c, 0xaa, 0x98, 0x15, 0xbf, 0xd1, 0x7d, 0xa8, 0x9c,
+	0xf2, 0xec, 0x2d, 0x8b, 0x23, 0x9a, 0x98, 0x4b, 0x40, 0xfc, 0x53, 0x6a, 0x09, 0x36, 0x7a, 0x17,
+	0x5a, 0xf1, 0x59, 0xe4, 0xfa, 0xaf, 0xec, 0x93, 0x28, 0x98, 0x08, 0x95, 0xab, 0x16, 0x48, 0xd6,
+	0xb3, 0x28, 0x98, 0x70, 0x9b, 0xa9, 0x09, 0x11, 0x61, 0x6e, 0x20, 0x92, 0xdb, 0xb0, 0xd4, 0x22,
+	0x8b, 0xb3, 0x4c, 0x0c, 0x8d, 0x64, 0x57, 0x2e, 0x82, 0xd8, 0xc8, 0x90, 0x66, 0xe7, 0xbf, 0xd1,
+	0x22, 0x94, 0x58, 0xa0, 0x1c, 0x51, 0x62, 0x81, 0xf9, 0x6f, 0x03, 0xda, 0xba, 0x45, 0xa4, 0xaf,
+	0x18, 0x55, 0xfa, 0x88, 0xdf, 0xe8, 0x3d, 0x68, 0xc8, 0x7b, 0x88, 0x3a, 0x79, 0xf3, 0xa4, 0x6c,
+	0xf4, 0x2e, 0xd4, 0x23, 0x3a, 0x09, 0xce, 0xa9, 0xd3, 0x2f, 0xeb, 0x33, 0x12, 0x2e, 0xba, 0x0f,
+	0xa0, 0xa5, 0xa7, 0xd4, 0xad, 0x79, 0x92, 0xa4, 0x25, 0x1f, 0x16, 0x6f, 0x95, 0x2d, 0x8c, 0x56,
+	0x15, 0x87, 0x37, 0x05, 0x67, 0xc4, 0x2d, 0xb7, 0x0a, 0x35, 0xe2, 0xd1, 0x88, 0xc5, 0xfd, 0xda,
+	0x7a, 0x99, 0x07, 0xab, 0xa4, 0xb8, 0xc9, 0xc4, 0x2f, 0xdb, 0xa3, 0xe7, 0xd4, 0xeb, 0xd7, 0x45,
+	0x10, 0x81, 0x60, 0xed, 0x71, 0x8e, 0xf9, 0x21, 0x2c, 0x67, 0x8f, 0xfb, 0x35, 0xf7, 0xb3, 0xf9,
+	0x18, 0x96, 0xfe, 0x8f, 0x3b, 0xd0, 0xfc, 0x02, 0x96, 0x9e, 0x53, 0xf6, 0xcc, 0xd5, 0x52, 0xe1,
+	0x06, 0x39, 0xd9, 0x83, 0xaa, 0xe7, 0x4e, 0x5c, 0x96, 0x3c, 0xd3, 0x82, 0x30, 0x1f, 0x42, 0x27,
+	0xdb, 0x4b, 0xc9, 0xb0, 0x06, 0x55, 0x6e, 0xa4, 0x2c, 0xaf, 0x84, 0x55, 0x25, 0xcf, 0xfc, 0x8d,
+	0x21, 0xb4, 0xe3, 0xac, 0x63, 0x6b, 0x2f, 0x39, 0x7f, 0x00, 0x0d, 0x3e, 0x1c, 0x12, 0x76, 0xa6,
+	0xce, 0x4e, 0x69, 0x6e, 0x47, 0x7a, 0x11, 0xba, 0xd1, 0xa5, 0x8a, 0x4b, 0x45, 0xa1, 0x8f, 0x60,
+	0xd9, 0x09, 0x5e, 0xfb, 0xe2, 0x09, 0xe2, 0x93, 0xb5, 0x94, 0xec, 0x24, 0x03, 0xcf, 0x14, 0x1f,
+	0xdd, 0x85, 0x46, 0xe0, 0x53, 0x9b, 0xb9, 0x13, 0x99, 0x97, 0x0d, 0xab, 0x1e, 0xf8, 0xf4, 0xc8,
+	0x9d, 0x50, 0x73, 0x47, 0xd4, 0x6a, 0xa9, 0x40, 0x4a, 0x89, 0x0e, 0x94, 0xa7, 0x91, 0xa7, 0x84,
+	0xe1, 0x3f, 0xb9, 0xbb, 0xc5, 0xc9, 0x34, 0xe6, 0xb7, 0x8c, 0x94, 0xa5, 0xa9, 0x38, 0x43, 0x66,
+	0xee, 0x42, 0x77, 0x5b, 0x3b, 0xf5, 0x86, 0x9a, 0x05, 0x27, 0x27, 0x31, 0x4d, 0x76, 0x53, 0x94,
+	0x39, 0x86, 0xa6, 0xb8, 0xfc, 0xcf, 0xa6, 0xfe, 0x2b, 0x6d, 0x92, 0xa1, 0x4f, 0x52, 0x41, 0x4f,
+	0xc4, 0xd2, 0xb6, 0x08, 0x7a, 0x92, 0x26, 0x70, 0x59, 0x4b, 0x60, 0x7e, 0x97, 0x9e, 0x91, 0xcd,
+	0xc7, 0x9f, 0xf6, 0x2b, 0xea, 0x2e, 0x15, 0x94, 0xf9, 0x7b, 0x03, 0x96, 0xe5, 0xdd, 0xa0, 0x8b,
+	0x7b, 0x83, 0x40, 0x50, 0x1a, 0x89, 0xe1, 0x52, 0xa6, 0x91, 0x30, 0xf3, 0x2d, 0x04, 0x48, 0x15,
+	0xa8, 0x66, 0x0a, 0x98, 0xcf, 0x01, 0xe9, 0x32, 0x29, 0x5f, 0xdc, 0x85, 0x0a, 0x3f, 0x41, 0x45,
+	0xb4, 0x8a, 0x27, 0xc1, 0xd2, 0x36, 0x2f, 0xe5, 0xb4, 0x1b, 0xc3, 0xe2, 0xa1, 0xeb, 0xdf, 0xc2,
+	0x11, 0xea, 0x5d, 0x29, 0xe5, 0xde, 0x95, 0xbc, 0xcb, 0xcb, 0xb3, 0x2e, 0xff, 0x26, 0x2c, 0xa5,
+	0x87, 0x28, 0x51, 0x57, 0xa1, 0x1c, 0xba, 0xbe, 0x92, 0xb4, 0x82, 0x0f, 0x5d, 0xdf, 0xe2, 0x0c,
+	0x13, 0x43, 0xe7, 0xd8, 0x0f, 0x6f, 0x2c, 0x91, 0xf9, 0x11, 0x2c, 0x6b, 0xf3, 0xdf, 0xb0, 0xf9,
+	0x63, 0xe8, 0xf0, 0xba, 0xfb, 0x28, 0x22, 0xf1, 0xd9, 0xcd, 0x1d, 0x69, 0x7e, 0x06, 0xcb, 0xda,
+	0xb2, 0xf4, 0x02, 0xc9, 0x25, 0x6f, 0x1b, 0x8b, 0x61, 0xea, 0xe8, 0x39, 0xfc, 0x08, 0x90, 0x45,
+	0x63, 0x16, 0x44, 0xf4, 0xa6, 0xea, 0x3c, 0x82, 0x6e, 0x6e, 0xc5, 0x1b, 0x1d, 0x6b, 0x3e, 0x84,
+	0xe5, 0xc3, 0x69, 0x74, 0x4a, 0x73, 0x4a, 0x5d, 0x77, 0xc4, 0x26, 0x20, 0x7d, 0x81, 0x3a, 0xe1,
+	0x1e, 0x34, 0x93, 0x19, 0x52, 0xa5, 0xa6, 0x95, 0x31, 0xcc, 0x3b, 0xb0, 0xf2, 0x9c, 0xb2, 0x17,
+	0x84, 0xbf, 0x80, 0x3e, 0xf1, 0xc7, 0x89, 0x2e, 0xe2, 0x65, 0x2e, 0x1a, 0xe0, 0xc5, 0x14, 0xf5,
+	0xc9, 0x4b, 0x8f, 0x3a, 0x42, 0x80, 0x86, 0x95, 0x90, 0x57, 0xc6, 0x50, 0x0f, 0xaa, 0x53, 0x9f,
+	0xb9, 0x49, 0x75, 0x2c, 0x09, 0x73, 0x07, 0xba, 0xb9, 0xdd, 0x95, 0xb8, 0x18, 0x5a, 0x93, 0x8c,
+	0xad, 0xec, 0xd2, 0xc6, 0xfa, 0x54, 0x7d, 0x82, 0xf9, 0x32, 0x69, 0x92, 0x86, 0x63, 0xf1, 0x48,
+	0x69, 0x86, 0x9a, 0xc6, 0x34, 0xd2, 0x3c, 0x9f, 0xd2, 0x3c, 0xef, 0xa2, 0xc0, 0x4b, 0x72, 0x57,
+	0xfc, 0xe6, 0xf3, 0xd3, 0x4e, 0xad, 0x2c, 0xac, 0x94, 0xd2, 0xe6, 0x8f, 0x60, 0x29, 0xdd, 0x3d,
+	0x7b, 0x65, 0x88, 0x64, 0xa5, 0xaf, 0x4c, 0x32, 0x25, 0x19, 0x10, 0x5b, 0x92, 0x38, 0x7e, 0x1d,
+	0x44, 0x49, 0x71, 0x98, 0xd2, 0xe6, 0x0a, 0x74, 0x79, 0xe4, 0xa9, 0x35, 0xc9, 0x2b, 0x64, 0x7e,
+	0x0f, 0x7a, 0x09, 0x6b, 0xb6, 0x8f, 0x54, 0xbb, 0x66, 0x7d, 0x64, 0x72, 0x5e, 0x3a, 0x62, 0x1e,
+	0xc1, 0x60, 0x38, 0x65, 0x67, 0xd4, 0x67, 0xee, 0xf8, 0x76, 0x16, 0xb9, 0x4e, 0xd4, 0x4f, 0x60,
+	0xad, 0x70, 0x57, 0x25, 0x9a, 0x68, 0x5e, 0x5f, 0x51, 0x5f, 0xed, 0x29, 0x09, 0xf3, 0x3b, 0x70,
+	0x6f, 0xeb, 0x8c, 0xd7, 0x33, 0x6a, 0xfa, 0xa1, 0xda, 0xed, 0x06, 0xc2, 0x98, 0xab, 0xd0, 0x7b,
+	0x4e, 0x19, 0x3f, 0x73, 0x2b, 0xf0, 0x4f, 0xdc, 0xd3, 0xc4, 0x38, 0x3f, 0x05, 0xa4, 0x33, 0xd5,
+	0xf9, 0xef, 0x42, 0x2b, 0x70, 0x9d, 0xb1, 0xed, 0xc6, 0xf1, 0x94, 0x46, 0x6a, 0x33, 0xe0, 0xac,
+	0x5d, 0xc1, 0x41, 0xef, 0xc3, 0xa2, 0x98, 0x30, 0xf6, 0x5c, 0xea, 0xb3, 0xac, 0x52, 0x6f, 0x73,
+	0xee, 0x96, 0x60, 0xee, 0x3a, 0xe6, 0x57, 0xb0, 0x2a, 0x1c, 0x32, 0x75, 0x5c, 0xb6, 0x73, 0x4e,
+	0x33, 0x9f, 0x70, 0x05, 0xc9, 0x98, 0x05, 0xc9, 0xd6, 0x92, 0xe0, 0xdc, 0xd8, 0xe5, 0x11, 0x2a,
+	0x1f, 0x2e, 0x49, 0x64, 0x25, 0x42, 0x59, 0x2f, 0x11, 0x9e, 0xc0, 0x1d, 0x6d, 0xdf, 0x9c, 0x63,
+	0x1f, 0x40, 0x8d, 0x9e, 0xd3, 0xcc, 0xad, 0x2d, 0x9c, 0xcd, 0xb4, 0xd4, 0x90, 0xf9, 0x08, 0xfa,
+	0x7c, 0xd1, 0x7e, 0xc0, 0xdc, 0x13, 0xee, 0x03, 0x37, 0xf0, 0x75, 0xe9, 0xe4, 0x89, 0x86, 0x7e,
+	0xe2, 0x21, 0xdc, 0xcd, 0xcd, 0xce, 0x9d, 0xf9, 0x09, 0x2c, 0xf8, 0xfa, 0x60, 0x5a, 0x64, 0xeb,
+	0x4b, 0xac, 0xfc, 0x1c, 0xf3, 0x8f, 0x06, 0xa0, 0x2f, 0x09, 0x1b, 0x9f, 0xe5, 0x8d, 0x73, 0xb3,
+	0xb2, 0x89, 0x37, 0x68, 0xb2, 0x46, 0x6f, 0x5a, 0x92, 0x90, 0x97, 0x45, 0xe8, 0x91, 0x4b, 0x65,
+	0x2a, 0x45, 0xf1, 0x32, 0x45, 0x98, 0x92, 0xfb, 0x89, 0xbf, 0x96, 0x15, 0xab, 0x2e, 0xe8, 0x5d,
+	0x71, 0xbf, 0x9c, 0x04, 0x9e, 0x17, 0xbc, 0x16, 0x0f, 0x66, 0xc3, 0x52, 0x94, 0xf9, 0xdb, 0x32,
+	0xd4, 0x55, 0x89, 0x77, 0xfb, 0x26, 0xfe, 0x3e, 0xc0, 0x58, 0xdc, 0x1f, 0x8e, 0xf6, 0xc0, 0x29,
+	0xce, 0x50, 0xe8, 0x27, 0x02, 0x2b, 0xce, 0x95, 0xc0, 0x2d, 0xc9, 0x93, 0x45, 0xf0, 0x26, 0x2c,
+	0x38, 0xaa, 0x79, 0xb4, 0x79, 0xf3, 0xa7, 0xba, 0xf7, 0x05, 0x9c, 0x6b, 0x29, 0xdb, 0x8e, 0x46,
+	0xa1, 0x3e, 0x54, 0x42, 0xd7, 0x97, 0x75, 0x71, 0xf2, 0x90, 0x09, 0x0e, 0xfa, 0x20, 0x6d, 0xfc,
+	0xea, 0x62, 0x9b, 0x45, 0x9c, 0x6f, 0x21, 0xd5, 0x68, 0x11, 0xf8, 0xd0, 0xb8, 0x16, 0x7c, 0x68,
+	0x5e, 0x09, 0x3e, 0x3c, 0x80, 0x85, 0x31, 0x09, 0xed, 0x71, 0xe0, 0x9f, 0x78, 0x2e, 0xbf, 0xff,
+	0x40, 0x38, 0xaa, 0x3d, 0x26, 0xe1, 0x56, 0xc2, 0x9b, 0x45, 0x28, 0x5a, 0xd7, 0x23, 0x14, 0x5f,
+	0x03, 0x64, 0x23, 0x85, 0xed, 0x7a, 0x1f, 0xea, 0x21, 0x61, 0x8c, 0x46, 0xc9, 0x6b, 0x91, 0x90,
+	0xdc, 0xcd, 0x67, 0x94, 0x38, 0x34, 0x4a, 0x5a, 0x5c, 0x49, 0x71, 0xbe, 0x47, 0x2e, 0x83, 0x29,
+	0x4b, 0xaa, 0x28, 0x49, 0x99, 0xbf, 0x30, 0xa0, 0xa5, 0x29, 0xc5, 0x5b, 0x61, 0x0e, 0x40, 0x24,
+	0x6f, 0x38, 0xf7, 0x57, 0x63, 0x42, 0x2e, 0x44, 0x85, 0x9e, 0x0c, 0xbe, 0xbc, 0x64, 0x34, 0x56,
+	0xa9, 0xcb, 0x07, 0x9f, 0x72, 0x9a, 0x37, 0xf3, 0x7c, 0x30, 0xc1, 0x09, 0xca, 0x56, 0x6d, 0x42,
+	0x2e, 0x86, 0xa7, 0xe2, 0x8e, 0x79, 0x45, 0x69, 0x68, 0xfb, 0xf4, 0x35, 0x8d, 0x93, 0x20, 0x00,
+	0xce, 0xda, 0x17, 0x1c, 0xf3, 0x77, 0x06, 0x2c, 0xe4, 0xfc, 0xa4, 0x35, 0xf0, 0xc6, 0x15, 0x0d,
+	0x7c, 0xfe, 0x91, 0xe4, 0xbd, 0xd2, 0x94, 0x9d, 0x05, 0xa9, 0xd6, 0x92, 0xe2, 0x02, 0x87, 0x64,
+	0x1a, 0xcb, 0xf0, 0x54, 0x8d, 0xbd, 0x64, 0x0c, 0x59, 0xbe, 0xeb, 0xaf, 0xce, 0x74, 0xfd, 0x7f,
+	0x33, 0xa0, 0xad, 0x87, 0x20, 0xef, 0x22, 0x5d, 0x47, 0x89, 0x53, 0x72, 0x9d, 0xac, 0x4b, 0x29,
+	0xcd, 0x77, 0x29, 0xdc, 0x3f, 0x52, 0xb2, 0xe4, 0x39, 0x4c, 0xc8, 0x99, 0x8c, 0xa9, 0xcc, 0x66,
+	0x0c, 0x6f, 0xee, 0x24, 0xa4, 0xe2, 0x70, 0x94, 0xb7, 0xaa, 0x9a, 0x3b, 0xc5, 0x7a, 0x7a, 0x99,
+	0x9b, 0x40, 0x98, 0x40, 0x04, 0xcb, 0xd9, 0x84, 0x21, 0x33, 0xff, 0x65, 0x40, 0x85, 0xa7, 0xa8,
+	0xf0, 0x87, 0xeb, 0x0b, 0x7f, 0x48, 0x3f, 0xd6, 0x26, 0xae, 0xcf, 0xfd, 0xd1, 0x83, 0xaa, 0x4c,
+	0x47, 0xd5, 0x89, 0x09, 0x02, 0x7d, 0x00, 0x4b, 0x6a, 0xba, 0x1d, 0xd3, 0x71, 0xe0, 0x3b, 0xb1,
+	0xda, 0x7c, 0x41, 0x2e, 0x1b, 0x49, 0x26, 0xaf, 0x88, 0x58, 0xe0, 0xd1, 0x48, 0x14, 0x18, 0xf5,
+	0xa4, 0x69, 0x55, 0x8c, 0xcc, 0x2a, 0xe5, 0x02, 0xab, 0xac, 0x41, 0xd3, 0xa7, 0x17, 0xcc, 0x16,
+	0xcd, 0xb6, 0xf2, 0x06, 0x67, 0xf0, 0x60, 0x47, 0xf7, 0xa0, 0x4a, 0xa3, 0x28, 0x88, 0x84, 0xce,
+	0x8b, 0x9b, 0x35, 0xbc, 0xc3, 0x29, 0x4b, 0x32, 0xcd, 0x7f, 0x18, 0x50, 0xe1, 0x5b, 0xf1, 0x6c,
+	0xd0, 0xca, 0x37, 0xf1, 0x3b, 0xed, 0xdf, 0x4b, 0x5a, 0xff, 0x5e, 0xd4, 0x49, 0x7c, 0x43, 0x95,
+	0xe3, 0xe2, 0x52, 0x56, 0x02, 0x68, 0x9c, 0xeb, 0x45, 0x48, 0xaa, 0xe7, 0xda, 0x4c, 0xf5, 0xcc,
+	0x2f, 0x00, 0x8f, 0xc4, 0xcc, 0x9e, 0x04, 0x8e, 0x7b, 0xe2, 0x52, 0x47, 0x19, 0xa5, 0xcd, 0x99,
+	0x2f, 0x14, 0xcf, 0x8c, 0xa0, 0xa5, 0x15, 0x61, 0x5a, 0x1c, 0x1b, 0x57, 0xc4, 0x71, 0x29, 0x17,
+	0xc7, 0xf7, 0x01, 0x62, 0x46, 0xa2, 0xfc, 0x3d, 0xab, 0x38, 0x43, 0x96, 0xd5, 0x88, 0x15, 0xbd,
+	0x46, 0xfc, 0xa5, 0x01, 0xe5, 0x43, 0xd7, 0x2f, 0x34, 0xd9, 0x6d, 0x13, 0xe9, 0x0d, 0x61, 0x9b,
+	0x6f, 0x74, 0xaa, 0xb3, 0x8d, 0xce, 0x4b, 0x68, 0x69, 0x6d, 0xc0, 0x75, 0xfd, 0x18, 0xc7, 0x44,
+	0xe4, 0x4c, 0xad, 0x49, 0x56, 0x9c, 0x21, 0xe3, 0xef, 0x5b, 0xc8, 0x8b, 0xf4, 0xcc, 0x0a, 0x75,
+	0x41, 0x0f, 0x99, 0x79, 0x0c, 0xf5, 0x61, 0x56, 0x3a, 0xbe, 0xb5, 0xea, 0xf5, 0xef, 0x06, 0x40,
+	0x56, 0x54, 0x68, 0xb7, 0x40, 0x45, 0xdc, 0x02, 0x45, 0xa1, 0x97, 0x96, 0x3c, 0x65, 0xbd, 0xe4,
+	0xe1, 0x96, 0x1d, 0xa7, 0x81, 0xd7, 0xb4, 0x14, 0xc5, 0xf9, 0x8c, 0x44, 0xa7, 0x94, 0xa9, 0x64,
+	0x57, 0x94, 0x38, 0x29, 0xec, 0xd7, 0xd4, 0x7d, 0x13, 0xf2, 0x2b, 0x25, 0x9e, 0x8e, 0xc7, 0x34,
+	0x96, 0x6f, 0x5b, 0xc3, 0x4a, 0x48, 0x1d, 0xa0, 0x6d, 0xe4, 0x01, 0xda, 0x3f, 0x19, 0xd0, 0xd6,
+	0xcb, 0x92, 0x39, 0xf1, 0x67, 0x0b, 0x90, 0x52, 0x31, 0x6e, 0x23, 0x80, 0x26, 0xa5, 0x8d, 0x20,
+	0xb2, 0x3b, 0xa4, 0xa2, 0xdf, 0x21, 0xda, 0xb5, 0x57, 0xcd, 0x5f, 0x7b, 0x77, 0xa0, 0x1e, 0xf3,
+	0xfa, 0x31, 0xbd, 0xb2, 0x6a, 0x9c, 0x1c, 0x32, 0xf3, 0xaf, 0x06, 0x54, 0xaf, 0x34, 0xad, 0x78,
+	0xf7, 0x4a, 0xda, 0xbb, 0x97, 0x98, 0xbb, 0x9c, 0x43, 0xea, 0xf2, 0x3a, 0x54, 0x0a, 0x71, 0x66,
+	0x5e, 0xaf, 0xf0, 0xaa, 0x48, 0x19, 0x99, 0x93, 0xbb, 0xfc, 0x12, 0x17, 0xdd, 0x9c, 0x2d, 0xf2,
+	0xa3, 0x96, 0x75, 0x84, 0x87, 0x3c, 0x47, 0x34, 0xbb, 0xd6, 0x73, 0x76, 0xfd, 0xd6, 0x1e, 0x54,
+	0xc5, 0xd5, 0x80, 0xda, 0xd0, 0xd8, 0x3f, 0xb0, 0x77, 0x2c, 0xeb, 0xc0, 0xea, 0xbc, 0x83, 0x5a,
+	0x50, 0x3f, 0xde, 0xff, 0xe1, 0xfe, 0xc1, 0x97, 0xfb, 0x1d, 0x83, 0x0f, 0x1d, 0x3c, 0x1d, 0x1d,
+	0xec, 0xed, 0x1c, 0xed, 0x74, 0x4a, 0x68, 0x01, 0x9a, 0x47, 0x07, 0x07, 0xf6, 0xe8, 0xc5, 0x70,
+	0x6f, 0xaf, 0x53, 0xe6, 0x33, 0xf7, 0x0f, 0xec, 0x67, 0xbb, 0x7b, 0x3b, 0x9d, 0xca, 0xe6, 0x5f,
+	0xda, 0xd0, 0x78, 0x4a, 0xc6, 0xaf, 0xa2, 0x61, 0xe8, 0xa2, 0x6f, 0x43, 0x4b, 0xfb, 0x32, 0x87,
+	0xba, 0x05, 0xdf, 0xe9, 0x06, 0x2b, 0xb8, 0xf0, 0x73, 0xd9, 0x26, 0x40, 0x36, 0x19, 0x21, 0x3c,
+	0x07, 0x02, 0x0e, 0x3a, 0x78, 0x16, 0xef, 0x7b, 0x02, 0x0b, 0xb9, 0x4f, 0x46, 0x68, 0x05, 0x17,
+	0x7d, 0x35, 0x1b, 0xac, 0xe2, 0xe2, 0x2f, 0x4b, 0x4f, 0x60, 0x21, 0xf7, 0x31, 0x05, 0xad, 0xe0,
+	0xa2, 0xcf, 0x43, 0x83, 0x55, 0x5c, 0xfc, 0xcd, 0x65, 0x1b, 0xba, 0x05, 0x9f, 0x10, 0xd0, 0x1a,
+	0xbe, 0xfa, 0xc3, 0x42, 0xa1, 0x16, 0x9d, 0xd9, 0xaf, 0x05, 0xa8, 0x8f, 0xaf, 0xf8, 0x80, 0x50,
+	0x6c, 0x85, 0x1c, 0x90, 0x8e, 0x56, 0x70, 0x11, 0xe2, 0x3f, 0x58, 0xc5, 0xc5, 0x78, 0xfb, 0x43,
+	0x68, 0x24, 0x28, 0x26, 0xea, 0xe0, 0x19, 0x70, 0x74, 0xb0, 0x8c, 0xe7, 0x20, 0xce, 0xc7, 0x00,
+	0x8a, 0x77, 0x6c, 0xed, 0x49, 0x57, 0xe5, 0x11, 0xcd, 0x41, 0x17, 0x17, 0x80, 0x8a, 0x9b, 0xd0,
+	0xd6, 0x31, 0x42, 0xd4, 0xc3, 0x05, 0x90, 0xe1, 0x00, 0x70, 0x8a, 0xfe, 0x3d, 0x32, 0xd0, 0x67,
+	0x00, 0x19, 0x24, 0x86, 0x10, 0x9e, 0xc3, 0xec, 0x06, 0x5d, 0x3c, 0x8f, 0x99, 0x6d, 0x18, 0xe8,
+	0x63, 0xa8, 0x2b, 0x74, 0x0a, 0x2d, 0xe1, 0x3c, 0x18, 0x36, 0xe8, 0x64, 0x8c, 0x54, 0xb4, 0x66,
+	0x0a, 0x38, 0xa1, 0x65, 0x3c, 0x0b, 0x56, 0x0d, 0x10, 0x9e, 0xc7, 0xa3, 0x36, 0xa1, 0x99, 0x02,
+	0x48, 0x68, 0x19, 0xcf, 0x62, 0x50, 0x03, 0x84, 0xe7, 0xf1, 0xa5, 0xcf, 0xa1, 0xa5, 0x21, 0x41,
+	0xa8, 0x8b, 0xe7, 0x91, 0xa4, 0x41, 0x0f, 0x17, 0x81, 0x45, 0x8f, 0x01, 0x32, 0x80, 0x07, 0x21,
+	0x3c, 0x07, 0x0f, 0x0d, 0xba, 0xb8, 0x00, 0x01, 0xfa, 0x3c, 0xc9, 0x90, 0xe4, 0x75, 0x59, 0xc1,
+	0x39, 0x3a, 0x33, 0xc9, 0x6c, 0x6f, 0xff, 0x5d, 0x68, 0xeb, 0x28, 0x05, 0xea, 0xe1, 0x02, 0xd0,
+	0x62, 0xb0, 0x82, 0x0b, 0x31, 0x8b, 0x43, 0xe8, 0x16, 0xe0, 0x06, 0x3c, 0x31, 0xae, 0xc4, 0x28,
+	0x06, 0xf7, 0xf0, 0x75, 0x50, 0xc3, 0x0f, 0x60, 0xa5, 0x10, 0x54, 0x40, 0xf7, 0xf1, 0x75, 0x60,
+	0x43, 0xa1, 0x62, 0x0b, 0x39, 0x88, 0x01, 0xad, 0xe0, 0x22, 0xc8, 0x61, 0xd0, 0xc5, 0x05, 0x88,
+	0xc3, 0x36, 0x2c, 0xcd, 0x40, 0x05, 0xe8, 0x0e, 0x2e, 0x06, 0x0f, 0x06, 0x7d, 0x7c, 0x55, 0xe7,
+	0xff, 0x85, 0xc4, 0x1e, 0x73, 0x6d, 0x3a, 0xba, 0x8b, 0xaf, 0x6a, 0xf4, 0x07, 0x03, 0x7c, 0x75,
+	0x47, 0xff, 0x04, 0x16, 0xf3, 0x28, 0x1e, 0x5a, 0xc5, 0x85, 0xb0, 0xde, 0xa0, 0x87, 0x8b, 0x40,
+	0xb7, 0x27, 0xb0, 0x38, 0x9a, 0x5d, 0x3f, 0xba, 0xc5, 0xfa, 0x8f, 0xa1, 0xa5, 0x61, 0x03, 0xa8,
+	0x8b, 0xe7, 0x91, 0x82, 0x41, 0x0d, 0x0b, 0xfa, 0x91, 0xf1, 0xb4, 0xfe, 0x55, 0x55, 0xfc, 0x93,
+	0xc8, 0xcb, 0x9a, 0xf8, 0xf3, 0xc9, 0xff, 0x06, 0x00, 0xb0, 0xae, 0x06, 0xf9, 0x38, 0x22, 0x00,
+	0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// BackrApiClient is the client API for BackrApi service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type BackrApiClient interface {
+	// projects
+	GetProjects(ctx context.Context, in *GetProjectsRequest, opts ...grpc.CallOption) (*ProjectsListResponse, error)
+	GetProject(ctx context.Context, in *GetProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error)
+	CreateProject(ctx context.Context, in *CreateProjectRequest, opts ...grpc.CallOption) (*CreateProjectResponse, error)
+	UpdateProject(ctx context.Context, in *UpdateProjectRequest, opts ...grpc.CallOption) (*UpdateProjectResponse, error)
+	ApproveDeletionPlan(ctx context.Context, in *ApproveDeletionPlanRequest, opts ...grpc.CallOption) (*ProjectResponse, error)
+	SetProjectStatus(ctx context.Context, in *SetProjectStatusRequest, opts ...grpc.CallOption) (*ProjectResponse, error)
+	// rules
+	SimulateRules(ctx context.Context, in *SimulateRulesRequest, opts ...grpc.CallOption) (*SimulateRulesResponse, error)
+	// files
+	GetFiles(ctx context.Context, in *GetFilesRequest, opts ...grpc.CallOption) (*GetFilesResponse, error)
+	GetFileURL(ctx context.Context, in *GetFileURLRequest, opts ...grpc.CallOption) (*GetFileURLResponse, error)
+	DownloadFile(ctx context.Context, in *DownloadFileRequest, opts ...grpc.CallOption) (BackrApi_DownloadFileClient, error)
+	UploadFile(ctx context.Context, opts ...grpc.CallOption) (BackrApi_UploadFileClient, error)
+	PinFile(ctx context.Context, in *PinFileRequest, opts ...grpc.CallOption) (*PinFileResponse, error)
+	UnpinFile(ctx context.Context, in *UnpinFileRequest, opts ...grpc.CallOption) (*UnpinFileResponse, error)
+	// trash
+	ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error)
+	RestoreFile(ctx context.Context, in *RestoreFileRequest, opts ...grpc.CallOption) (*RestoreFileResponse, error)
+	PurgeTrash(ctx context.Context, in *PurgeTrashRequest, opts ...grpc.CallOption) (*PurgeTrashResponse, error)
+	// account
+	CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*AccountResponse, error)
+	ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*AccountsListResponse, error)
+	AuthenticateAccount(ctx context.Context, in *AuthenticateAccountRequest, opts ...grpc.CallOption) (*AuthenticateAccountResponse, error)
+	ChangeAccountPassword(ctx context.Context, in *ChangeAccountPasswordRequest, opts ...grpc.CallOption) (*AccountResponse, error)
+	GetAuthConfig(ctx context.Context, in *GetAuthConfigRequest, opts ...grpc.CallOption) (*AuthConfigResponse, error)
+	// audit
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*AuditEventsListResponse, error)
+	// notifications
+	ListNotifications(ctx context.Context, in *ListNotificationsRequest, opts ...grpc.CallOption) (*NotificationsListResponse, error)
+	// maintenance
+	GetMaintenance(ctx context.Context, in *GetMaintenanceRequest, opts ...grpc.CallOption) (*MaintenanceResponse, error)
+	SetMaintenance(ctx context.Context, in *SetMaintenanceRequest, opts ...grpc.CallOption) (*MaintenanceResponse, error)
+	// events
+	WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (BackrApi_WatchEventsClient, error)
+}
+
+type backrApiClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewBackrApiClient(cc *grpc.ClientConn) BackrApiClient {
+	return &backrApiClient{cc}
+}
+
+func (c *backrApiClient) GetProjects(ctx context.Context, in *GetProjectsRequest, opts ...grpc.CallOption) (*ProjectsListResponse, error) {
+	out := new(ProjectsListResponse)
+	err := c.cc.Invoke(ctx, "/BackrApi/GetProjects", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrApiClient) GetProject(ctx context.Context, in *GetProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error) {
+	out := new(ProjectResponse)
+	err := c.cc.Invoke(ctx, "/BackrApi/GetProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrApiClient) CreateProject(ctx context.Context, in *CreateProjectRequest, opts ...grpc.CallOption) (*CreateProjectResponse, error) {
+	out := new(CreateProjectResponse)
+	err := c.cc.Invoke(ctx, "/BackrApi/CreateProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrApiClient) UpdateProject(ctx context.Context, in *UpdateProjectRequest, opts ...grpc.CallOption) (*UpdateProjectResponse, error) {
+	out := new(UpdateProjectResponse)
+	err := c.cc.Invoke(ctx, "/BackrApi/UpdateProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrApiClient) ApproveDeletionPlan(ctx context.Context, in *ApproveDeletionPlanRequest, opts ...grpc.CallOption) (*ProjectResponse, error) {
+	out := new(ProjectResponse)
+	err := c.cc.Invoke(ctx, "/BackrApi/ApproveDeletionPlan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrApiClient) SetProjectStatus(ctx context.Context, in *SetProjectStatusRequest, opts ...grpc.CallOption) (*ProjectResponse, error) {
+	out := new(ProjectResponse)
+	err := c.cc.Invoke(ctx, "/BackrApi/SetProjectStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrApiClient) SimulateRules(ctx context.Context, in *SimulateRulesRequest, opts ...grpc.CallOption) (*SimulateRulesResponse, error) {
+	out := new(SimulateRulesResponse)
+	err := c.cc.Invoke(ctx, "/BackrApi/SimulateRules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrApiClient) GetFiles(ctx context.Context, in *GetFilesRequest, opts ...grpc.CallOption) (*GetFilesResponse, error) {
+	out := new(GetFilesResponse)
+	err := c.cc.Invoke(ctx, "/BackrApi/GetFiles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrApiClient) GetFileURL(ctx context.Context, in *GetFileURLRequest, opts ...grpc.CallOption) (*GetFileURLResponse, error) {
+	out := new(GetFileURLResponse)
+	err := c.cc.Invoke(ctx, "/BackrApi/GetFileURL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrApiClient) DownloadFile(ctx context.Context, in *DownloadFileRequest, opts ...grpc.CallOption) (BackrApi_DownloadFileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BackrApi_serviceDesc.Streams[0], "/BackrApi/DownloadFile", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &backrApiDownloadFileClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BackrApi_DownloadFileClient interface {
+	Recv() (*FileChunk, error)
+	grpc.ClientStream
+}
+
+type backrApiDownloadFileClient struct {
+	grpc.ClientStream
+}
+
+func (x *backrApiDownloadFileClient) Recv() (*FileChunk, error) {
+	m := new(FileChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *backrApiClient) UploadFile(ctx context.Context, opts ...grpc.CallOption) (BackrApi_UploadFileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BackrApi_serviceDesc.Streams[1], "/BackrApi/UploadFile", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &backrApiUploadFileClient{stream}
+	return x, nil
+}
+
+type BackrApi_UploadFileClient interface {
+	Send(*UploadFileRequest) error
+	CloseAndRecv() (*UploadFileResponse, error)
+	grpc.ClientStream
+}
+
+type backrApiUploadFileClient struct {
+	grpc.ClientStream
+}
+
+func (x *backrApiUploadFileClient) Send(m *UploadFileRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *backrApiUploadFileClient) CloseAndRecv() (*UploadFileResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadFileResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (c *backrApiClient) PinFile(ctx context.Context, in *PinFileRequest, opts ...grpc.CallOption) (*PinFileResponse, error) {
+	out := new(PinFileResponse)
+	err := c.cc.Invoke(ctx, "/BackrApi/PinFile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-// BackrApiClient is the client API for BackrApi service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type BackrApiClient interface {
+func (c *backrApiClient) UnpinFile(ctx context.Context, in *UnpinFileRequest, opts ...grpc.CallOption) (*UnpinFileResponse, error) {
+	out := new(UnpinFileResponse)
+	err := c.cc.Invoke(ctx, "/BackrApi/UnpinFile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrApiClient) ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error) {
+	out := new(ListTrashResponse)
+	err := c.cc.Invoke(ctx, "/BackrApi/ListTrash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrApiClient) RestoreFile(ctx context.Context, in *RestoreFileRequest, opts ...grpc.CallOption) (*RestoreFileResponse, error) {
+	out := new(RestoreFileResponse)
+	err := c.cc.Invoke(ctx, "/BackrApi/RestoreFile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrApiClient) PurgeTrash(ctx context.Context, in *PurgeTrashRequest, opts ...grpc.CallOption) (*PurgeTrashResponse, error) {
+	out := new(PurgeTrashResponse)
+	err := c.cc.Invoke(ctx, "/BackrApi/PurgeTrash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrApiClient) CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*AccountResponse, error) {
+	out := new(AccountResponse)
+	err := c.cc.Invoke(ctx, "/BackrApi/CreateAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrApiClient) ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*AccountsListResponse, error) {
+	out := new(AccountsListResponse)
+	err := c.cc.Invoke(ctx, "/BackrApi/ListAccounts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrApiClient) AuthenticateAccount(ctx context.Context, in *AuthenticateAccountRequest, opts ...grpc.CallOption) (*AuthenticateAccountResponse, error) {
+	out := new(AuthenticateAccountResponse)
+	err := c.cc.Invoke(ctx, "/BackrApi/AuthenticateAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrApiClient) ChangeAccountPassword(ctx context.Context, in *ChangeAccountPasswordRequest, opts ...grpc.CallOption) (*AccountResponse, error) {
+	out := new(AccountResponse)
+	err := c.cc.Invoke(ctx, "/BackrApi/ChangeAccountPassword", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrApiClient) GetAuthConfig(ctx context.Context, in *GetAuthConfigRequest, opts ...grpc.CallOption) (*AuthConfigResponse, error) {
+	out := new(AuthConfigResponse)
+	err := c.cc.Invoke(ctx, "/BackrApi/GetAuthConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrApiClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*AuditEventsListResponse, error) {
+	out := new(AuditEventsListResponse)
+	err := c.cc.Invoke(ctx, "/BackrApi/ListAuditEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrApiClient) ListNotifications(ctx context.Context, in *ListNotificationsRequest, opts ...grpc.CallOption) (*NotificationsListResponse, error) {
+	out := new(NotificationsListResponse)
+	err := c.cc.Invoke(ctx, "/BackrApi/ListNotifications", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrApiClient) GetMaintenance(ctx context.Context, in *GetMaintenanceRequest, opts ...grpc.CallOption) (*MaintenanceResponse, error) {
+	out := new(MaintenanceResponse)
+	err := c.cc.Invoke(ctx, "/BackrApi/GetMaintenance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrApiClient) SetMaintenance(ctx context.Context, in *SetMaintenanceRequest, opts ...grpc.CallOption) (*MaintenanceResponse, error) {
+	out := new(MaintenanceResponse)
+	err := c.cc.Invoke(ctx, "/BackrApi/SetMaintenance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backrApiClient) WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (BackrApi_WatchEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BackrApi_serviceDesc.Streams[2], "/BackrApi/WatchEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &backrApiWatchEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BackrApi_WatchEventsClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type backrApiWatchEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *backrApiWatchEventsClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// BackrApiServer is the server API for BackrApi service.
+type BackrApiServer interface {
 	// projects
-	GetProjects(ctx context.Context, in *GetProjectsRequest, opts ...grpc.CallOption) (*ProjectsListResponse, error)
-	GetProject(ctx context.Context, in *GetProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error)
-	CreateProject(ctx context.Context, in *CreateProjectRequest, opts ...grpc.CallOption) (*CreateProjectResponse, error)
+	GetProjects(context.Context, *GetProjectsRequest) (*ProjectsListResponse, error)
+	GetProject(context.Context, *GetProjectRequest) (*ProjectResponse, error)
+	CreateProject(context.Context, *CreateProjectRequest) (*CreateProjectResponse, error)
+	UpdateProject(context.Context, *UpdateProjectRequest) (*UpdateProjectResponse, error)
+	ApproveDeletionPlan(context.Context, *ApproveDeletionPlanRequest) (*ProjectResponse, error)
+	SetProjectStatus(context.Context, *SetProjectStatusRequest) (*ProjectResponse, error)
+	// rules
+	SimulateRules(context.Context, *SimulateRulesRequest) (*SimulateRulesResponse, error)
 	// files
-	GetFiles(ctx context.Context, in *GetFilesRequest, opts ...grpc.CallOption) (*GetFilesResponse, error)
-	GetFileURL(ctx context.Context, in *GetFileURLRequest, opts ...grpc.CallOption) (*GetFileURLResponse, error)
+	GetFiles(context.Context, *GetFilesRequest) (*GetFilesResponse, error)
+	GetFileURL(context.Context, *GetFileURLRequest) (*GetFileURLResponse, error)
+	DownloadFile(*DownloadFileRequest, BackrApi_DownloadFileServer) error
+	UploadFile(BackrApi_UploadFileServer) error
+	PinFile(context.Context, *PinFileRequest) (*PinFileResponse, error)
+	UnpinFile(context.Context, *UnpinFileRequest) (*UnpinFileResponse, error)
+	// trash
+	ListTrash(context.Context, *ListTrashRequest) (*ListTrashResponse, error)
+	RestoreFile(context.Context, *RestoreFileRequest) (*RestoreFileResponse, error)
+	PurgeTrash(context.Context, *PurgeTrashRequest) (*PurgeTrashResponse, error)
 	// account
-	CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*AccountResponse, error)
-	ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*AccountsListResponse, error)
-	AuthenticateAccount(ctx context.Context, in *AuthenticateAccountRequest, opts ...grpc.CallOption) (*AuthenticateAccountResponse, error)
-	ChangeAccountPassword(ctx context.Context, in *ChangeAccountPasswordRequest, opts ...grpc.CallOption) (*AccountResponse, error)
+	CreateAccount(context.Context, *CreateAccountRequest) (*AccountResponse, error)
+	ListAccounts(context.Context, *ListAccountsRequest) (*AccountsListResponse, error)
+	AuthenticateAccount(context.Context, *AuthenticateAccountRequest) (*AuthenticateAccountResponse, error)
+	ChangeAccountPassword(context.Context, *ChangeAccountPasswordRequest) (*AccountResponse, error)
+	GetAuthConfig(context.Context, *GetAuthConfigRequest) (*AuthConfigResponse, error)
+	// audit
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*AuditEventsListResponse, error)
+	// notifications
+	ListNotifications(context.Context, *ListNotificationsRequest) (*NotificationsListResponse, error)
+	// maintenance
+	GetMaintenance(context.Context, *GetMaintenanceRequest) (*MaintenanceResponse, error)
+	SetMaintenance(context.Context, *SetMaintenanceRequest) (*MaintenanceResponse, error)
+	// events
+	WatchEvents(*WatchEventsRequest, BackrApi_WatchEventsServer) error
 }
 
-type backrApiClient struct {
-	cc *grpc.ClientConn
+// UnimplementedBackrApiServer can be embedded to have forward compatible implementations.
+type UnimplementedBackrApiServer struct {
 }
 
-func NewBackrApiClient(cc *grpc.ClientConn) BackrApiClient {
-	return &backrApiClient{cc}
+func (*UnimplementedBackrApiServer) GetProjects(ctx context.Context, req *GetProjectsRequest) (*ProjectsListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProjects not implemented")
+}
+func (*UnimplementedBackrApiServer) GetProject(ctx context.Context, req *GetProjectRequest) (*ProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProject not implemented")
+}
+func (*UnimplementedBackrApiServer) CreateProject(ctx context.Context, req *CreateProjectRequest) (*CreateProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProject not implemented")
+}
+func (*UnimplementedBackrApiServer) UpdateProject(ctx context.Context, req *UpdateProjectRequest) (*UpdateProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProject not implemented")
+}
+func (*UnimplementedBackrApiServer) ApproveDeletionPlan(ctx context.Context, req *ApproveDeletionPlanRequest) (*ProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveDeletionPlan not implemented")
+}
+func (*UnimplementedBackrApiServer) SetProjectStatus(ctx context.Context, req *SetProjectStatusRequest) (*ProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetProjectStatus not implemented")
+}
+func (*UnimplementedBackrApiServer) SimulateRules(ctx context.Context, req *SimulateRulesRequest) (*SimulateRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SimulateRules not implemented")
+}
+func (*UnimplementedBackrApiServer) GetFiles(ctx context.Context, req *GetFilesRequest) (*GetFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFiles not implemented")
+}
+func (*UnimplementedBackrApiServer) GetFileURL(ctx context.Context, req *GetFileURLRequest) (*GetFileURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFileURL not implemented")
+}
+func (*UnimplementedBackrApiServer) DownloadFile(req *DownloadFileRequest, srv BackrApi_DownloadFileServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadFile not implemented")
+}
+func (*UnimplementedBackrApiServer) UploadFile(srv BackrApi_UploadFileServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadFile not implemented")
+}
+func (*UnimplementedBackrApiServer) PinFile(ctx context.Context, req *PinFileRequest) (*PinFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PinFile not implemented")
+}
+func (*UnimplementedBackrApiServer) UnpinFile(ctx context.Context, req *UnpinFileRequest) (*UnpinFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnpinFile not implemented")
+}
+func (*UnimplementedBackrApiServer) ListTrash(ctx context.Context, req *ListTrashRequest) (*ListTrashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrash not implemented")
+}
+func (*UnimplementedBackrApiServer) RestoreFile(ctx context.Context, req *RestoreFileRequest) (*RestoreFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreFile not implemented")
+}
+func (*UnimplementedBackrApiServer) PurgeTrash(ctx context.Context, req *PurgeTrashRequest) (*PurgeTrashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeTrash not implemented")
+}
+func (*UnimplementedBackrApiServer) CreateAccount(ctx context.Context, req *CreateAccountRequest) (*AccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAccount not implemented")
+}
+func (*UnimplementedBackrApiServer) ListAccounts(ctx context.Context, req *ListAccountsRequest) (*AccountsListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccounts not implemented")
+}
+func (*UnimplementedBackrApiServer) AuthenticateAccount(ctx context.Context, req *AuthenticateAccountRequest) (*AuthenticateAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthenticateAccount not implemented")
+}
+func (*UnimplementedBackrApiServer) ChangeAccountPassword(ctx context.Context, req *ChangeAccountPasswordRequest) (*AccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeAccountPassword not implemented")
+}
+func (*UnimplementedBackrApiServer) GetAuthConfig(ctx context.Context, req *GetAuthConfigRequest) (*AuthConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuthConfig not implemented")
+}
+func (*UnimplementedBackrApiServer) ListAuditEvents(ctx context.Context, req *ListAuditEventsRequest) (*AuditEventsListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (*UnimplementedBackrApiServer) ListNotifications(ctx context.Context, req *ListNotificationsRequest) (*NotificationsListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNotifications not implemented")
+}
+func (*UnimplementedBackrApiServer) GetMaintenance(ctx context.Context, req *GetMaintenanceRequest) (*MaintenanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMaintenance not implemented")
+}
+func (*UnimplementedBackrApiServer) SetMaintenance(ctx context.Context, req *SetMaintenanceRequest) (*MaintenanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMaintenance not implemented")
+}
+func (*UnimplementedBackrApiServer) WatchEvents(req *WatchEventsRequest, srv BackrApi_WatchEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvents not implemented")
+}
+
+func RegisterBackrApiServer(s *grpc.Server, srv BackrApiServer) {
+	s.RegisterService(&_BackrApi_serviceDesc, srv)
+}
+
+func _BackrApi_GetProjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrApiServer).GetProjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/BackrApi/GetProjects",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrApiServer).GetProjects(ctx, req.(*GetProjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackrApi_GetProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrApiServer).GetProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/BackrApi/GetProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrApiServer).GetProject(ctx, req.(*GetProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackrApi_CreateProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrApiServer).CreateProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/BackrApi/CreateProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrApiServer).CreateProject(ctx, req.(*CreateProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackrApi_UpdateProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrApiServer).UpdateProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/BackrApi/UpdateProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrApiServer).UpdateProject(ctx, req.(*UpdateProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackrApi_ApproveDeletionPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveDeletionPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrApiServer).ApproveDeletionPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/BackrApi/ApproveDeletionPlan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrApiServer).ApproveDeletionPlan(ctx, req.(*ApproveDeletionPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *backrApiClient) GetProjects(ctx context.Context, in *GetProjectsRequest, opts ...grpc.CallOption) (*ProjectsListResponse, error) {
-	out := new(ProjectsListResponse)
-	err := c.cc.Invoke(ctx, "/BackrApi/GetProjects", in, out, opts...)
-	if err != nil {
+func _BackrApi_SetProjectStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetProjectStatusRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(BackrApiServer).SetProjectStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/BackrApi/SetProjectStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrApiServer).SetProjectStatus(ctx, req.(*SetProjectStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *backrApiClient) GetProject(ctx context.Context, in *GetProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error) {
-	out := new(ProjectResponse)
-	err := c.cc.Invoke(ctx, "/BackrApi/GetProject", in, out, opts...)
-	if err != nil {
+func _BackrApi_SimulateRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulateRulesRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(BackrApiServer).SimulateRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/BackrApi/SimulateRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrApiServer).SimulateRules(ctx, req.(*SimulateRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *backrApiClient) CreateProject(ctx context.Context, in *CreateProjectRequest, opts ...grpc.CallOption) (*CreateProjectResponse, error) {
-	out := new(CreateProjectResponse)
-	err := c.cc.Invoke(ctx, "/BackrApi/CreateProject", in, out, opts...)
-	if err != nil {
+func _BackrApi_GetFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFilesRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(BackrApiServer).GetFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/BackrApi/GetFiles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrApiServer).GetFiles(ctx, req.(*GetFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *backrApiClient) GetFiles(ctx context.Context, in *GetFilesRequest, opts ...grpc.CallOption) (*GetFilesResponse, error) {
-	out := new(GetFilesResponse)
-	err := c.cc.Invoke(ctx, "/BackrApi/GetFiles", in, out, opts...)
-	if err != nil {
+func _BackrApi_GetFileURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFileURLRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(BackrApiServer).GetFileURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/BackrApi/GetFileURL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrApiServer).GetFileURL(ctx, req.(*GetFileURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *backrApiClient) GetFileURL(ctx context.Context, in *GetFileURLRequest, opts ...grpc.CallOption) (*GetFileURLResponse, error) {
-	out := new(GetFileURLResponse)
-	err := c.cc.Invoke(ctx, "/BackrApi/GetFileURL", in, out, opts...)
-	if err != nil {
-		return nil, err
+func _BackrApi_DownloadFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadFileRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return out, nil
+	return srv.(BackrApiServer).DownloadFile(m, &backrApiDownloadFileServer{stream})
 }
 
-func (c *backrApiClient) CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*AccountResponse, error) {
-	out := new(AccountResponse)
-	err := c.cc.Invoke(ctx, "/BackrApi/CreateAccount", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type BackrApi_DownloadFileServer interface {
+	Send(*FileChunk) error
+	grpc.ServerStream
 }
 
-func (c *backrApiClient) ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*AccountsListResponse, error) {
-	out := new(AccountsListResponse)
-	err := c.cc.Invoke(ctx, "/BackrApi/ListAccounts", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type backrApiDownloadFileServer struct {
+	grpc.ServerStream
 }
 
-func (c *backrApiClient) AuthenticateAccount(ctx context.Context, in *AuthenticateAccountRequest, opts ...grpc.CallOption) (*AuthenticateAccountResponse, error) {
-	out := new(AuthenticateAccountResponse)
-	err := c.cc.Invoke(ctx, "/BackrApi/AuthenticateAccount", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (x *backrApiDownloadFileServer) Send(m *FileChunk) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (c *backrApiClient) ChangeAccountPassword(ctx context.Context, in *ChangeAccountPasswordRequest, opts ...grpc.CallOption) (*AccountResponse, error) {
-	out := new(AccountResponse)
-	err := c.cc.Invoke(ctx, "/BackrApi/ChangeAccountPassword", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func _BackrApi_UploadFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BackrApiServer).UploadFile(&backrApiUploadFileServer{stream})
 }
 
-// BackrApiServer is the server API for BackrApi service.
-type BackrApiServer interface {
-	// projects
-	GetProjects(context.Context, *GetProjectsRequest) (*ProjectsListResponse, error)
-	GetProject(context.Context, *GetProjectRequest) (*ProjectResponse, error)
-	CreateProject(context.Context, *CreateProjectRequest) (*CreateProjectResponse, error)
-	// files
-	GetFiles(context.Context, *GetFilesRequest) (*GetFilesResponse, error)
-	GetFileURL(context.Context, *GetFileURLRequest) (*GetFileURLResponse, error)
-	// account
-	CreateAccount(context.Context, *CreateAccountRequest) (*AccountResponse, error)
-	ListAccounts(context.Context, *ListAccountsRequest) (*AccountsListResponse, error)
-	AuthenticateAccount(context.Context, *AuthenticateAccountRequest) (*AuthenticateAccountResponse, error)
-	ChangeAccountPassword(context.Context, *ChangeAccountPasswordRequest) (*AccountResponse, error)
+type BackrApi_UploadFileServer interface {
+	SendAndClose(*UploadFileResponse) error
+	Recv() (*UploadFileRequest, error)
+	grpc.ServerStream
 }
 
-func RegisterBackrApiServer(s *grpc.Server, srv BackrApiServer) {
-	s.RegisterService(&_BackrApi_serviceDesc, srv)
+type backrApiUploadFileServer struct {
+	grpc.ServerStream
 }
 
-func _BackrApi_GetProjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetProjectsRequest)
+func (x *backrApiUploadFileServer) SendAndClose(m *UploadFileResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *backrApiUploadFileServer) Recv() (*UploadFileRequest, error) {
+	m := new(UploadFileRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _BackrApi_PinFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinFileRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackrApiServer).GetProjects(ctx, in)
+		return srv.(BackrApiServer).PinFile(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/BackrApi/GetProjects",
+		FullMethod: "/BackrApi/PinFile",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackrApiServer).GetProjects(ctx, req.(*GetProjectsRequest))
+		return srv.(BackrApiServer).PinFile(ctx, req.(*PinFileRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BackrApi_GetProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetProjectRequest)
+func _BackrApi_UnpinFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnpinFileRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackrApiServer).GetProject(ctx, in)
+		return srv.(BackrApiServer).UnpinFile(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/BackrApi/GetProject",
+		FullMethod: "/BackrApi/UnpinFile",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackrApiServer).GetProject(ctx, req.(*GetProjectRequest))
+		return srv.(BackrApiServer).UnpinFile(ctx, req.(*UnpinFileRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BackrApi_CreateProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateProjectRequest)
+func _BackrApi_ListTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrashRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackrApiServer).CreateProject(ctx, in)
+		return srv.(BackrApiServer).ListTrash(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/BackrApi/CreateProject",
+		FullMethod: "/BackrApi/ListTrash",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackrApiServer).CreateProject(ctx, req.(*CreateProjectRequest))
+		return srv.(BackrApiServer).ListTrash(ctx, req.(*ListTrashRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BackrApi_GetFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetFilesRequest)
+func _BackrApi_RestoreFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreFileRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackrApiServer).GetFiles(ctx, in)
+		return srv.(BackrApiServer).RestoreFile(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/BackrApi/GetFiles",
+		FullMethod: "/BackrApi/RestoreFile",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackrApiServer).GetFiles(ctx, req.(*GetFilesRequest))
+		return srv.(BackrApiServer).RestoreFile(ctx, req.(*RestoreFileRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BackrApi_GetFileURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetFileURLRequest)
+func _BackrApi_PurgeTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeTrashRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BackrApiServer).GetFileURL(ctx, in)
+		return srv.(BackrApiServer).PurgeTrash(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/BackrApi/GetFileURL",
+		FullMethod: "/BackrApi/PurgeTrash",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BackrApiServer).GetFileURL(ctx, req.(*GetFileURLRequest))
+		return srv.(BackrApiServer).PurgeTrash(ctx, req.(*PurgeTrashRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1455,6 +4904,117 @@ func _BackrApi_ChangeAccountPassword_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BackrApi_GetAuthConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAuthConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrApiServer).GetAuthConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/BackrApi/GetAuthConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrApiServer).GetAuthConfig(ctx, req.(*GetAuthConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackrApi_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrApiServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/BackrApi/ListAuditEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrApiServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackrApi_ListNotifications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNotificationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrApiServer).ListNotifications(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/BackrApi/ListNotifications",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrApiServer).ListNotifications(ctx, req.(*ListNotificationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackrApi_GetMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMaintenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrApiServer).GetMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/BackrApi/GetMaintenance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrApiServer).GetMaintenance(ctx, req.(*GetMaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackrApi_SetMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMaintenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackrApiServer).SetMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/BackrApi/SetMaintenance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackrApiServer).SetMaintenance(ctx, req.(*SetMaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackrApi_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BackrApiServer).WatchEvents(m, &backrApiWatchEventsServer{stream})
+}
+
+type BackrApi_WatchEventsServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type backrApiWatchEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *backrApiWatchEventsServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _BackrApi_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "BackrApi",
 	HandlerType: (*BackrApiServer)(nil),
@@ -1471,6 +5031,22 @@ var _BackrApi_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CreateProject",
 			Handler:    _BackrApi_CreateProject_Handler,
 		},
+		{
+			MethodName: "UpdateProject",
+			Handler:    _BackrApi_UpdateProject_Handler,
+		},
+		{
+			MethodName: "ApproveDeletionPlan",
+			Handler:    _BackrApi_ApproveDeletionPlan_Handler,
+		},
+		{
+			MethodName: "SetProjectStatus",
+			Handler:    _BackrApi_SetProjectStatus_Handler,
+		},
+		{
+			MethodName: "SimulateRules",
+			Handler:    _BackrApi_SimulateRules_Handler,
+		},
 		{
 			MethodName: "GetFiles",
 			Handler:    _BackrApi_GetFiles_Handler,
@@ -1479,6 +5055,26 @@ var _BackrApi_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetFileURL",
 			Handler:    _BackrApi_GetFileURL_Handler,
 		},
+		{
+			MethodName: "PinFile",
+			Handler:    _BackrApi_PinFile_Handler,
+		},
+		{
+			MethodName: "UnpinFile",
+			Handler:    _BackrApi_UnpinFile_Handler,
+		},
+		{
+			MethodName: "ListTrash",
+			Handler:    _BackrApi_ListTrash_Handler,
+		},
+		{
+			MethodName: "RestoreFile",
+			Handler:    _BackrApi_RestoreFile_Handler,
+		},
+		{
+			MethodName: "PurgeTrash",
+			Handler:    _BackrApi_PurgeTrash_Handler,
+		},
 		{
 			MethodName: "CreateAccount",
 			Handler:    _BackrApi_CreateAccount_Handler,
@@ -1495,7 +5091,43 @@ var _BackrApi_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ChangeAccountPassword",
 			Handler:    _BackrApi_ChangeAccountPassword_Handler,
 		},
+		{
+			MethodName: "GetAuthConfig",
+			Handler:    _BackrApi_GetAuthConfig_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _BackrApi_ListAuditEvents_Handler,
+		},
+		{
+			MethodName: "ListNotifications",
+			Handler:    _BackrApi_ListNotifications_Handler,
+		},
+		{
+			MethodName: "GetMaintenance",
+			Handler:    _BackrApi_GetMaintenance_Handler,
+		},
+		{
+			MethodName: "SetMaintenance",
+			Handler:    _BackrApi_SetMaintenance_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DownloadFile",
+			Handler:       _BackrApi_DownloadFile_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UploadFile",
+			Handler:       _BackrApi_UploadFile_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _BackrApi_WatchEvents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api.proto",
 }
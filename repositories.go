@@ -1,13 +1,25 @@
 package manager
 
-import "net/url"
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
 
 // ProjectRepository defines methods required
 // to work with projects
 type ProjectRepository interface {
+	// GetAll returns the projects. If some records are corrupted, the valid projects are returned
+	// along with a RepositoryError of kind ErrCorruptedRecord, listing the keys of the corrupted records.
 	GetAll() ([]Project, error)
+	// GetByName returns the project, or nil if it does not exist
 	GetByName(name string) (*Project, error)
 	Save(project Project) error
+	// Update applies fn to the stored project and saves the result atomically, so the changes made
+	// concurrently to the other fields are not lost. A RepositoryError of kind ErrNotFound is returned
+	// if the project does not exist, and the error of fn is returned as is (nothing is saved).
+	Update(name string, fn func(project *Project) error) error
 }
 
 // FileRepository abstracts interactions
@@ -18,7 +30,18 @@ type FileRepository interface {
 	GetFolderForFile(File) (string, error)
 	GetFilenameForFile(File) (string, error)
 	RemoveFile(File) error
-	GetURL(File) (*url.URL, error)
+	GetURL(File, URLOptions) (*url.URL, error)
+	// Stat returns the file stored at the path of the file, or nil if it does not exist
+	Stat(File) (*File, error)
+	// Open returns a reader of the content of the file, and the size of the file.
+	// The reader must be closed.
+	Open(File) (io.ReadCloser, int64, error)
+	// PutFile stores the content of the file, reading size bytes (or until EOF if size is -1)
+	PutFile(file File, content io.Reader, size int64) error
+	// CopyFile copies the content and the metadata of src to the path of dst, without downloading it
+	CopyFile(src File, dst File) error
+	// Metadata returns the metadata headers of the file (i.e. X-Amz-Meta-Backup-Date for S3)
+	Metadata(File) (http.Header, error)
 }
 
 // AccountRepository abstracts interactions with
@@ -27,8 +50,60 @@ type AccountRepository interface {
 	List() ([]Account, error)
 	Get(username string) (*Account, error)
 	// Create must return an automatically generated password for the created user
-	Create(username string) (string, error)
+	Create(username string, role Role, projects []string) (string, error)
 	Delete(username string) error
 	ChangePassword(username string) (string, error)
 	Authenticate(username, password string) error
 }
+
+// AuditRepository stores the audit trail.
+// It is append-only: events cannot be updated or deleted.
+type AuditRepository interface {
+	Append(event AuditEvent) error
+	// List returns the events matching the filter, from the most recent to the oldest
+	List(filter AuditFilter) ([]AuditEvent, error)
+}
+
+// NotificationRepository stores the history of sent notifications
+type NotificationRepository interface {
+	Append(record NotificationRecord) error
+	// List returns the most recent notifications first, limit <= 0 means no limit
+	List(limit int) ([]NotificationRecord, error)
+}
+
+// URLOptions customizes the URL returned by FileRepository.GetURL
+type URLOptions struct {
+	// Expiry is the lifetime of the URL (DefaultURLExpiry if zero)
+	Expiry time.Duration
+	// Filename forces the browser to download the file, saved with this name
+	Filename string
+}
+
+// DefaultURLExpiry is the lifetime of the URLs, when not specified
+const DefaultURLExpiry = 15 * time.Minute
+
+// TrashRepository stores the files moved to the trash, indexed by path
+type TrashRepository interface {
+	Add(file TrashedFile) error
+	// Get returns the trashed file, or nil if the file is not in the trash
+	Get(path string) (*TrashedFile, error)
+	List() ([]TrashedFile, error)
+	Remove(path string) error
+}
+
+// MaintenanceRepository stores the maintenance mode of the daemon
+type MaintenanceRepository interface {
+	// Get returns the maintenance mode, or nil if it is not enabled
+	Get() (*Maintenance, error)
+	// Set enables the maintenance mode, or disables it if m is nil
+	Set(m *Maintenance) error
+}
+
+// DownloadTokenRepository stores the tokens of the one-time download URLs
+type DownloadTokenRepository interface {
+	// Create stores the token, and removes the expired ones
+	Create(token string, info DownloadToken) error
+	// Claim removes the token and returns its info, so it can be used only once.
+	// It returns nil if the token does not exist.
+	Claim(token string) (*DownloadToken, error)
+}
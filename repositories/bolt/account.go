@@ -1,8 +1,6 @@
 package bolt
 
 import (
-	"bytes"
-	"encoding/gob"
 	"fmt"
 
 	"github.com/agence-webup/backr/manager"
@@ -26,30 +24,27 @@ type accountRepository struct {
 
 func (repo *accountRepository) List() ([]manager.Account, error) {
 	accounts := []manager.Account{}
-	repo.db.View(func(tx *bolt.Tx) error {
+	err := repo.db.View(func(tx *bolt.Tx) error {
 		b := tx.Bucket(accountBucket)
 		if b == nil {
 			return nil
 		}
 
-		err := b.ForEach(func(key, value []byte) error {
-			var account manager.Account
-			buf := bytes.NewBuffer(value)
-			err := gob.NewDecoder(buf).Decode(&account)
+		return b.ForEach(func(key, value []byte) error {
+			var document accountDocument
+			err := decodeDocument(value, &document)
 			if err != nil {
-				return fmt.Errorf("unable to deserialize gob data: %v", err)
+				return manager.NewCorruptedRecordError("list accounts", err, string(key))
 			}
 
-			accounts = append(accounts, account)
+			accounts = append(accounts, document.toAccount())
 
 			return nil
 		})
-		if err != nil {
-			return fmt.Errorf("unable to fetch bucket: %v", err)
-		}
-
-		return nil
 	})
+	if err != nil {
+		return nil, manager.NewStorageError("list accounts", err)
+	}
 
 	return accounts, nil
 }
@@ -63,23 +58,29 @@ func (repo *accountRepository) Get(username string) (*manager.Account, error) {
 		}
 
 		value := b.Get([]byte(username))
+		if value == nil {
+			return nil
+		}
 
-		var acc manager.Account
-		buf := bytes.NewBuffer(value)
-		err := gob.NewDecoder(buf).Decode(&acc)
+		var document accountDocument
+		err := decodeDocument(value, &document)
 		if err != nil {
-			return fmt.Errorf("unable to deserialize gob data: %v", err)
+			return manager.NewCorruptedRecordError(fmt.Sprintf("get account '%v'", username), err, username)
 		}
 
+		acc := document.toAccount()
 		account = &acc
 
 		return nil
 	})
+	if err != nil {
+		return nil, manager.NewStorageError(fmt.Sprintf("get account '%v'", username), err)
+	}
 
-	return account, err
+	return account, nil
 }
 
-func (repo *accountRepository) Create(username string) (string, error) {
+func (repo *accountRepository) Create(username string, role manager.Role, projects []string) (string, error) {
 
 	if username == "" {
 		return "", fmt.Errorf("username cannot be empty")
@@ -93,9 +94,11 @@ func (repo *accountRepository) Create(username string) (string, error) {
 	account := manager.Account{
 		Username:       username,
 		HashedPassword: pwd.Hashed,
+		Role:           role,
+		Projects:       projects,
 	}
 
-	repo.db.Update(func(tx *bolt.Tx) error {
+	err = repo.db.Update(func(tx *bolt.Tx) error {
 		// get or create the bucket
 		b, err := tx.CreateBucketIfNotExists(accountBucket)
 		if err != nil {
@@ -103,20 +106,22 @@ func (repo *accountRepository) Create(username string) (string, error) {
 		}
 
 		// serialize account
-		buf := bytes.Buffer{}
-		err = gob.NewEncoder(&buf).Encode(account)
+		data, err := encodeDocument(newAccountDocument(account))
 		if err != nil {
-			return fmt.Errorf("unable to serialize gob data: %v", err)
+			return err
 		}
 
 		// put it into the bucket
-		err = b.Put([]byte(username), buf.Bytes())
+		err = b.Put([]byte(username), data)
 		if err != nil {
 			return fmt.Errorf("unable to put data in bucket: %v", err)
 		}
 
 		return nil
 	})
+	if err != nil {
+		return "", manager.NewStorageError(fmt.Sprintf("create account '%v'", username), err)
+	}
 
 	return pwd.Plain, nil
 }
@@ -127,7 +132,7 @@ func (repo *accountRepository) Delete(username string) error {
 		return fmt.Errorf("username cannot be empty")
 	}
 
-	return repo.db.Update(func(tx *bolt.Tx) error {
+	err := repo.db.Update(func(tx *bolt.Tx) error {
 		b := tx.Bucket(accountBucket)
 		if b == nil {
 			return nil
@@ -139,6 +144,8 @@ func (repo *accountRepository) Delete(username string) error {
 		}
 		return nil
 	})
+
+	return manager.NewStorageError(fmt.Sprintf("delete account '%v'", username), err)
 }
 
 func (repo *accountRepository) Authenticate(username string, password string) error {
@@ -146,18 +153,20 @@ func (repo *accountRepository) Authenticate(username string, password string) er
 	return repo.db.View(func(tx *bolt.Tx) error {
 		b := tx.Bucket(accountBucket)
 		if b == nil {
+			bcrypt.SimulateComparison(password)
 			return fmt.Errorf("wrong credentials: bucket does not exist")
 		}
 
 		value := b.Get([]byte(username))
 		if value == nil {
+			// spend the same time as for an existing account
+			bcrypt.SimulateComparison(password)
 			return fmt.Errorf("wrong credentials: username does not exist")
 		}
 
 		// unserialize account
-		var account manager.Account
-		buf := bytes.NewBuffer(value)
-		err := gob.NewDecoder(buf).Decode(&account)
+		var account accountDocument
+		err := decodeDocument(value, &account)
 		if err != nil {
 			return fmt.Errorf("wrong credentials: %v", err)
 		}
@@ -175,10 +184,10 @@ func (repo *accountRepository) ChangePassword(username string) (string, error) {
 
 	account, err := repo.Get(username)
 	if err != nil {
-		return "", fmt.Errorf("unable to get account: %v", err)
+		return "", err
 	}
 	if account == nil {
-		return "", fmt.Errorf("account not found")
+		return "", manager.NewNotFoundError("change password", username)
 	}
 
 	pwd, err := bcrypt.GeneratePassword()
@@ -195,15 +204,14 @@ func (repo *accountRepository) ChangePassword(username string) (string, error) {
 			return fmt.Errorf("unable to get bucket")
 		}
 
-		// serialize project
-		buf := bytes.Buffer{}
-		err = gob.NewEncoder(&buf).Encode(account)
+		// serialize account
+		data, err := encodeDocument(newAccountDocument(*account))
 		if err != nil {
-			return fmt.Errorf("unable to serialize gob data: %v", err)
+			return err
 		}
 
 		// put it into the bucket
-		err = b.Put([]byte(username), buf.Bytes())
+		err = b.Put([]byte(username), data)
 		if err != nil {
 			return fmt.Errorf("unable to put data in bucket: %v", err)
 		}
@@ -211,7 +219,7 @@ func (repo *accountRepository) ChangePassword(username string) (string, error) {
 		return nil
 	})
 	if err != nil {
-		return "", err
+		return "", manager.NewStorageError(fmt.Sprintf("change password of account '%v'", username), err)
 	}
 
 	return pwd.Plain, nil
@@ -0,0 +1,105 @@
+package bolt
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/agence-webup/backr/manager"
+	bolt "go.etcd.io/bbolt"
+)
+
+var auditBucket = []byte("audit")
+
+// NewAuditRepository returns an append-only AuditRepository backed by a Bolt database.
+// Events are keyed by a sequence, so they are stored in chronological order.
+func NewAuditRepository(db *bolt.DB) manager.AuditRepository {
+	return &auditRepository{
+		db: db,
+	}
+}
+
+type auditRepository struct {
+	db *bolt.DB
+}
+
+func (repo *auditRepository) Append(event manager.AuditEvent) error {
+	err := repo.db.Update(func(tx *bolt.Tx) error {
+		// get or create the bucket
+		b, err := tx.CreateBucketIfNotExists(auditBucket)
+		if err != nil {
+			return fmt.Errorf("unable to create bolt bucket: %v", err)
+		}
+
+		id, err := b.NextSequence()
+		if err != nil {
+			return fmt.Errorf("unable to get next sequence: %v", err)
+		}
+		event.ID = id
+
+		// serialize event
+		data, err := encodeDocument(newAuditEventDocument(event))
+		if err != nil {
+			return err
+		}
+
+		// put it into the bucket
+		err = b.Put(sequenceKey(id), data)
+		if err != nil {
+			return fmt.Errorf("unable to put data in bucket: %v", err)
+		}
+
+		return nil
+	})
+
+	return manager.NewStorageError("append audit event", err)
+}
+
+func (repo *auditRepository) List(filter manager.AuditFilter) ([]manager.AuditEvent, error) {
+	events := []manager.AuditEvent{}
+
+	err := repo.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(auditBucket)
+		if b == nil {
+			return nil
+		}
+
+		// walk from the most recent event to the oldest
+		c := b.Cursor()
+		for key, value := c.Last(); key != nil; key, value = c.Prev() {
+			var document auditEventDocument
+			err := decodeDocument(value, &document)
+			if err != nil {
+				return manager.NewCorruptedRecordError("list audit events", err, fmt.Sprint(binary.BigEndian.Uint64(key)))
+			}
+			event := document.toAuditEvent()
+
+			if !filter.Since.IsZero() && event.Date.Before(filter.Since) {
+				break
+			}
+			if filter.Actor != "" && event.Actor != filter.Actor {
+				continue
+			}
+
+			events = append(events, event)
+
+			if filter.Limit > 0 && len(events) >= filter.Limit {
+				break
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, manager.NewStorageError("list audit events", err)
+	}
+
+	return events, nil
+}
+
+// sequenceKey encodes a sequence number as a key, preserving the order of the keys
+func sequenceKey(id uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, id)
+	return key
+}
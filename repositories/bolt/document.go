@@ -0,0 +1,594 @@
+package bolt
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+)
+
+// The records are stored as JSON documents, decoupled from the layout of the manager types:
+// the DB can be inspected with any tool, and a manager type can evolve without breaking the stored records.
+// A change of the format of a document requires a migration (see migration.go).
+
+type projectDocument struct {
+	Name           string                       `json:"name"`
+	Rules          []ruleDocument               `json:"rules"`
+	State          map[string]ruleStateDocument `json:"state,omitempty"`
+	CreatedAt      time.Time                    `json:"created_at"`
+	DeletionPlan   *deletionPlanDocument        `json:"deletion_plan,omitempty"`
+	Pins           []pinDocument                `json:"pins,omitempty"`
+	Pause          *pauseDocument               `json:"pause,omitempty"`
+	UploadInterval int64                        `json:"upload_interval_seconds,omitempty"`
+	Caps           *capsDocument                `json:"caps,omitempty"`
+	CapConflicts   []string                     `json:"cap_conflicts,omitempty"`
+	DateSource     *dateSourceDocument          `json:"date_source,omitempty"`
+}
+
+type dateSourceDocument struct {
+	Type    string `json:"type"`
+	Pattern string `json:"pattern,omitempty"`
+	Header  string `json:"header,omitempty"`
+	Layout  string `json:"layout,omitempty"`
+}
+
+type capsDocument struct {
+	MaxFiles   int   `json:"max_files,omitempty"`
+	MaxBytes   int64 `json:"max_bytes,omitempty"`
+	MaxAge     int64 `json:"max_age_seconds,omitempty"`
+	KeepNewest int   `json:"keep_newest,omitempty"`
+}
+
+type pauseDocument struct {
+	Status   string    `json:"status"`
+	Reason   string    `json:"reason"`
+	Author   string    `json:"author"`
+	PausedAt time.Time `json:"paused_at"`
+	ResumeAt time.Time `json:"resume_at"`
+}
+
+type pinDocument struct {
+	Path      string    `json:"path"`
+	Reason    string    `json:"reason"`
+	Author    string    `json:"author"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type deletionPlanDocument struct {
+	ID         string         `json:"id"`
+	Files      []fileDocument `json:"files"`
+	Reasons    []string       `json:"reasons"`
+	CreatedAt  time.Time      `json:"created_at"`
+	ApprovedBy string         `json:"approved_by,omitempty"`
+	ApprovedAt time.Time      `json:"approved_at"`
+}
+
+type maintenanceDocument struct {
+	Reason    string    `json:"reason"`
+	Author    string    `json:"author"`
+	StartedAt time.Time `json:"started_at"`
+	Until     time.Time `json:"until"`
+}
+
+// the min age was stored as a count of days (min_age): it is kept for the whole days,
+// min_age_seconds takes precedence when it's set
+type ruleDocument struct {
+	Count            int   `json:"count"`
+	MinAge           int   `json:"min_age"`
+	MinAgeSeconds    int64 `json:"min_age_seconds,omitempty"`
+	ToleranceSeconds int64 `json:"tolerance_seconds,omitempty"`
+}
+
+type ruleStateDocument struct {
+	Rule  ruleDocument            `json:"rule"`
+	Files []selectedFileDocument  `json:"files"`
+	Next  *time.Time              `json:"next,omitempty"`
+	Error *ruleStateErrorDocument `json:"error,omitempty"`
+}
+
+type fileDocument struct {
+	Path string    `json:"path"`
+	Date time.Time `json:"date"`
+	Size int64     `json:"size"`
+}
+
+type selectedFileDocument struct {
+	fileDocument
+	Expiration time.Time               `json:"expiration"`
+	Error      *ruleStateErrorDocument `json:"error,omitempty"`
+}
+
+type ruleStateErrorDocument struct {
+	File   *fileDocument `json:"file,omitempty"`
+	Reason string        `json:"reason"`
+}
+
+type accountDocument struct {
+	Username       string   `json:"username"`
+	HashedPassword string   `json:"hashed_password"`
+	Role           string   `json:"role,omitempty"`
+	Projects       []string `json:"projects,omitempty"`
+}
+
+type notificationDocument struct {
+	ID          uint64    `json:"id"`
+	ProjectName string    `json:"project_name"`
+	Level       string    `json:"level"`
+	Count       int       `json:"count"`
+	Reasons     []string  `json:"reasons"`
+	SentAt      time.Time `json:"sent_at"`
+}
+
+type auditEventDocument struct {
+	ID      uint64    `json:"id"`
+	Date    time.Time `json:"date"`
+	Actor   string    `json:"actor"`
+	Action  string    `json:"action"`
+	Target  string    `json:"target,omitempty"`
+	IP      string    `json:"ip,omitempty"`
+	Success bool      `json:"success"`
+	Message string    `json:"message,omitempty"`
+}
+
+type downloadTokenDocument struct {
+	FilePath  string    `json:"file_path"`
+	Filename  string    `json:"filename,omitempty"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type trashedFileDocument struct {
+	File      fileDocument `json:"file"`
+	TrashPath string       `json:"trash_path,omitempty"`
+	TrashedAt time.Time    `json:"trashed_at"`
+	PurgeAt   time.Time    `json:"purge_at"`
+}
+
+// the reasons of the rule errors are stored by name, the values of the constants may change
+var ruleStateErrorReasons = map[manager.RuleStateErrorType]string{
+	manager.RuleStateErrorObsolete:        "obsolete",
+	manager.RuleStateErrorSizeTooSmall:    "size_too_small",
+	manager.RuleStateErrorNoFile:          "no_file",
+	manager.RuleStateErrorDeletionBlocked: "deletion_blocked",
+	manager.RuleStateErrorCapConflict:     "cap_conflict",
+}
+
+var alertLevels = map[manager.AlertLevel]string{
+	manager.Warning: "warning",
+	manager.Critic:  "critic",
+}
+
+func encodeDocument(document interface{}) ([]byte, error) {
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("unable to serialize json data: %v", err)
+	}
+	return data, nil
+}
+
+func decodeDocument(data []byte, document interface{}) error {
+	err := json.Unmarshal(data, document)
+	if err != nil {
+		return fmt.Errorf("unable to deserialize json data: %v", err)
+	}
+	return nil
+}
+
+func newProjectDocument(project manager.Project) projectDocument {
+	d := projectDocument{
+		Name:           project.Name,
+		Rules:          []ruleDocument{},
+		CreatedAt:      project.CreatedAt,
+		UploadInterval: int64(project.UploadInterval / time.Second),
+	}
+	for _, r := range project.Rules {
+		d.Rules = append(d.Rules, newRuleDocument(r))
+	}
+	if len(project.State) > 0 {
+		d.State = map[string]ruleStateDocument{}
+		for id, rs := range project.State {
+			d.State[string(id)] = newRuleStateDocument(rs)
+		}
+	}
+	if project.DeletionPlan != nil {
+		plan := newDeletionPlanDocument(*project.DeletionPlan)
+		d.DeletionPlan = &plan
+	}
+	for _, pin := range project.Pins {
+		d.Pins = append(d.Pins, newPinDocument(pin))
+	}
+	if project.Pause != nil {
+		pause := newPauseDocument(*project.Pause)
+		d.Pause = &pause
+	}
+	if project.Caps.IsSet() {
+		d.Caps = &capsDocument{
+			MaxFiles:   project.Caps.MaxFiles,
+			MaxBytes:   project.Caps.MaxBytes,
+			MaxAge:     int64(project.Caps.MaxAge / time.Second),
+			KeepNewest: project.Caps.KeepNewest,
+		}
+	}
+	d.CapConflicts = project.CapConflicts
+	if !project.DateSource.IsLastModified() {
+		d.DateSource = &dateSourceDocument{
+			Type:    string(project.DateSource.Type),
+			Pattern: project.DateSource.Pattern,
+			Header:  project.DateSource.Header,
+			Layout:  project.DateSource.Layout,
+		}
+	}
+	return d
+}
+
+func (d projectDocument) toProject() (manager.Project, error) {
+	project := manager.Project{
+		Name:           d.Name,
+		Rules:          []manager.Rule{},
+		CreatedAt:      d.CreatedAt,
+		UploadInterval: time.Duration(d.UploadInterval) * time.Second,
+	}
+	for _, r := range d.Rules {
+		project.Rules = append(project.Rules, r.toRule())
+	}
+	if d.State != nil {
+		project.State = manager.ProjectState{}
+		for id, rsd := range d.State {
+			rs, err := rsd.toRuleState()
+			if err != nil {
+				return project, fmt.Errorf("invalid state for rule '%v': %v", id, err)
+			}
+			project.State[manager.RuleID(id)] = rs
+		}
+	}
+	if d.DeletionPlan != nil {
+		plan := d.DeletionPlan.toDeletionPlan()
+		project.DeletionPlan = &plan
+	}
+	for _, pin := range d.Pins {
+		project.Pins = append(project.Pins, pin.toPin())
+	}
+	if d.Pause != nil {
+		pause, err := d.Pause.toPause()
+		if err != nil {
+			return project, err
+		}
+		project.Pause = &pause
+	}
+	if d.Caps != nil {
+		project.Caps = manager.Caps{
+			MaxFiles:   d.Caps.MaxFiles,
+			MaxBytes:   d.Caps.MaxBytes,
+			MaxAge:     time.Duration(d.Caps.MaxAge) * time.Second,
+			KeepNewest: d.Caps.KeepNewest,
+		}
+	}
+	project.CapConflicts = d.CapConflicts
+	if d.DateSource != nil {
+		project.DateSource = manager.DateSource{
+			Type:    manager.DateSourceType(d.DateSource.Type),
+			Pattern: d.DateSource.Pattern,
+			Header:  d.DateSource.Header,
+			Layout:  d.DateSource.Layout,
+		}
+		err := project.DateSource.Validate()
+		if err != nil {
+			return project, err
+		}
+	}
+	return project, nil
+}
+
+func newDeletionPlanDocument(plan manager.DeletionPlan) deletionPlanDocument {
+	d := deletionPlanDocument{
+		ID:         plan.ID,
+		Files:      []fileDocument{},
+		Reasons:    plan.Reasons,
+		CreatedAt:  plan.CreatedAt,
+		ApprovedBy: plan.ApprovedBy,
+		ApprovedAt: plan.ApprovedAt,
+	}
+	for _, f := range plan.Files {
+		d.Files = append(d.Files, newFileDocument(f))
+	}
+	return d
+}
+
+func (d deletionPlanDocument) toDeletionPlan() manager.DeletionPlan {
+	plan := manager.DeletionPlan{
+		ID:         d.ID,
+		Files:      []manager.File{},
+		Reasons:    d.Reasons,
+		CreatedAt:  d.CreatedAt,
+		ApprovedBy: d.ApprovedBy,
+		ApprovedAt: d.ApprovedAt,
+	}
+	for _, f := range d.Files {
+		plan.Files = append(plan.Files, f.toFile())
+	}
+	return plan
+}
+
+func newPinDocument(pin manager.Pin) pinDocument {
+	return pinDocument{
+		Path:      pin.Path,
+		Reason:    pin.Reason,
+		Author:    pin.Author,
+		CreatedAt: pin.CreatedAt,
+		ExpiresAt: pin.ExpiresAt,
+	}
+}
+
+func (d pinDocument) toPin() manager.Pin {
+	return manager.Pin{
+		Path:      d.Path,
+		Reason:    d.Reason,
+		Author:    d.Author,
+		CreatedAt: d.CreatedAt,
+		ExpiresAt: d.ExpiresAt,
+	}
+}
+
+func newPauseDocument(pause manager.Pause) pauseDocument {
+	return pauseDocument{
+		Status:   string(pause.Status),
+		Reason:   pause.Reason,
+		Author:   pause.Author,
+		PausedAt: pause.PausedAt,
+		ResumeAt: pause.ResumeAt,
+	}
+}
+
+func (d pauseDocument) toPause() (manager.Pause, error) {
+	status := manager.ProjectStatus(d.Status)
+	if !status.IsValid() {
+		return manager.Pause{}, fmt.Errorf("unknown project status '%v'", d.Status)
+	}
+	return manager.Pause{
+		Status:   status,
+		Reason:   d.Reason,
+		Author:   d.Author,
+		PausedAt: d.PausedAt,
+		ResumeAt: d.ResumeAt,
+	}, nil
+}
+
+func newMaintenanceDocument(m manager.Maintenance) maintenanceDocument {
+	return maintenanceDocument{
+		Reason:    m.Reason,
+		Author:    m.Author,
+		StartedAt: m.StartedAt,
+		Until:     m.Until,
+	}
+}
+
+func (d maintenanceDocument) toMaintenance() manager.Maintenance {
+	return manager.Maintenance{
+		Reason:    d.Reason,
+		Author:    d.Author,
+		StartedAt: d.StartedAt,
+		Until:     d.Until,
+	}
+}
+
+func newRuleDocument(rule manager.Rule) ruleDocument {
+	return ruleDocument{
+		Count:            rule.Count,
+		MinAge:           int(rule.MinAge / manager.Day),
+		MinAgeSeconds:    int64(rule.MinAge / time.Second),
+		ToleranceSeconds: int64(rule.Tolerance / time.Second),
+	}
+}
+
+func (d ruleDocument) toRule() manager.Rule {
+	rule := manager.Rule{
+		Count:     d.Count,
+		MinAge:    time.Duration(d.MinAge) * manager.Day,
+		Tolerance: time.Duration(d.ToleranceSeconds) * time.Second,
+	}
+	if d.MinAgeSeconds > 0 {
+		rule.MinAge = time.Duration(d.MinAgeSeconds) * time.Second
+	}
+	return rule
+}
+
+func newRuleStateDocument(rs manager.RuleState) ruleStateDocument {
+	d := ruleStateDocument{
+		Rule:  newRuleDocument(rs.Rule),
+		Files: []selectedFileDocument{},
+		Next:  rs.Next,
+		Error: newRuleStateErrorDocument(rs.Error),
+	}
+	for _, f := range rs.Files {
+		d.Files = append(d.Files, selectedFileDocument{
+			fileDocument: newFileDocument(f.File),
+			Expiration:   f.Expiration,
+			Error:        newRuleStateErrorDocument(f.Error),
+		})
+	}
+	return d
+}
+
+func (d ruleStateDocument) toRuleState() (manager.RuleState, error) {
+	rs := manager.RuleState{
+		Rule:  d.Rule.toRule(),
+		Files: []manager.SelectedFile{},
+		Next:  d.Next,
+	}
+
+	var err error
+	rs.Error, err = d.Error.toRuleStateError()
+	if err != nil {
+		return rs, err
+	}
+
+	for _, fd := range d.Files {
+		f := manager.SelectedFile{
+			File:       fd.fileDocument.toFile(),
+			Expiration: fd.Expiration,
+		}
+		f.Error, err = fd.Error.toRuleStateError()
+		if err != nil {
+			return rs, err
+		}
+		rs.Files = append(rs.Files, f)
+	}
+	return rs, nil
+}
+
+func newFileDocument(file manager.File) fileDocument {
+	return fileDocument{Path: file.Path, Date: file.Date, Size: file.Size}
+}
+
+func (d fileDocument) toFile() manager.File {
+	return manager.File{Path: d.Path, Date: d.Date, Size: d.Size}
+}
+
+func newRuleStateErrorDocument(e *manager.RuleStateError) *ruleStateErrorDocument {
+	if e == nil {
+		return nil
+	}
+
+	d := ruleStateErrorDocument{Reason: ruleStateErrorReasons[e.Reason]}
+	if e.File != (manager.File{}) {
+		f := newFileDocument(e.File)
+		d.File = &f
+	}
+	return &d
+}
+
+func (d *ruleStateErrorDocument) toRuleStateError() (*manager.RuleStateError, error) {
+	if d == nil {
+		return nil, nil
+	}
+
+	e := manager.RuleStateError{}
+	found := false
+	for reason, name := range ruleStateErrorReasons {
+		if name == d.Reason {
+			e.Reason = reason
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("unknown error reason '%v'", d.Reason)
+	}
+
+	if d.File != nil {
+		e.File = d.File.toFile()
+	}
+	return &e, nil
+}
+
+func newAccountDocument(account manager.Account) accountDocument {
+	return accountDocument{
+		Username:       account.Username,
+		HashedPassword: account.HashedPassword,
+		Role:           string(account.Role),
+		Projects:       account.Projects,
+	}
+}
+
+func (d accountDocument) toAccount() manager.Account {
+	return manager.Account{
+		Username:       d.Username,
+		HashedPassword: d.HashedPassword,
+		Role:           manager.Role(d.Role),
+		Projects:       d.Projects,
+	}
+}
+
+func newNotificationDocument(record manager.NotificationRecord) notificationDocument {
+	return notificationDocument{
+		ID:          record.ID,
+		ProjectName: record.ProjectName,
+		Level:       alertLevels[record.Level],
+		Count:       record.Count,
+		Reasons:     record.Reasons,
+		SentAt:      record.SentAt,
+	}
+}
+
+func (d notificationDocument) toNotificationRecord() manager.NotificationRecord {
+	record := manager.NotificationRecord{
+		ID:          d.ID,
+		ProjectName: d.ProjectName,
+		Count:       d.Count,
+		Reasons:     d.Reasons,
+		SentAt:      d.SentAt,
+	}
+	for level, name := range alertLevels {
+		if name == d.Level {
+			record.Level = level
+		}
+	}
+	return record
+}
+
+func newAuditEventDocument(event manager.AuditEvent) auditEventDocument {
+	return auditEventDocument{
+		ID:      event.ID,
+		Date:    event.Date,
+		Actor:   event.Actor,
+		Action:  string(event.Action),
+		Target:  event.Target,
+		IP:      event.IP,
+		Success: event.Success,
+		Message: event.Message,
+	}
+}
+
+func (d auditEventDocument) toAuditEvent() manager.AuditEvent {
+	return manager.AuditEvent{
+		ID:      d.ID,
+		Date:    d.Date,
+		Actor:   d.Actor,
+		Action:  manager.AuditAction(d.Action),
+		Target:  d.Target,
+		IP:      d.IP,
+		Success: d.Success,
+		Message: d.Message,
+	}
+}
+
+func newDownloadTokenDocument(token manager.DownloadToken) downloadTokenDocument {
+	return downloadTokenDocument{
+		FilePath:  token.FilePath,
+		Filename:  token.Filename,
+		CreatedBy: token.CreatedBy,
+		CreatedAt: token.CreatedAt,
+		ExpiresAt: token.ExpiresAt,
+	}
+}
+
+func (d downloadTokenDocument) toDownloadToken() manager.DownloadToken {
+	return manager.DownloadToken{
+		FilePath:  d.FilePath,
+		Filename:  d.Filename,
+		CreatedBy: d.CreatedBy,
+		CreatedAt: d.CreatedAt,
+		ExpiresAt: d.ExpiresAt,
+	}
+}
+
+func newTrashedFileDocument(file manager.TrashedFile) trashedFileDocument {
+	return trashedFileDocument{
+		File:      newFileDocument(file.File),
+		TrashPath: file.TrashPath,
+		TrashedAt: file.TrashedAt,
+		PurgeAt:   file.PurgeAt,
+	}
+}
+
+func (d trashedFileDocument) toTrashedFile() manager.TrashedFile {
+	return manager.TrashedFile{
+		File:      d.File.toFile(),
+		TrashPath: d.TrashPath,
+		TrashedAt: d.TrashedAt,
+		PurgeAt:   d.PurgeAt,
+	}
+}
@@ -0,0 +1,120 @@
+package bolt
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	bolt "go.etcd.io/bbolt"
+)
+
+var downloadTokenBucket = []byte("download_tokens")
+
+// NewDownloadTokenRepository returns a DownloadTokenRepository backed by a Bolt database.
+// Only a hash of the tokens is stored.
+func NewDownloadTokenRepository(db *bolt.DB) manager.DownloadTokenRepository {
+	return &downloadTokenRepository{
+		db: db,
+	}
+}
+
+type downloadTokenRepository struct {
+	db *bolt.DB
+}
+
+func (repo *downloadTokenRepository) Create(token string, info manager.DownloadToken) error {
+	if token == "" {
+		return fmt.Errorf("token cannot be empty")
+	}
+
+	err := repo.db.Update(func(tx *bolt.Tx) error {
+		// get or create the bucket
+		b, err := tx.CreateBucketIfNotExists(downloadTokenBucket)
+		if err != nil {
+			return fmt.Errorf("unable to create bolt bucket: %v", err)
+		}
+
+		// remove the expired tokens, never used
+		now := time.Now()
+		expiredKeys := [][]byte{}
+		err = b.ForEach(func(key, value []byte) error {
+			var existing downloadTokenDocument
+			err := decodeDocument(value, &existing)
+			if err != nil || existing.ExpiresAt.Before(now) {
+				expiredKeys = append(expiredKeys, key)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("unable to fetch bucket: %v", err)
+		}
+		for _, key := range expiredKeys {
+			err := b.Delete(key)
+			if err != nil {
+				return fmt.Errorf("unable to delete bolt key: %v", err)
+			}
+		}
+
+		// serialize token
+		data, err := encodeDocument(newDownloadTokenDocument(info))
+		if err != nil {
+			return err
+		}
+
+		// put it into the bucket
+		err = b.Put(downloadTokenKey(token), data)
+		if err != nil {
+			return fmt.Errorf("unable to put data in bucket: %v", err)
+		}
+
+		return nil
+	})
+
+	return manager.NewStorageError("create download token", err)
+}
+
+func (repo *downloadTokenRepository) Claim(token string) (*manager.DownloadToken, error) {
+	var info *manager.DownloadToken
+
+	err := repo.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(downloadTokenBucket)
+		if b == nil {
+			return nil
+		}
+
+		key := downloadTokenKey(token)
+		value := b.Get(key)
+		if value == nil {
+			return nil
+		}
+
+		var document downloadTokenDocument
+		err := decodeDocument(value, &document)
+		if err != nil {
+			return manager.NewCorruptedRecordError("claim download token", err)
+		}
+
+		// the token is removed in the same transaction, so it can't be claimed twice
+		err = b.Delete(key)
+		if err != nil {
+			return fmt.Errorf("unable to delete bolt key: %v", err)
+		}
+
+		existing := document.toDownloadToken()
+		info = &existing
+		return nil
+	})
+
+	if err != nil {
+		return nil, manager.NewStorageError("claim download token", err)
+	}
+
+	return info, nil
+}
+
+// downloadTokenKey returns the key of a token: a leak of the DB must not leak valid URLs
+func downloadTokenKey(token string) []byte {
+	hash := sha256.Sum256([]byte(token))
+	return hash[:]
+}
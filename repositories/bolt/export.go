@@ -0,0 +1,162 @@
+package bolt
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// exportFormat identifies the archives produced by Export
+const exportFormat = "backr-manager-export"
+
+// the buckets included in the archives: the download tokens are transient, and not exported
+var exportedBuckets = [][]byte{projectBucket, accountBucket, notificationHistoryBucket, auditBucket, trashBucket, settingsBucket}
+
+// archive is the portable representation of the database: the records are kept as stored (JSON documents),
+// along with the schema version, so an archive can be imported by a newer version of the manager
+type archive struct {
+	Format        string          `json:"format"`
+	SchemaVersion int             `json:"schema_version"`
+	ExportedAt    time.Time       `json:"exported_at"`
+	Buckets       []archiveBucket `json:"buckets"`
+}
+
+type archiveBucket struct {
+	Name     string          `json:"name"`
+	Sequence uint64          `json:"sequence"`
+	Records  []archiveRecord `json:"records"`
+}
+
+type archiveRecord struct {
+	Key   []byte          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+// Export writes a gzipped JSON archive of the projects (with their state), accounts (with their hashed password),
+// notifications history and audit trail. The archive is consistent, even if the database is in use.
+func Export(db *bolt.DB, w io.Writer) error {
+	a := archive{
+		Format:     exportFormat,
+		ExportedAt: time.Now(),
+		Buckets:    []archiveBucket{},
+	}
+
+	err := db.View(func(tx *bolt.Tx) error {
+		var err error
+		a.SchemaVersion, err = readSchemaVersion(tx)
+		if err != nil {
+			return err
+		}
+
+		for _, name := range exportedBuckets {
+			bucket := archiveBucket{Name: string(name), Records: []archiveRecord{}}
+
+			b := tx.Bucket(name)
+			if b != nil {
+				bucket.Sequence = b.Sequence()
+				err := b.ForEach(func(key, value []byte) error {
+					if !json.Valid(value) {
+						return fmt.Errorf("the record '%x' of the bucket '%s' is not a JSON document: migrate the DB first", key, name)
+					}
+					bucket.Records = append(bucket.Records, archiveRecord{Key: key, Value: json.RawMessage(value)})
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			a.Buckets = append(a.Buckets, bucket)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to read the DB: %v", err)
+	}
+
+	gz := gzip.NewWriter(w)
+	err = json.NewEncoder(gz).Encode(a)
+	if err != nil {
+		return fmt.Errorf("unable to write the archive: %v", err)
+	}
+	return gz.Close()
+}
+
+// Import restores an archive produced by Export, then applies the migrations required by the archive.
+// The records of the archive overwrite the existing ones, unless the database already contains projects
+// or accounts and overwrite is false.
+func Import(db *bolt.DB, r io.Reader, overwrite bool) ([]Migration, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the archive: %v", err)
+	}
+	defer gz.Close()
+
+	a := archive{}
+	err = json.NewDecoder(gz).Decode(&a)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the archive: %v", err)
+	}
+	if a.Format != exportFormat {
+		return nil, fmt.Errorf("unknown archive format '%v'", a.Format)
+	}
+	if _, err := pendingMigrations(a.SchemaVersion); err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		if !overwrite {
+			for _, name := range [][]byte{projectBucket, accountBucket} {
+				if b := tx.Bucket(name); b != nil && b.Stats().KeyN > 0 {
+					return fmt.Errorf("the DB already contains %s", name)
+				}
+			}
+		}
+
+		for _, bucket := range a.Buckets {
+			if !isExportedBucket(bucket.Name) {
+				return fmt.Errorf("unexpected bucket '%v' in the archive", bucket.Name)
+			}
+
+			b, err := tx.CreateBucketIfNotExists([]byte(bucket.Name))
+			if err != nil {
+				return fmt.Errorf("unable to create bolt bucket: %v", err)
+			}
+
+			for _, record := range bucket.Records {
+				err := b.Put(record.Key, record.Value)
+				if err != nil {
+					return fmt.Errorf("unable to put data in bucket: %v", err)
+				}
+			}
+
+			if bucket.Sequence > b.Sequence() {
+				err := b.SetSequence(bucket.Sequence)
+				if err != nil {
+					return fmt.Errorf("unable to set bucket sequence: %v", err)
+				}
+			}
+		}
+
+		return writeSchemaVersion(tx, a.SchemaVersion)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to import the archive: %v", err)
+	}
+
+	// the archive may have been exported by an older version
+	return Migrate(db, "")
+}
+
+func isExportedBucket(name string) bool {
+	for _, b := range exportedBuckets {
+		if string(b) == name {
+			return true
+		}
+	}
+	return false
+}
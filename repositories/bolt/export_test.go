@@ -0,0 +1,79 @@
+package bolt
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agence-webup/backr/manager"
+	bolt "go.etcd.io/bbolt"
+)
+
+func openTestDB(t *testing.T, dir string, name string) *bolt.DB {
+	db, err := bolt.Open(filepath.Join(dir, name), 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Migrate(db, ""); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestExportImport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backr-export")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	source := openTestDB(t, dir, "source.db")
+	defer source.Close()
+
+	NewProjectRepository(source).Save(manager.Project{Name: "project1", Rules: []manager.Rule{{Count: 3, MinAge: manager.Day}}})
+	NewAccountRepository(source).Create("john", manager.RoleAdmin, nil)
+	NewAuditRepository(source).Append(manager.AuditEvent{Actor: "john", Action: manager.AuditActionLogin, Success: true})
+
+	archive := bytes.Buffer{}
+	err = Export(source, &archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	target := openTestDB(t, dir, "target.db")
+	defer target.Close()
+
+	_, err = Import(target, bytes.NewReader(archive.Bytes()), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	project, _ := NewProjectRepository(target).GetByName("project1")
+	if project == nil || len(project.Rules) != 1 {
+		t.Errorf("unexpected project: %+v", project)
+	}
+	account, _ := NewAccountRepository(target).Get("john")
+	if account == nil || account.HashedPassword == "" {
+		t.Errorf("unexpected account: %+v", account)
+	}
+
+	// the sequences are restored, so the IDs are not reused
+	auditRepo := NewAuditRepository(target)
+	auditRepo.Append(manager.AuditEvent{Actor: "john", Action: manager.AuditActionLogin, Success: true})
+	events, _ := auditRepo.List(manager.AuditFilter{})
+	if len(events) != 2 || events[0].ID != 2 {
+		t.Errorf("unexpected audit events: %+v", events)
+	}
+
+	// the DB is not empty anymore
+	_, err = Import(target, bytes.NewReader(archive.Bytes()), false)
+	if err == nil {
+		t.Error("expected the import to be refused without overwrite")
+	}
+	_, err = Import(target, bytes.NewReader(archive.Bytes()), true)
+	if err != nil {
+		t.Errorf("expected the import to succeed with overwrite: %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package bolt
+
+import (
+	"fmt"
+
+	"github.com/agence-webup/backr/manager"
+	bolt "go.etcd.io/bbolt"
+)
+
+// the settings of the daemon, keyed by name
+var settingsBucket = []byte("settings")
+
+var maintenanceKey = []byte("maintenance")
+
+// NewMaintenanceRepository returns a MaintenanceRepository backed by a Bolt database
+func NewMaintenanceRepository(db *bolt.DB) manager.MaintenanceRepository {
+	return &maintenanceRepository{
+		db: db,
+	}
+}
+
+type maintenanceRepository struct {
+	db *bolt.DB
+}
+
+func (repo *maintenanceRepository) Get() (*manager.Maintenance, error) {
+	var maintenance *manager.Maintenance
+
+	err := repo.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(settingsBucket)
+		if b == nil {
+			return nil
+		}
+
+		value := b.Get(maintenanceKey)
+		if value == nil {
+			return nil
+		}
+
+		var document maintenanceDocument
+		err := decodeDocument(value, &document)
+		if err != nil {
+			return manager.NewCorruptedRecordError("get maintenance mode", err, string(maintenanceKey))
+		}
+
+		m := document.toMaintenance()
+		maintenance = &m
+
+		return nil
+	})
+	if err != nil {
+		return nil, manager.NewStorageError("get maintenance mode", err)
+	}
+
+	return maintenance, nil
+}
+
+func (repo *maintenanceRepository) Set(m *manager.Maintenance) error {
+	err := repo.db.Update(func(tx *bolt.Tx) error {
+		// get or create the bucket
+		b, err := tx.CreateBucketIfNotExists(settingsBucket)
+		if err != nil {
+			return fmt.Errorf("unable to create bolt bucket: %v", err)
+		}
+
+		if m == nil {
+			err = b.Delete(maintenanceKey)
+			if err != nil {
+				return fmt.Errorf("unable to delete bolt key: %v", err)
+			}
+			return nil
+		}
+
+		// serialize maintenance mode
+		data, err := encodeDocument(newMaintenanceDocument(*m))
+		if err != nil {
+			return err
+		}
+
+		// put it into the bucket
+		err = b.Put(maintenanceKey, data)
+		if err != nil {
+			return fmt.Errorf("unable to put data in bucket: %v", err)
+		}
+
+		return nil
+	})
+
+	return manager.NewStorageError("set maintenance mode", err)
+}
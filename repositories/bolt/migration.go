@@ -0,0 +1,164 @@
+package bolt
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// the "meta" bucket stores information about the database itself, like the schema version
+var metaBucket = []byte("meta")
+
+var schemaVersionKey = []byte("schema_version")
+
+// Migration represents a change of the schema of the database.
+// Each migration upgrades the schema to its version.
+type Migration struct {
+	Version     int
+	Description string
+
+	migrate func(tx *bolt.Tx) error
+}
+
+// migrations must be sorted by version, without gaps.
+// A migration must never be modified once released: add a new one instead.
+var migrations = []Migration{
+	{
+		Version:     1,
+		Description: "create the buckets and record the schema version",
+		migrate: func(tx *bolt.Tx) error {
+			for _, name := range [][]byte{projectBucket, accountBucket, auditBucket, notificationHistoryBucket, downloadTokenBucket} {
+				_, err := tx.CreateBucketIfNotExists(name)
+				if err != nil {
+					return fmt.Errorf("unable to create bucket '%s': %v", name, err)
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version:     2,
+		Description: "convert the gob records to JSON documents",
+		migrate:     migrateGobToJSON,
+	},
+	{
+		Version:     3,
+		Description: "convert the notifications of the stateful notifier to JSON documents",
+		migrate:     migrateNotificationStates,
+	},
+}
+
+// LatestSchemaVersion returns the schema version expected by this version of the manager
+func LatestSchemaVersion() int {
+	return migrations[len(migrations)-1].Version
+}
+
+// SchemaVersion returns the schema version of the database (0 if it has never been migrated)
+func SchemaVersion(db *bolt.DB) (int, error) {
+	version := 0
+	err := db.View(func(tx *bolt.Tx) error {
+		var err error
+		version, err = readSchemaVersion(tx)
+		return err
+	})
+	return version, err
+}
+
+// PendingMigrations returns the migrations to apply to the database.
+// It returns an error if the database has been migrated by a newer version of the manager.
+func PendingMigrations(db *bolt.DB) ([]Migration, error) {
+	version, err := SchemaVersion(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return pendingMigrations(version)
+}
+
+func pendingMigrations(version int) ([]Migration, error) {
+	if version > LatestSchemaVersion() {
+		return nil, fmt.Errorf("the schema version of the database (%d) is newer than the supported one (%d): upgrade the manager", version, LatestSchemaVersion())
+	}
+
+	pending := []Migration{}
+	for _, m := range migrations {
+		if m.Version > version {
+			pending = append(pending, m)
+		}
+	}
+	return pending, nil
+}
+
+// Migrate applies the pending migrations, and returns them.
+// When some migrations are pending, the database is copied to backupPath first (unless empty).
+// Each migration is applied in its own transaction, updating the schema version:
+// if a migration fails, the database stays at the version of the previous one.
+func Migrate(db *bolt.DB, backupPath string) ([]Migration, error) {
+	pending, err := PendingMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) == 0 {
+		return pending, nil
+	}
+
+	if backupPath != "" {
+		err := db.View(func(tx *bolt.Tx) error {
+			return tx.CopyFile(backupPath, 0600)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to backup the database before migrating: %v", err)
+		}
+	}
+
+	applied := []Migration{}
+	for _, m := range pending {
+		err := db.Update(func(tx *bolt.Tx) error {
+			err := m.migrate(tx)
+			if err != nil {
+				return err
+			}
+			return writeSchemaVersion(tx, m.Version)
+		})
+		if err != nil {
+			return applied, fmt.Errorf("migration %d (%v) failed: %v", m.Version, m.Description, err)
+		}
+		applied = append(applied, m)
+	}
+
+	return applied, nil
+}
+
+func readSchemaVersion(tx *bolt.Tx) (int, error) {
+	b := tx.Bucket(metaBucket)
+	if b == nil {
+		return 0, nil
+	}
+
+	value := b.Get(schemaVersionKey)
+	if value == nil {
+		return 0, nil
+	}
+	if len(value) != 8 {
+		return 0, fmt.Errorf("invalid schema version")
+	}
+
+	return int(binary.BigEndian.Uint64(value)), nil
+}
+
+func writeSchemaVersion(tx *bolt.Tx, version int) error {
+	b, err := tx.CreateBucketIfNotExists(metaBucket)
+	if err != nil {
+		return fmt.Errorf("unable to create bolt bucket: %v", err)
+	}
+
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, uint64(version))
+
+	err = b.Put(schemaVersionKey, value)
+	if err != nil {
+		return fmt.Errorf("unable to put data in bucket: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+package bolt
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Before the schema version 2, the records were encoded using gob.
+// The layouts below are frozen copies of the manager types at that time,
+// so the migration still decodes the old records when the manager types evolve.
+
+type gobProject struct {
+	Name      string
+	Rules     []gobRule
+	State     map[string]gobRuleState
+	CreatedAt time.Time
+}
+
+type gobRule struct {
+	Count  int
+	MinAge int
+}
+
+type gobRuleState struct {
+	Rule  gobRule
+	Files []gobSelectedFile
+	Next  *time.Time
+	Error *gobRuleStateError
+}
+
+type gobFile struct {
+	Path string
+	Date time.Time
+	Size int64
+}
+
+type gobSelectedFile struct {
+	File       gobFile
+	Expiration time.Time
+	Error      *gobRuleStateError
+}
+
+type gobRuleStateError struct {
+	File   gobFile
+	Reason int
+}
+
+type gobAccount struct {
+	Username       string
+	HashedPassword string
+	Role           string
+	Projects       []string
+}
+
+type gobNotificationRecord struct {
+	ID          uint64
+	ProjectName string
+	Level       int
+	Count       int
+	Reasons     []string
+	SentAt      time.Time
+}
+
+type gobAuditEvent struct {
+	ID      uint64
+	Date    time.Time
+	Actor   string
+	Action  string
+	Target  string
+	IP      string
+	Success bool
+	Message string
+}
+
+type gobDownloadToken struct {
+	FilePath  string
+	Filename  string
+	CreatedBy string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// gobNotification is the notification stored by the stateful notifier before the schema version 3.
+// Only the required fields are decoded: the other fields of the statement (i.e. the whole project) are ignored by gob.
+type gobNotification struct {
+	Statement gobProjectErrorStatement
+	CreatedAt time.Time
+	SentAt    time.Time
+}
+
+type gobProjectErrorStatement struct {
+	Project  gobNotifiedProject
+	Count    int
+	Reasons  map[int]string
+	MaxLevel int
+}
+
+type gobNotifiedProject struct {
+	Name string
+}
+
+// notificationStateDocument is a frozen copy of the JSON layout of the notifications of the stateful notifier
+type notificationStateDocument struct {
+	ProjectName string         `json:"project_name"`
+	Count       int            `json:"count"`
+	Reasons     map[int]string `json:"reasons"`
+	MaxLevel    int            `json:"max_level"`
+	CreatedAt   time.Time      `json:"created_at"`
+	SentAt      time.Time      `json:"sent_at"`
+}
+
+// migrateGobToJSON converts the gob records of every bucket into JSON documents
+func migrateGobToJSON(tx *bolt.Tx) error {
+	converters := []struct {
+		bucket  []byte
+		convert func(value []byte) (interface{}, error)
+	}{
+		{projectBucket, func(value []byte) (interface{}, error) {
+			var p gobProject
+			err := gob.NewDecoder(bytes.NewBuffer(value)).Decode(&p)
+			return p.toDocument(), err
+		}},
+		{accountBucket, func(value []byte) (interface{}, error) {
+			var a gobAccount
+			err := gob.NewDecoder(bytes.NewBuffer(value)).Decode(&a)
+			return accountDocument(a), err
+		}},
+		{notificationHistoryBucket, func(value []byte) (interface{}, error) {
+			var r gobNotificationRecord
+			err := gob.NewDecoder(bytes.NewBuffer(value)).Decode(&r)
+			return newNotificationDocument(manager.NotificationRecord{
+				ID:          r.ID,
+				ProjectName: r.ProjectName,
+				Level:       manager.AlertLevel(r.Level),
+				Count:       r.Count,
+				Reasons:     r.Reasons,
+				SentAt:      r.SentAt,
+			}), err
+		}},
+		{auditBucket, func(value []byte) (interface{}, error) {
+			var e gobAuditEvent
+			err := gob.NewDecoder(bytes.NewBuffer(value)).Decode(&e)
+			return auditEventDocument(e), err
+		}},
+		{downloadTokenBucket, func(value []byte) (interface{}, error) {
+			var t gobDownloadToken
+			err := gob.NewDecoder(bytes.NewBuffer(value)).Decode(&t)
+			return downloadTokenDocument(t), err
+		}},
+	}
+
+	for _, c := range converters {
+		err := convertGobBucket(tx, c.bucket, c.convert)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateNotificationStates converts the gob notifications of the stateful notifier into JSON documents,
+// storing only the name of the project instead of the whole project
+func migrateNotificationStates(tx *bolt.Tx) error {
+	return convertGobBucket(tx, notificationStateBucket, func(value []byte) (interface{}, error) {
+		var n gobNotification
+		err := gob.NewDecoder(bytes.NewBuffer(value)).Decode(&n)
+		return notificationStateDocument{
+			ProjectName: n.Statement.Project.Name,
+			Count:       n.Statement.Count,
+			Reasons:     n.Statement.Reasons,
+			MaxLevel:    n.Statement.MaxLevel,
+			CreatedAt:   n.CreatedAt,
+			SentAt:      n.SentAt,
+		}, err
+	})
+}
+
+// convertGobBucket converts the gob records of the bucket, the JSON documents are kept as is
+func convertGobBucket(tx *bolt.Tx, bucket []byte, convert func(value []byte) (interface{}, error)) error {
+	b := tx.Bucket(bucket)
+	if b == nil {
+		return nil
+	}
+
+	// the bucket can't be modified while iterating
+	documents := map[string][]byte{}
+	err := b.ForEach(func(key, value []byte) error {
+		if json.Valid(value) {
+			return nil
+		}
+
+		document, err := convert(value)
+		if err != nil {
+			return fmt.Errorf("unable to deserialize gob data of '%x': %v", key, err)
+		}
+		data, err := encodeDocument(document)
+		if err != nil {
+			return err
+		}
+		documents[string(key)] = data
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to convert bucket '%s': %v", bucket, err)
+	}
+
+	for key, data := range documents {
+		err := b.Put([]byte(key), data)
+		if err != nil {
+			return fmt.Errorf("unable to put data in bucket: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (p gobProject) toDocument() projectDocument {
+	project := manager.Project{
+		Name:      p.Name,
+		CreatedAt: p.CreatedAt,
+	}
+	for _, r := range p.Rules {
+		project.Rules = append(project.Rules, r.toRule())
+	}
+	if p.State != nil {
+		project.State = manager.ProjectState{}
+		for id, rs := range p.State {
+			state := manager.RuleState{
+				Rule:  rs.Rule.toRule(),
+				Next:  rs.Next,
+				Error: rs.Error.toRuleStateError(),
+			}
+			for _, f := range rs.Files {
+				state.Files = append(state.Files, manager.SelectedFile{
+					File:       manager.File(f.File),
+					Expiration: f.Expiration,
+					Error:      f.Error.toRuleStateError(),
+				})
+			}
+			project.State[manager.RuleID(id)] = state
+		}
+	}
+
+	return newProjectDocument(project)
+}
+
+// toRule converts the min age, stored as a count of days
+func (r gobRule) toRule() manager.Rule {
+	return manager.Rule{Count: r.Count, MinAge: time.Duration(r.MinAge) * manager.Day}
+}
+
+func (e *gobRuleStateError) toRuleStateError() *manager.RuleStateError {
+	if e == nil {
+		return nil
+	}
+	return &manager.RuleStateError{
+		File:   manager.File(e.File),
+		Reason: manager.RuleStateErrorType(e.Reason),
+	}
+}
@@ -0,0 +1,178 @@
+package bolt
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestMigrate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backr-migration")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := bolt.Open(filepath.Join(dir, "test.db"), 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	backupPath := filepath.Join(dir, "backup.db")
+	applied, err := Migrate(db, backupPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != len(migrations) {
+		t.Errorf("expected %d migrations to be applied, got %d", len(migrations), len(applied))
+	}
+	if _, err := os.Stat(backupPath); err != nil {
+		t.Errorf("expected a backup before migrating: %v", err)
+	}
+
+	version, _ := SchemaVersion(db)
+	if version != LatestSchemaVersion() {
+		t.Errorf("expected schema version %d, got %d", LatestSchemaVersion(), version)
+	}
+
+	// nothing to do, no backup
+	os.Remove(backupPath)
+	applied, err = Migrate(db, backupPath)
+	if err != nil || len(applied) != 0 {
+		t.Errorf("expected no migration, got %v (%v)", applied, err)
+	}
+	if _, err := os.Stat(backupPath); !os.IsNotExist(err) {
+		t.Error("expected no backup when the DB is up to date")
+	}
+}
+
+func TestMigrateShouldStopOnFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backr-migration")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := bolt.Open(filepath.Join(dir, "test.db"), 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	original := migrations
+	defer func() { migrations = original }()
+	migrations = append(append([]Migration{}, original...), Migration{
+		Version:     LatestSchemaVersion() + 1,
+		Description: "failing migration",
+		migrate: func(tx *bolt.Tx) error {
+			return fmt.Errorf("failure")
+		},
+	})
+
+	applied, err := Migrate(db, "")
+	if err == nil || len(applied) != len(original) {
+		t.Fatalf("expected the last migration to fail, got %v (%v)", applied, err)
+	}
+
+	version, _ := SchemaVersion(db)
+	if version != original[len(original)-1].Version {
+		t.Errorf("expected the schema version of the last successful migration, got %d", version)
+	}
+
+	// a newer DB can't be used
+	migrations = original
+	if _, err := pendingMigrations(LatestSchemaVersion() + 1); err == nil {
+		t.Error("expected an error for a DB newer than the supported schema")
+	}
+}
+
+func TestMigrateGobToJSON(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backr-migration")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := bolt.Open(filepath.Join(dir, "test.db"), 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// records written by the previous versions of the manager
+	next := time.Date(2019, 8, 16, 2, 0, 0, 0, time.UTC)
+	file := manager.File{Path: "project1/file1.tar.gz", Date: next.Add(-24 * time.Hour), Size: 42}
+	project := manager.Project{
+		Name:      "project1",
+		Rules:     []manager.Rule{{Count: 3, MinAge: manager.Day}},
+		CreatedAt: next.Add(-48 * time.Hour),
+		State: manager.ProjectState{
+			"rule3.1": manager.RuleState{
+				Rule:  manager.Rule{Count: 3, MinAge: manager.Day},
+				Next:  &next,
+				Files: []manager.SelectedFile{{File: file, Expiration: next, Error: &manager.RuleStateError{File: file, Reason: manager.RuleStateErrorSizeTooSmall}}},
+				Error: &manager.RuleStateError{Reason: manager.RuleStateErrorNoFile},
+			},
+		},
+	}
+	account := manager.Account{Username: "john", HashedPassword: "hash", Role: manager.RoleReader}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for bucket, records := range map[string]map[string]interface{}{
+			string(projectBucket): {project.Name: project},
+			string(accountBucket): {account.Username: account},
+		} {
+			b, err := tx.CreateBucketIfNotExists([]byte(bucket))
+			if err != nil {
+				return err
+			}
+			for key, record := range records {
+				buf := bytes.Buffer{}
+				if err := gob.NewEncoder(&buf).Encode(record); err != nil {
+					return err
+				}
+				if err := b.Put([]byte(key), buf.Bytes()); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Migrate(db, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	migratedProject, err := NewProjectRepository(db).GetByName("project1")
+	if err != nil || migratedProject == nil {
+		t.Fatalf("unable to get migrated project: %v", err)
+	}
+	rs := migratedProject.State["rule3.1"]
+	if migratedProject.Name != project.Name || len(migratedProject.Rules) != 1 || !migratedProject.CreatedAt.Equal(project.CreatedAt) {
+		t.Errorf("unexpected project: %+v", migratedProject)
+	}
+	if rs.Next == nil || !rs.Next.Equal(next) || rs.Error == nil || rs.Error.Reason != manager.RuleStateErrorNoFile {
+		t.Errorf("unexpected rule state: %+v", rs)
+	}
+	if len(rs.Files) != 1 || rs.Files[0].Path != file.Path || rs.Files[0].Size != 42 || rs.Files[0].Error == nil || rs.Files[0].Error.Reason != manager.RuleStateErrorSizeTooSmall {
+		t.Errorf("unexpected selected files: %+v", rs.Files)
+	}
+
+	migratedAccount, err := NewAccountRepository(db).Get("john")
+	if err != nil || migratedAccount == nil || migratedAccount.HashedPassword != "hash" || migratedAccount.Role != manager.RoleReader {
+		t.Errorf("unexpected account: %+v (%v)", migratedAccount, err)
+	}
+}
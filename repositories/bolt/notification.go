@@ -0,0 +1,93 @@
+package bolt
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/agence-webup/backr/manager"
+	bolt "go.etcd.io/bbolt"
+)
+
+// the "notifications" bucket is used by the stateful notifier to store its state
+var notificationStateBucket = []byte("notifications")
+
+var notificationHistoryBucket = []byte("notification_history")
+
+// NewNotificationRepository returns a NotificationRepository backed by a Bolt database.
+// Records are keyed by a sequence, so they are stored in chronological order.
+func NewNotificationRepository(db *bolt.DB) manager.NotificationRepository {
+	return &notificationRepository{
+		db: db,
+	}
+}
+
+type notificationRepository struct {
+	db *bolt.DB
+}
+
+func (repo *notificationRepository) Append(record manager.NotificationRecord) error {
+	err := repo.db.Update(func(tx *bolt.Tx) error {
+		// get or create the bucket
+		b, err := tx.CreateBucketIfNotExists(notificationHistoryBucket)
+		if err != nil {
+			return fmt.Errorf("unable to create bolt bucket: %v", err)
+		}
+
+		id, err := b.NextSequence()
+		if err != nil {
+			return fmt.Errorf("unable to get next sequence: %v", err)
+		}
+		record.ID = id
+
+		// serialize record
+		data, err := encodeDocument(newNotificationDocument(record))
+		if err != nil {
+			return err
+		}
+
+		// put it into the bucket
+		err = b.Put(sequenceKey(id), data)
+		if err != nil {
+			return fmt.Errorf("unable to put data in bucket: %v", err)
+		}
+
+		return nil
+	})
+
+	return manager.NewStorageError("append notification record", err)
+}
+
+func (repo *notificationRepository) List(limit int) ([]manager.NotificationRecord, error) {
+	records := []manager.NotificationRecord{}
+
+	err := repo.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(notificationHistoryBucket)
+		if b == nil {
+			return nil
+		}
+
+		// walk from the most recent record to the oldest
+		c := b.Cursor()
+		for key, value := c.Last(); key != nil; key, value = c.Prev() {
+			var document notificationDocument
+			err := decodeDocument(value, &document)
+			if err != nil {
+				return manager.NewCorruptedRecordError("list notification records", err, fmt.Sprint(binary.BigEndian.Uint64(key)))
+			}
+
+			records = append(records, document.toNotificationRecord())
+
+			if limit > 0 && len(records) >= limit {
+				break
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, manager.NewStorageError("list notification records", err)
+	}
+
+	return records, nil
+}
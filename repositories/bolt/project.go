@@ -1,8 +1,6 @@
 package bolt
 
 import (
-	"bytes"
-	"encoding/gob"
 	"fmt"
 	"time"
 
@@ -26,32 +24,39 @@ type projectRepo struct {
 	db *bolt.DB
 }
 
+// GetAll returns the projects which can be decoded: if some records are corrupted,
+// the other projects are returned with an error of kind ErrCorruptedRecord listing the corrupted keys.
 func (repo *projectRepo) GetAll() ([]manager.Project, error) {
 	projects := []manager.Project{}
-	repo.db.View(func(tx *bolt.Tx) error {
+	corruptedKeys := []string{}
+	var corruptionErr error
+
+	err := repo.db.View(func(tx *bolt.Tx) error {
 		b := tx.Bucket(projectBucket)
 		if b == nil {
 			return nil
 		}
 
-		err := b.ForEach(func(key, value []byte) error {
-			var project manager.Project
-			buf := bytes.NewBuffer(value)
-			err := gob.NewDecoder(buf).Decode(&project)
+		return b.ForEach(func(key, value []byte) error {
+			project, err := decodeProject(value)
 			if err != nil {
-				return fmt.Errorf("unable to deserialize gob data: %v", err)
+				corruptedKeys = append(corruptedKeys, string(key))
+				corruptionErr = err
+				return nil
 			}
 
 			projects = append(projects, project)
 
 			return nil
 		})
-		if err != nil {
-			return fmt.Errorf("unable to fetch bucket: %v", err)
-		}
-
-		return nil
 	})
+	if err != nil {
+		return nil, manager.NewStorageError("get all projects", err)
+	}
+
+	if len(corruptedKeys) > 0 {
+		return projects, manager.NewCorruptedRecordError("get all projects", corruptionErr, corruptedKeys...)
+	}
 
 	return projects, nil
 }
@@ -60,7 +65,7 @@ func (repo *projectRepo) GetByName(name string) (*manager.Project, error) {
 
 	var project *manager.Project
 
-	repo.db.View(func(tx *bolt.Tx) error {
+	err := repo.db.View(func(tx *bolt.Tx) error {
 		b := tx.Bucket(projectBucket)
 		if b == nil {
 			return nil
@@ -68,15 +73,18 @@ func (repo *projectRepo) GetByName(name string) (*manager.Project, error) {
 
 		value := b.Get([]byte(name))
 		if value != nil {
-			buf := bytes.NewBuffer(value)
-			err := gob.NewDecoder(buf).Decode(&project)
+			p, err := decodeProject(value)
 			if err != nil {
-				return fmt.Errorf("unable to deserialize gob data: %v", err)
+				return manager.NewCorruptedRecordError(fmt.Sprintf("get project '%v'", name), err, name)
 			}
+			project = &p
 		}
 
 		return nil
 	})
+	if err != nil {
+		return nil, manager.NewStorageError(fmt.Sprintf("get project '%v'", name), err)
+	}
 
 	return project, nil
 }
@@ -87,7 +95,7 @@ func (repo *projectRepo) Save(project manager.Project) error {
 		project.CreatedAt = time.Now()
 	}
 
-	repo.db.Update(func(tx *bolt.Tx) error {
+	err := repo.db.Update(func(tx *bolt.Tx) error {
 		// get or create the bucket
 		b, err := tx.CreateBucketIfNotExists(projectBucket)
 		if err != nil {
@@ -95,20 +103,70 @@ func (repo *projectRepo) Save(project manager.Project) error {
 		}
 
 		// serialize project
-		buf := bytes.Buffer{}
-		err = gob.NewEncoder(&buf).Encode(project)
+		data, err := encodeDocument(newProjectDocument(project))
 		if err != nil {
-			return fmt.Errorf("unable to serialize gob data: %v", err)
+			return err
 		}
 
 		// put it into the bucket
-		err = b.Put([]byte(project.Name), buf.Bytes())
+		err = b.Put([]byte(project.Name), data)
+		if err != nil {
+			return fmt.Errorf("unable to put data in bucket: %v", err)
+		}
+
+		return nil
+	})
+
+	return manager.NewStorageError(fmt.Sprintf("save project '%v'", project.Name), err)
+}
+
+func (repo *projectRepo) Update(name string, fn func(project *manager.Project) error) error {
+	op := fmt.Sprintf("update project '%v'", name)
+
+	var fnErr error
+	err := repo.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(projectBucket)
+		if b == nil {
+			return manager.NewNotFoundError(op, name)
+		}
+
+		value := b.Get([]byte(name))
+		if value == nil {
+			return manager.NewNotFoundError(op, name)
+		}
+		project, err := decodeProject(value)
+		if err != nil {
+			return manager.NewCorruptedRecordError(op, err, name)
+		}
+
+		fnErr = fn(&project)
+		if fnErr != nil {
+			return fnErr
+		}
+
+		data, err := encodeDocument(newProjectDocument(project))
+		if err != nil {
+			return err
+		}
+		err = b.Put([]byte(name), data)
 		if err != nil {
 			return fmt.Errorf("unable to put data in bucket: %v", err)
 		}
 
 		return nil
 	})
+	if fnErr != nil {
+		return fnErr
+	}
+
+	return manager.NewStorageError(op, err)
+}
 
-	return nil
+func decodeProject(value []byte) (manager.Project, error) {
+	var document projectDocument
+	err := decodeDocument(value, &document)
+	if err != nil {
+		return manager.Project{}, err
+	}
+	return document.toProject()
 }
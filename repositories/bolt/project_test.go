@@ -0,0 +1,68 @@
+package bolt
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agence-webup/backr/manager"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestProjectRepositoryCorruptedRecord(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backr-project")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := bolt.Open(filepath.Join(dir, "test.db"), 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	repo := NewProjectRepository(db)
+	err = repo.Save(manager.Project{Name: "valid", Rules: []manager.Rule{{MinAge: manager.Day, Count: 3}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(projectBucket).Put([]byte("corrupted"), []byte("{not json"))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the valid projects are still returned
+	projects, err := repo.GetAll()
+	if !errors.Is(err, manager.ErrCorruptedRecord) {
+		t.Fatalf("expected a corrupted record error, got %v", err)
+	}
+	var repoErr *manager.RepositoryError
+	if !errors.As(err, &repoErr) || len(repoErr.Keys) != 1 || repoErr.Keys[0] != "corrupted" {
+		t.Errorf("expected the corrupted key to be reported, got %v", err)
+	}
+	if len(projects) != 1 || projects[0].Name != "valid" {
+		t.Errorf("expected the valid project to be returned, got %v", projects)
+	}
+
+	_, err = repo.GetByName("corrupted")
+	if !errors.Is(err, manager.ErrCorruptedRecord) {
+		t.Errorf("expected a corrupted record error, got %v", err)
+	}
+
+	project, err := repo.GetByName("unknown")
+	if err != nil || project != nil {
+		t.Errorf("expected no project and no error, got %v, %v", project, err)
+	}
+
+	// a failure of the storage is reported
+	db.Close()
+	err = repo.Save(manager.Project{Name: "other"})
+	if !errors.Is(err, manager.ErrStorage) {
+		t.Errorf("expected a storage error, got %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package bolt
+
+import (
+	"fmt"
+
+	"github.com/agence-webup/backr/manager"
+	bolt "go.etcd.io/bbolt"
+)
+
+var trashBucket = []byte("trash")
+
+// NewTrashRepository returns a TrashRepository backed by a Bolt database.
+// The trashed files are keyed by path.
+func NewTrashRepository(db *bolt.DB) manager.TrashRepository {
+	return &trashRepository{
+		db: db,
+	}
+}
+
+type trashRepository struct {
+	db *bolt.DB
+}
+
+func (repo *trashRepository) Add(file manager.TrashedFile) error {
+	err := repo.db.Update(func(tx *bolt.Tx) error {
+		// get or create the bucket
+		b, err := tx.CreateBucketIfNotExists(trashBucket)
+		if err != nil {
+			return fmt.Errorf("unable to create bolt bucket: %v", err)
+		}
+
+		// serialize trashed file
+		data, err := encodeDocument(newTrashedFileDocument(file))
+		if err != nil {
+			return err
+		}
+
+		// put it into the bucket
+		err = b.Put([]byte(file.File.Path), data)
+		if err != nil {
+			return fmt.Errorf("unable to put data in bucket: %v", err)
+		}
+
+		return nil
+	})
+
+	return manager.NewStorageError(fmt.Sprintf("trash file '%v'", file.File.Path), err)
+}
+
+func (repo *trashRepository) Get(path string) (*manager.TrashedFile, error) {
+	var file *manager.TrashedFile
+
+	err := repo.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(trashBucket)
+		if b == nil {
+			return nil
+		}
+
+		value := b.Get([]byte(path))
+		if value == nil {
+			return nil
+		}
+
+		var document trashedFileDocument
+		err := decodeDocument(value, &document)
+		if err != nil {
+			return manager.NewCorruptedRecordError(fmt.Sprintf("get trashed file '%v'", path), err, path)
+		}
+
+		f := document.toTrashedFile()
+		file = &f
+
+		return nil
+	})
+	if err != nil {
+		return nil, manager.NewStorageError(fmt.Sprintf("get trashed file '%v'", path), err)
+	}
+
+	return file, nil
+}
+
+func (repo *trashRepository) List() ([]manager.TrashedFile, error) {
+	files := []manager.TrashedFile{}
+
+	err := repo.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(trashBucket)
+		if b == nil {
+			return nil
+		}
+
+		return b.ForEach(func(key, value []byte) error {
+			var document trashedFileDocument
+			err := decodeDocument(value, &document)
+			if err != nil {
+				return manager.NewCorruptedRecordError("list trashed files", err, string(key))
+			}
+
+			files = append(files, document.toTrashedFile())
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, manager.NewStorageError("list trashed files", err)
+	}
+
+	return files, nil
+}
+
+func (repo *trashRepository) Remove(path string) error {
+	err := repo.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(trashBucket)
+		if b == nil {
+			return nil
+		}
+
+		err := b.Delete([]byte(path))
+		if err != nil {
+			return fmt.Errorf("unable to delete bolt key: %v", err)
+		}
+		return nil
+	})
+
+	return manager.NewStorageError(fmt.Sprintf("remove trashed file '%v'", path), err)
+}
@@ -1,16 +1,23 @@
 package inmem
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/agence-webup/backr/manager"
 )
 
 type fileRepo struct {
-	Files []manager.File
+	Files    []manager.File
+	Contents map[string][]byte
+	Headers  map[string]http.Header
 }
 
 // NewFileRepository returns a FileRepository instance,
@@ -81,10 +88,95 @@ func (repo *fileRepo) RemoveFile(file manager.File) error {
 	return nil
 }
 
-func (repo *fileRepo) GetURL(file manager.File) (*url.URL, error) {
+func (repo *fileRepo) GetURL(file manager.File, options manager.URLOptions) (*url.URL, error) {
+	return &url.URL{Scheme: "inmem", Path: "/" + file.Path}, nil
+}
+
+func (repo *fileRepo) Stat(file manager.File) (*manager.File, error) {
+	for _, f := range repo.Files {
+		if f.Path == file.Path {
+			return &f, nil
+		}
+	}
+
 	return nil, nil
 }
 
+func (repo *fileRepo) Open(file manager.File) (io.ReadCloser, int64, error) {
+	for _, f := range repo.Files {
+		if f.Path == file.Path {
+			content := repo.Contents[file.Path]
+			return ioutil.NopCloser(bytes.NewReader(content)), int64(len(content)), nil
+		}
+	}
+
+	return nil, 0, manager.NewNotFoundError("open file", file.Path)
+}
+
+func (repo *fileRepo) PutFile(file manager.File, content io.Reader, size int64) error {
+	if size >= 0 {
+		content = io.LimitReader(content, size)
+	}
+	data, err := ioutil.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	if size >= 0 && int64(len(data)) != size {
+		return fmt.Errorf("expected %d bytes, got %d", size, len(data))
+	}
+
+	file.Size = int64(len(data))
+	if file.Date.IsZero() {
+		file.Date = time.Now()
+	}
+	// overwrite the existing file, if any
+	for i, f := range repo.Files {
+		if f.Path == file.Path {
+			repo.Files = append(repo.Files[:i], repo.Files[i+1:]...)
+			break
+		}
+	}
+	CreateFakeFileWithContent(repo, file, data)
+
+	return nil
+}
+
+func (repo *fileRepo) CopyFile(src manager.File, dst manager.File) error {
+	for _, f := range repo.Files {
+		if f.Path == src.Path {
+			// like S3, the copy is dated from its creation
+			copied := manager.File{Path: dst.Path, Date: time.Now(), Size: f.Size}
+			for i, existing := range repo.Files {
+				if existing.Path == dst.Path {
+					repo.Files = append(repo.Files[:i], repo.Files[i+1:]...)
+					break
+				}
+			}
+			CreateFakeFileWithContent(repo, copied, repo.Contents[src.Path])
+			if header, ok := repo.Headers[src.Path]; ok {
+				SetFakeMetadata(repo, dst.Path, header)
+			}
+			return nil
+		}
+	}
+
+	return manager.NewNotFoundError("copy file", src.Path)
+}
+
+func (repo *fileRepo) Metadata(file manager.File) (http.Header, error) {
+	for _, f := range repo.Files {
+		if f.Path == file.Path {
+			header := http.Header{}
+			for key, values := range repo.Headers[file.Path] {
+				header[key] = append([]string{}, values...)
+			}
+			return header, nil
+		}
+	}
+
+	return nil, manager.NewNotFoundError("get metadata", file.Path)
+}
+
 func (repo *fileRepo) getFileComponents(file manager.File) ([]string, error) {
 	components := strings.Split(file.Path, "/")
 
@@ -104,3 +196,28 @@ func CreateFakeFile(repo manager.FileRepository, file manager.File) {
 	}
 	r.Files = append(r.Files, file)
 }
+
+// CreateFakeFileWithContent adds the file and its content into memory, accessible by the repo
+func CreateFakeFileWithContent(repo manager.FileRepository, file manager.File, content []byte) {
+	r, ok := repo.(*fileRepo)
+	if !ok {
+		return
+	}
+	if r.Contents == nil {
+		r.Contents = map[string][]byte{}
+	}
+	r.Files = append(r.Files, file)
+	r.Contents[file.Path] = content
+}
+
+// SetFakeMetadata sets the metadata headers of the file
+func SetFakeMetadata(repo manager.FileRepository, path string, header http.Header) {
+	r, ok := repo.(*fileRepo)
+	if !ok {
+		return
+	}
+	if r.Headers == nil {
+		r.Headers = map[string]http.Header{}
+	}
+	r.Headers[path] = header
+}
@@ -1,6 +1,7 @@
 package inmem
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/agence-webup/backr/manager"
@@ -46,3 +47,19 @@ func (repo *projectRepo) Save(project manager.Project) error {
 
 	return nil
 }
+
+func (repo *projectRepo) Update(name string, fn func(project *manager.Project) error) error {
+	p, ok := repo.projectsByName[name]
+	if !ok {
+		return manager.NewNotFoundError(fmt.Sprintf("update project '%v'", name), name)
+	}
+
+	err := fn(&p)
+	if err != nil {
+		return err
+	}
+
+	repo.projectsByName[name] = p
+
+	return nil
+}
@@ -3,9 +3,10 @@ package s3
 import (
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"net/url"
 	"strings"
-	"time"
 
 	"github.com/agence-webup/backr/manager"
 
@@ -47,7 +48,7 @@ func (repo *fileRepository) GetAll() ([]manager.File, error) {
 	files := []manager.File{}
 	for object := range repo.minioClient.ListObjectsV2(repo.bucket, "", recursive, doneCh) {
 		if object.Err != nil {
-			return files, fmt.Errorf("unable to list S3 objects: %w", object.Err)
+			return files, manager.NewStorageError("list S3 objects", object.Err)
 		}
 
 		f := manager.File{
@@ -116,15 +117,23 @@ func (repo *fileRepository) GetFilenameForFile(file manager.File) (string, error
 }
 
 func (repo *fileRepository) RemoveFile(file manager.File) error {
-	return repo.minioClient.RemoveObject(repo.bucket, file.Path)
+	return manager.NewStorageError("remove S3 object", repo.minioClient.RemoveObject(repo.bucket, file.Path))
 }
 
-func (repo *fileRepository) GetURL(file manager.File) (*url.URL, error) {
+func (repo *fileRepository) GetURL(file manager.File, options manager.URLOptions) (*url.URL, error) {
 	// Set request parameters for content-disposition.
 	reqParams := make(url.Values)
+	if options.Filename != "" {
+		reqParams.Set("response-content-disposition", fmt.Sprintf("attachment; filename=\"%v\"", options.Filename))
+	}
+
+	expiry := options.Expiry
+	if expiry == 0 {
+		expiry = manager.DefaultURLExpiry
+	}
 
-	// Generates a presigned url which expires in a day.
-	presignedURL, err := repo.minioClient.PresignedGetObject(repo.bucket, file.Path, 15*time.Minute, reqParams)
+	// Generates a presigned url which expires after the requested duration.
+	presignedURL, err := repo.minioClient.PresignedGetObject(repo.bucket, file.Path, expiry, reqParams)
 	if err != nil {
 		return nil, err
 	}
@@ -132,6 +141,78 @@ func (repo *fileRepository) GetURL(file manager.File) (*url.URL, error) {
 	return presignedURL, nil
 }
 
+func (repo *fileRepository) Stat(file manager.File) (*manager.File, error) {
+	info, err := repo.minioClient.StatObject(repo.bucket, file.Path, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, nil
+		}
+		return nil, manager.NewStorageError("stat S3 object", err)
+	}
+
+	return &manager.File{
+		Path: info.Key,
+		Date: info.LastModified,
+		Size: info.Size,
+	}, nil
+}
+
+func (repo *fileRepository) Open(file manager.File) (io.ReadCloser, int64, error) {
+	object, err := repo.minioClient.GetObject(repo.bucket, file.Path, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, 0, manager.NewStorageError("open S3 object", err)
+	}
+
+	info, err := object.Stat()
+	if err != nil {
+		object.Close()
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, 0, manager.NewNotFoundError("open S3 object", file.Path)
+		}
+		return nil, 0, manager.NewStorageError("open S3 object", err)
+	}
+
+	return object, info.Size, nil
+}
+
+func (repo *fileRepository) PutFile(file manager.File, content io.Reader, size int64) error {
+	// large files are sent using a multipart upload by the client
+	_, err := repo.minioClient.PutObject(repo.bucket, file.Path, content, size, minio.PutObjectOptions{
+		ContentType: "application/octet-stream",
+	})
+	return manager.NewStorageError("put S3 object", err)
+}
+
+func (repo *fileRepository) CopyFile(src manager.File, dst manager.File) error {
+	// the copy is done by S3, the metadata of the source object are kept
+	destination, err := minio.NewDestinationInfo(repo.bucket, dst.Path, nil, nil)
+	if err != nil {
+		return manager.NewStorageError("copy S3 object", err)
+	}
+
+	err = repo.minioClient.CopyObject(destination, minio.NewSourceInfo(repo.bucket, src.Path, nil))
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return manager.NewNotFoundError("copy S3 object", src.Path)
+		}
+		return manager.NewStorageError("copy S3 object", err)
+	}
+
+	return nil
+}
+
+func (repo *fileRepository) Metadata(file manager.File) (http.Header, error) {
+	info, err := repo.minioClient.StatObject(repo.bucket, file.Path, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, manager.NewNotFoundError("stat S3 object", file.Path)
+		}
+		return nil, manager.NewStorageError("stat S3 object", err)
+	}
+
+	return info.Metadata, nil
+}
+
 func (repo *fileRepository) getFileComponents(file manager.File) ([]string, error) {
 	components := strings.Split(file.Path, "/")
 
@@ -0,0 +1,128 @@
+package manager
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// RuleWarningType represents the kind of a RuleWarning
+type RuleWarningType string
+
+const (
+	// RuleWarningSubsumed indicates that the files kept by the rule are already kept by another rule
+	RuleWarningSubsumed RuleWarningType = "subsumed"
+	// RuleWarningUnsatisfiable indicates that the upload interval of the project is too long for the rule:
+	// its files are always outdated
+	RuleWarningUnsatisfiable RuleWarningType = "unsatisfiable"
+	// RuleWarningCapConflict indicates that the caps of the project remove files kept by the rules
+	RuleWarningCapConflict RuleWarningType = "cap_conflict"
+)
+
+// RuleWarning describes a rule which is accepted, but probably not what is expected
+type RuleWarning struct {
+	Type RuleWarningType
+	// Rule is the concerned rule (zero value for the caps conflicts)
+	Rule    Rule
+	Message string
+}
+
+// RulesReport describes the effect of a set of rules
+type RulesReport struct {
+	Warnings []RuleWarning
+	// MaxRetention is the age reached by the oldest kept file before its removal
+	MaxRetention time.Duration
+	// ExpectedFileCount is the count of files kept at the same time, once the rules are fulfilled
+	ExpectedFileCount int
+}
+
+// LintRules checks the rules of a project, uploading a file every uploadInterval (1 day if not set).
+// An error is returned when the rules are invalid: negative values, or duplicated rules.
+func LintRules(rules []Rule, uploadInterval time.Duration) (RulesReport, error) {
+	report := RulesReport{Warnings: []RuleWarning{}}
+
+	ids := map[RuleID]bool{}
+	for _, r := range rules {
+		if r.Count <= 0 || r.MinAge <= 0 {
+			return report, fmt.Errorf("invalid rule %v: count and min age must be greater than 0", r)
+		}
+		if r.Tolerance < 0 {
+			return report, fmt.Errorf("invalid rule %v: the tolerance must not be negative", r)
+		}
+		if ids[r.GetID()] {
+			return report, fmt.Errorf("duplicated rule %v", r)
+		}
+		ids[r.GetID()] = true
+	}
+
+	declaredInterval := uploadInterval
+	if uploadInterval <= 0 {
+		uploadInterval = Day
+	}
+
+	// the ages of the kept files, according to the upload interval
+	keptAges := map[time.Duration]bool{}
+	for _, r := range rules {
+
+		// the files of a rule are a part of the files of another rule with the same min age and a greater count
+		for _, other := range rules {
+			if other.MinAge == r.MinAge && other.Count > r.Count {
+				report.Warnings = append(report.Warnings, RuleWarning{
+					Type:    RuleWarningSubsumed,
+					Rule:    r,
+					Message: fmt.Sprintf("the files of rule %v are already kept by rule %v", r, other),
+				})
+				break
+			}
+		}
+
+		minAge := r.MinAge
+		if declaredInterval > minAge+r.GetTolerance() {
+			report.Warnings = append(report.Warnings, RuleWarning{
+				Type:    RuleWarningUnsatisfiable,
+				Rule:    r,
+				Message: fmt.Sprintf("rule %v expects a file every %v, but a file is uploaded every %v", r, minAge, declaredInterval),
+			})
+		}
+
+		// for each file of the rule, the most recent upload at least k*minAge old
+		for k := 0; k < r.Count; k++ {
+			uploads := math.Ceil(float64(time.Duration(k)*minAge) / float64(uploadInterval))
+			keptAges[time.Duration(uploads)*uploadInterval] = true
+		}
+	}
+
+	ages := []time.Duration{}
+	for age := range keptAges {
+		ages = append(ages, age)
+	}
+	sort.Slice(ages, func(i, j int) bool { return ages[i] < ages[j] })
+
+	report.ExpectedFileCount = len(ages)
+	if len(ages) > 0 {
+		// the oldest file is replaced when the next file is uploaded
+		report.MaxRetention = ages[len(ages)-1] + uploadInterval
+	}
+
+	return report, nil
+}
+
+// LintCaps returns the warnings about the caps removing files expected by the rules (see LintRules)
+func LintCaps(caps Caps, report RulesReport) []RuleWarning {
+	warnings := []RuleWarning{}
+
+	if caps.MaxFiles > 0 && report.ExpectedFileCount > caps.MaxFiles {
+		warnings = append(warnings, RuleWarning{
+			Type:    RuleWarningCapConflict,
+			Message: fmt.Sprintf("the rules keep %d files, but the max count of files is %d", report.ExpectedFileCount, caps.MaxFiles),
+		})
+	}
+	if caps.MaxAge > 0 && report.MaxRetention > caps.MaxAge {
+		warnings = append(warnings, RuleWarning{
+			Type:    RuleWarningCapConflict,
+			Message: fmt.Sprintf("the rules keep files during %v, but the max age is %v", report.MaxRetention, caps.MaxAge),
+		})
+	}
+	return warnings
+}
@@ -0,0 +1,70 @@
+package manager
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLintRules(t *testing.T) {
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name           string
+		rules          []Rule
+		uploadInterval time.Duration
+		expectedErr    bool
+		warnings       []RuleWarningType
+		count          int
+		retention      time.Duration
+	}{
+		{"daily", []Rule{{Count: 3, MinAge: Day}, {Count: 2, MinAge: 15 * Day}}, 0, false, []RuleWarningType{}, 4, 16 * day},
+		{"duplicated", []Rule{{Count: 3, MinAge: Day}, {Count: 3, MinAge: Day}}, 0, true, nil, 0, 0},
+		{"invalid", []Rule{{Count: 0, MinAge: Day}}, 0, true, nil, 0, 0},
+		{"subsumed", []Rule{{Count: 3, MinAge: Day}, {Count: 5, MinAge: Day}}, 0, false, []RuleWarningType{RuleWarningSubsumed}, 5, 5 * day},
+		{"unsatisfiable", []Rule{{Count: 3, MinAge: Day}}, 2 * day, false, []RuleWarningType{RuleWarningUnsatisfiable}, 2, 4 * day},
+	}
+
+	for _, test := range tests {
+		report, err := LintRules(test.rules, test.uploadInterval)
+		if test.expectedErr {
+			if err == nil {
+				t.Errorf("%v: an error is expected", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", test.name, err)
+			continue
+		}
+
+		if len(report.Warnings) != len(test.warnings) {
+			t.Errorf("%v: expected warnings %v, got %v", test.name, test.warnings, report.Warnings)
+		} else {
+			for i, w := range report.Warnings {
+				if w.Type != test.warnings[i] {
+					t.Errorf("%v: expected warning %v, got %v", test.name, test.warnings[i], w.Type)
+				}
+			}
+		}
+		if report.ExpectedFileCount != test.count {
+			t.Errorf("%v: expected %v files, got %v", test.name, test.count, report.ExpectedFileCount)
+		}
+		if report.MaxRetention != test.retention {
+			t.Errorf("%v: expected a retention of %v, got %v", test.name, test.retention, report.MaxRetention)
+		}
+	}
+}
+
+func TestLintCaps(t *testing.T) {
+	report := RulesReport{ExpectedFileCount: 4, MaxRetention: 16 * 24 * time.Hour}
+
+	warnings := LintCaps(Caps{MaxFiles: 10, MaxAge: 30 * 24 * time.Hour}, report)
+	if len(warnings) != 0 {
+		t.Errorf("expected no warning, got %v", warnings)
+	}
+
+	warnings = LintCaps(Caps{MaxFiles: 3, MaxAge: 7 * 24 * time.Hour}, report)
+	if len(warnings) != 2 {
+		t.Errorf("expected 2 warnings, got %v", warnings)
+	}
+}
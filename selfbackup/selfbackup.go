@@ -0,0 +1,86 @@
+// Package selfbackup backs up the backup manager itself:
+// the state of the manager is exported into the reserved folder of the file repository.
+package selfbackup
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/repositories/bolt"
+	"github.com/rs/zerolog/log"
+	bbolt "go.etcd.io/bbolt"
+)
+
+// DefaultKeep is the count of exports kept when not configured
+const DefaultKeep = 7
+
+const filenamePrefix = "export-"
+
+// Execute exports the database into the reserved folder of the file repository,
+// then removes the oldest exports, keeping the most recent ones
+func Execute(db *bbolt.DB, fileRepo manager.FileRepository, keep int, date time.Time) (manager.File, error) {
+	if keep <= 0 {
+		keep = DefaultKeep
+	}
+
+	buf := bytes.Buffer{}
+	err := bolt.Export(db, &buf)
+	if err != nil {
+		return manager.File{}, fmt.Errorf("unable to export the DB: %v", err)
+	}
+
+	file := manager.File{
+		Path: manager.SelfBackupFolder + "/" + filenamePrefix + date.UTC().Format("20060102-150405") + ".json.gz",
+		Date: date,
+		Size: int64(buf.Len()),
+	}
+	err = fileRepo.PutFile(file, &buf, file.Size)
+	if err != nil {
+		return file, fmt.Errorf("unable to upload the export: %v", err)
+	}
+
+	err = prune(fileRepo, keep)
+	if err != nil {
+		return file, fmt.Errorf("unable to remove the old exports: %v", err)
+	}
+
+	return file, nil
+}
+
+// prune removes the oldest exports
+func prune(fileRepo manager.FileRepository, keep int) error {
+	filesByFolder, err := fileRepo.GetAllByFolder()
+	if err != nil {
+		return err
+	}
+
+	exports := []manager.File{}
+	for _, f := range filesByFolder[manager.SelfBackupFolder] {
+		filename, err := fileRepo.GetFilenameForFile(f)
+		if err == nil && strings.HasPrefix(filename, filenamePrefix) {
+			exports = append(exports, f)
+		}
+	}
+	if len(exports) <= keep {
+		return nil
+	}
+
+	// the filenames contain the date of the export
+	sort.Slice(exports, func(i, j int) bool {
+		return exports[i].Path < exports[j].Path
+	})
+
+	for _, f := range exports[:len(exports)-keep] {
+		err := fileRepo.RemoveFile(f)
+		if err != nil {
+			return err
+		}
+		log.Debug().Str("path", f.Path).Msg("self-backup: old export removed")
+	}
+
+	return nil
+}
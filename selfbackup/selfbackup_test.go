@@ -0,0 +1,47 @@
+package selfbackup
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/repositories/inmem"
+	bbolt "go.etcd.io/bbolt"
+)
+
+func TestExecuteShouldUploadAndPruneExports(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backr-selfbackup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := bbolt.Open(filepath.Join(dir, "test.db"), 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	fileRepo := inmem.NewFileRepository()
+	inmem.CreateFakeFile(fileRepo, manager.File{Path: "project1/file1.tar.gz"})
+
+	date := time.Date(2019, 10, 2, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 4; i++ {
+		_, err := Execute(db, fileRepo, 2, date.Add(time.Duration(i)*24*time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	filesByFolder, _ := fileRepo.GetAllByFolder()
+	exports := filesByFolder[manager.SelfBackupFolder]
+	if len(exports) != 2 || exports[0].Path != "_backr-manager/export-20191004-100000.json.gz" || exports[1].Path != "_backr-manager/export-20191005-100000.json.gz" {
+		t.Errorf("expected the 2 most recent exports to be kept, got %+v", exports)
+	}
+	if len(filesByFolder["project1"]) != 1 {
+		t.Error("the files of the projects must not be removed")
+	}
+}
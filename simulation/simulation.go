@@ -0,0 +1,294 @@
+// Package simulation replays the process day by day on in-memory repositories,
+// to see what retention rules do over a long period before applying them to a project.
+package simulation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/process"
+	"github.com/agence-webup/backr/manager/repositories/inmem"
+	"github.com/rs/zerolog"
+)
+
+// maxRunsPerDay limits the runs of the process, for the rules with a min age below a day
+const maxRunsPerDay = 24
+
+// MaxDays is the max duration of a simulation
+const MaxDays = 3 * 365
+
+// MinInterval is the min delay between two uploads of a schedule: it bounds the count of simulated files
+const MinInterval = time.Hour
+
+// MaxFileRuns bounds the cost of a simulation: the count of runs of the process multiplied by the count of files
+const MaxFileRuns = 30000000
+
+// ErrTooLarge is returned when a scenario exceeds MaxFileRuns
+var ErrTooLarge = errors.New("the simulation is too large")
+
+// Scenario describes a simulation
+type Scenario struct {
+	// ProjectName is the folder of the files
+	ProjectName string
+	Rules       []manager.Rule
+	// Caps and DateSource are the settings of the project (none by default)
+	Caps       manager.Caps
+	DateSource manager.DateSource
+	// Start is the date of the first run of the process
+	Start time.Time
+	// Days is the count of simulated days, the process runs once a day (or more often, see maxRunsPerDay)
+	Days int
+	// Files are the files existing at the start (e.g. the current listing of a project)
+	Files []manager.File
+	// Schedule generates the files uploaded during the simulation (no upload if nil)
+	Schedule *Schedule
+}
+
+// Schedule describes synthetic uploads
+type Schedule struct {
+	// Interval is the delay between two uploads (24h if zero)
+	Interval time.Duration
+	// Size is the size of the uploaded files
+	Size int64
+	// Gaps are the periods without upload, in days since the start
+	Gaps []DayRange
+	// ShrinkFrom is the day (since the start) from which the size is multiplied by ShrinkRatio (no shrink if zero)
+	ShrinkFrom  int
+	ShrinkRatio float64
+}
+
+// DayRange is a range of days since the start of the simulation, To being excluded
+type DayRange struct {
+	From int
+	To   int
+}
+
+func (r DayRange) contains(day int) bool {
+	return day >= r.From && day < r.To
+}
+
+// Day is the outcome of a simulated day
+type Day struct {
+	Date time.Time
+	// Uploaded are the files uploaded since the previous day
+	Uploaded []manager.File
+	// Removed are the files removed by the process
+	Removed []manager.File
+	// FileCount and TotalSize describe the storage used at the end of the day
+	FileCount int
+	TotalSize int64
+	// Alerts are the reasons of the alerts raised by the state of the project (none if the project is healthy)
+	Alerts []string
+	Level  manager.AlertLevel
+}
+
+// Result is the outcome of a simulation
+type Result struct {
+	Days []Day
+	// Survivors are the files kept at the end of the simulation, newest first
+	Survivors []manager.File
+}
+
+// Run replays the process once a day on in-memory repositories. The deletion guard is disabled.
+func Run(scenario Scenario) (Result, error) {
+	if len(scenario.Rules) == 0 {
+		return Result{}, fmt.Errorf("at least one rule is required")
+	}
+	if scenario.Days <= 0 || scenario.Days > MaxDays {
+		return Result{}, fmt.Errorf("the duration must be between 1 and %d days", MaxDays)
+	}
+	if scenario.ProjectName == "" {
+		return Result{}, fmt.Errorf("the project name is required")
+	}
+	if scenario.Schedule != nil && scenario.Schedule.Interval != 0 && scenario.Schedule.Interval < MinInterval {
+		return Result{}, fmt.Errorf("the upload interval must be at least %v", MinInterval)
+	}
+
+	// the process runs once a day, or more often for the rules with a min age below a day
+	runsPerDay := 1
+	for _, r := range scenario.Rules {
+		if r.MinAge > 0 && r.MinAge < manager.Day {
+			runs := int((manager.Day + r.MinAge - 1) / r.MinAge)
+			if runs > runsPerDay {
+				runsPerDay = runs
+			}
+		}
+	}
+	if runsPerDay > maxRunsPerDay {
+		runsPerDay = maxRunsPerDay
+	}
+	runInterval := manager.Day / time.Duration(runsPerDay)
+
+	// each run processes at most all the files
+	uploads := scenario.uploads()
+	runs := int64(scenario.Days) * int64(runsPerDay)
+	if files := int64(len(scenario.Files) + len(uploads)); runs*files > MaxFileRuns {
+		return Result{}, fmt.Errorf("%w: %d runs of the process over %d files (max %d), reduce the duration or the upload frequency", ErrTooLarge, runs, files, MaxFileRuns)
+	}
+
+	projectRepo := inmem.NewProjectRepository()
+	err := projectRepo.Save(manager.Project{
+		Name:       scenario.ProjectName,
+		Rules:      scenario.Rules,
+		Caps:       scenario.Caps,
+		DateSource: scenario.DateSource,
+		CreatedAt:  scenario.Start,
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	fileRepo := inmem.NewFileRepository()
+	for _, f := range scenario.Files {
+		inmem.CreateFakeFile(fileRepo, f)
+	}
+
+	// the simulation must not flood the logs of the daemon
+	logger := zerolog.Nop()
+	options := process.Options{Logger: &logger}
+
+	result := Result{Days: []Day{}}
+	// the uploads are sorted by date: next is the first upload not done yet
+	next := 0
+	for i := 0; i < scenario.Days; i++ {
+		dayDate := scenario.Start.Add(time.Duration(i) * manager.Day)
+		day := Day{Date: dayDate, Uploaded: []manager.File{}, Removed: []manager.File{}, Alerts: []string{}}
+
+		before, err := fileRepo.GetAll()
+		if err != nil {
+			return Result{}, err
+		}
+		before = append([]manager.File{}, before...)
+
+		for run := 0; run < runsPerDay; run++ {
+			date := dayDate.Add(time.Duration(run) * runInterval)
+
+			for ; next < len(uploads) && !uploads[next].Date.After(date); next++ {
+				f := uploads[next]
+				inmem.CreateFakeFile(fileRepo, f)
+				day.Uploaded = append(day.Uploaded, f)
+				before = append(before, f)
+			}
+
+			err = process.Execute(context.Background(), date, projectRepo, fileRepo, options)
+			if err != nil {
+				return Result{}, fmt.Errorf("day %d: %w", i, err)
+			}
+		}
+
+		after, err := fileRepo.GetAll()
+		if err != nil {
+			return Result{}, err
+		}
+		day.Removed = removedFiles(before, after)
+		day.FileCount = len(after)
+		for _, f := range after {
+			day.TotalSize += f.Size
+		}
+
+		notifier := &alertRecorder{}
+		err = process.Notify(projectRepo, notifier)
+		if err != nil {
+			return Result{}, fmt.Errorf("day %d: %w", i, err)
+		}
+		day.Alerts = notifier.reasons
+		day.Level = notifier.level
+
+		result.Days = append(result.Days, day)
+	}
+
+	survivors, err := fileRepo.GetAll()
+	if err != nil {
+		return Result{}, err
+	}
+	result.Survivors = manager.FilesSortedByDateDesc(survivors)
+
+	return result, nil
+}
+
+// uploads returns the files generated by the schedule during the simulation, sorted by date
+func (scenario Scenario) uploads() []manager.File {
+	files := []manager.File{}
+	schedule := scenario.Schedule
+	if schedule == nil {
+		return files
+	}
+
+	interval := schedule.Interval
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+
+	// the files are uploaded before the first run of the day
+	start := scenario.Start.Add(-time.Hour)
+	end := scenario.Start.Add(time.Duration(scenario.Days) * 24 * time.Hour)
+	for date := start; date.Before(end); date = date.Add(interval) {
+		day := int(date.Sub(start) / (24 * time.Hour))
+
+		skipped := false
+		for _, gap := range schedule.Gaps {
+			if gap.contains(day) {
+				skipped = true
+				break
+			}
+		}
+		if skipped {
+			continue
+		}
+
+		size := schedule.Size
+		if schedule.ShrinkFrom > 0 && day >= schedule.ShrinkFrom {
+			size = int64(float64(size) * schedule.ShrinkRatio)
+		}
+
+		files = append(files, manager.File{
+			Path: fmt.Sprintf("%v/backup-%v.tar.gz", scenario.ProjectName, date.UTC().Format("20060102-1504")),
+			Date: date,
+			Size: size,
+		})
+	}
+
+	return files
+}
+
+func removedFiles(before []manager.File, after []manager.File) []manager.File {
+	remaining := map[string]bool{}
+	for _, f := range after {
+		remaining[f.Path] = true
+	}
+
+	removed := []manager.File{}
+	for _, f := range before {
+		if !remaining[f.Path] {
+			removed = append(removed, f)
+		}
+	}
+	return removed
+}
+
+// alertRecorder records the alerts which would be sent for the simulated project
+type alertRecorder struct {
+	reasons []string
+	level   manager.AlertLevel
+}
+
+func (r *alertRecorder) Notify(stmt manager.ProjectErrorStatement) error {
+	for reason, details := range stmt.Reasons {
+		msg := reason.String()
+		if details != "" {
+			msg += ": " + details
+		}
+		r.reasons = append(r.reasons, msg)
+	}
+	sort.Strings(r.reasons)
+	r.level = stmt.MaxLevel
+	return nil
+}
+
+func (r *alertRecorder) NotifyGlobal(stmt manager.GlobalErrorStatement) error {
+	return nil
+}
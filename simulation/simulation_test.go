@@ -0,0 +1,114 @@
+package simulation
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+)
+
+func TestRunDailyUploads(t *testing.T) {
+	start := time.Date(2019, 01, 01, 8, 0, 0, 0, time.UTC)
+
+	result, err := Run(Scenario{
+		ProjectName: "project1",
+		Rules:       []manager.Rule{{Count: 3, MinAge: manager.Day}},
+		Start:       start,
+		Days:        60,
+		Schedule:    &Schedule{Size: 100},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Days) != 60 {
+		t.Fatalf("expected 60 days, got %d", len(result.Days))
+	}
+	for _, day := range result.Days[5:] {
+		if day.FileCount > 5 || len(day.Alerts) > 0 {
+			t.Errorf("%v: expected a stable storage without alert, got %d files and alerts %v", day.Date, day.FileCount, day.Alerts)
+		}
+	}
+	last := result.Days[len(result.Days)-1]
+	if last.TotalSize != int64(last.FileCount)*100 {
+		t.Errorf("expected the total size to match the files, got %d for %d files", last.TotalSize, last.FileCount)
+	}
+	if len(result.Survivors) != last.FileCount || !result.Survivors[0].Date.After(start.Add(58*24*time.Hour)) {
+		t.Errorf("expected the newest files to survive, got %v", result.Survivors)
+	}
+}
+
+func TestRunRaisesAlertsOnGaps(t *testing.T) {
+	start := time.Date(2019, 01, 01, 8, 0, 0, 0, time.UTC)
+
+	result, err := Run(Scenario{
+		ProjectName: "project1",
+		Rules:       []manager.Rule{{Count: 3, MinAge: manager.Day}},
+		Start:       start,
+		Days:        30,
+		Schedule:    &Schedule{Size: 100, Gaps: []DayRange{{From: 10, To: 15}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alerts := 0
+	for _, day := range result.Days {
+		if len(day.Alerts) > 0 {
+			alerts++
+		}
+	}
+	if alerts == 0 {
+		t.Errorf("expected alerts to be raised during the gap")
+	}
+	if len(result.Days[29].Alerts) > 0 {
+		t.Errorf("expected the alerts to be resolved after the gap, got %v", result.Days[29].Alerts)
+	}
+}
+
+func TestRunValidatesScenario(t *testing.T) {
+	_, err := Run(Scenario{ProjectName: "project1", Days: 10})
+	if err == nil {
+		t.Errorf("expected an error without rule")
+	}
+	_, err = Run(Scenario{ProjectName: "project1", Rules: []manager.Rule{{Count: 1, MinAge: manager.Day}}, Days: MaxDays + 1})
+	if err == nil {
+		t.Errorf("expected an error for a too long simulation")
+	}
+	_, err = Run(Scenario{ProjectName: "project1", Rules: []manager.Rule{{Count: 1, MinAge: manager.Day}}, Days: MaxDays, Schedule: &Schedule{Interval: time.Second}})
+	if err == nil {
+		t.Errorf("expected an error for a too short upload interval")
+	}
+	_, err = Run(Scenario{ProjectName: "project1", Rules: []manager.Rule{{Count: 24, MinAge: time.Hour}}, Days: MaxDays, Schedule: &Schedule{Interval: time.Hour}})
+	if !errors.Is(err, ErrTooLarge) {
+		t.Errorf("expected an error for a too large simulation, got %v", err)
+	}
+}
+
+func TestRunAppliesProjectCaps(t *testing.T) {
+	start := time.Date(2019, 01, 01, 8, 0, 0, 0, time.UTC)
+
+	result, err := Run(Scenario{
+		ProjectName: "project1",
+		Rules:       []manager.Rule{{Count: 7, MinAge: manager.Day}},
+		Caps:        manager.Caps{MaxFiles: 3},
+		Start:       start,
+		Days:        30,
+		Schedule:    &Schedule{Size: 100},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the files are removed when a selection is done: a new file may be kept until the next one
+	for _, day := range result.Days {
+		if day.FileCount > 4 {
+			t.Errorf("%v: expected the caps to limit the files, got %d", day.Date, day.FileCount)
+		}
+	}
+	last := result.Days[len(result.Days)-1]
+	if len(last.Alerts) == 0 {
+		t.Errorf("expected the caps conflict to be reported")
+	}
+}
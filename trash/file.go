@@ -0,0 +1,94 @@
+package trash
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+)
+
+// fileRepository decorates a FileRepository: the removed files are moved to the trash,
+// and the trashed files are hidden (including the files kept at their path, trashed before the prefix was introduced)
+type fileRepository struct {
+	manager.FileRepository
+	trash *Trash
+}
+
+func (repo *fileRepository) GetAll() ([]manager.File, error) {
+	files, err := repo.FileRepository.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	trashed, err := repo.trash.trashedPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	kept := []manager.File{}
+	for _, f := range files {
+		if !trashed[f.Path] && !strings.HasPrefix(f.Path, repo.trash.prefix) {
+			kept = append(kept, f)
+		}
+	}
+
+	return kept, nil
+}
+
+func (repo *fileRepository) GetAllByFolder() (manager.FilesByFolder, error) {
+	files, err := repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	filesByFolder := manager.FilesByFolder{}
+	for _, f := range files {
+		folder, err := repo.GetFolderForFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get folder for file '%v': %v", f.Path, err)
+		}
+		filesByFolder[folder] = append(filesByFolder[folder], f)
+	}
+
+	return filesByFolder, nil
+}
+
+func (repo *fileRepository) Stat(file manager.File) (*manager.File, error) {
+	trashed, err := repo.trash.trashRepo.Get(file.Path)
+	if err != nil {
+		return nil, err
+	}
+	if trashed != nil {
+		return nil, nil
+	}
+
+	return repo.FileRepository.Stat(file)
+}
+
+func (repo *fileRepository) Open(file manager.File) (io.ReadCloser, int64, error) {
+	trashed, err := repo.trash.trashRepo.Get(file.Path)
+	if err != nil {
+		return nil, 0, err
+	}
+	if trashed != nil {
+		return nil, 0, manager.NewNotFoundError("open file", file.Path)
+	}
+
+	return repo.FileRepository.Open(file)
+}
+
+func (repo *fileRepository) RemoveFile(file manager.File) error {
+	return repo.trash.Put(file, time.Now())
+}
+
+func (repo *fileRepository) PutFile(file manager.File, content io.Reader, size int64) error {
+	err := repo.FileRepository.PutFile(file, content, size)
+	if err != nil {
+		return err
+	}
+
+	// the new content replaces the trashed file
+	return repo.trash.trashRepo.Remove(file.Path)
+}
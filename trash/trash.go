@@ -0,0 +1,203 @@
+// Package trash keeps the removed files during a grace period before deleting them permanently,
+// so a mistake of the retention logic can be recovered.
+// A removed file is moved under the trash prefix of the file repository (out of the folders of the projects,
+// so the lifecycle rules of the storage can handle it), and recorded as trashed.
+// A restored file is moved back to its path.
+package trash
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/rs/zerolog/log"
+)
+
+// New returns the trash of the files of fileRepo.
+// The files are kept for the grace period of the config (manager.DefaultTrashGracePeriod if zero),
+// under its prefix (manager.DefaultTrashPrefix if empty).
+func New(fileRepo manager.FileRepository, trashRepo manager.TrashRepository, config manager.TrashConfig) *Trash {
+	gracePeriod := config.GracePeriod
+	if gracePeriod <= 0 {
+		gracePeriod = manager.DefaultTrashGracePeriod
+	}
+	prefix := strings.Trim(config.Prefix, "/")
+	if prefix == "" {
+		prefix = manager.DefaultTrashPrefix
+	}
+
+	return &Trash{
+		fileRepo:    fileRepo,
+		trashRepo:   trashRepo,
+		gracePeriod: gracePeriod,
+		prefix:      prefix + "/",
+	}
+}
+
+// Trash keeps the removed files during a grace period
+type Trash struct {
+	fileRepo    manager.FileRepository
+	trashRepo   manager.TrashRepository
+	gracePeriod time.Duration
+	// prefix ends with a slash
+	prefix string
+}
+
+// FileRepository returns a FileRepository moving the removed files to the trash,
+// and hiding the trashed files
+func (t *Trash) FileRepository() manager.FileRepository {
+	return &fileRepository{
+		FileRepository: t.fileRepo,
+		trash:          t,
+	}
+}
+
+// Put moves the file to the trash
+func (t *Trash) Put(file manager.File, date time.Time) error {
+	trashed := manager.TrashedFile{
+		File:      file,
+		TrashPath: t.prefix + file.Path,
+		TrashedAt: date,
+		PurgeAt:   date.Add(t.gracePeriod),
+	}
+
+	err := t.fileRepo.CopyFile(file, manager.File{Path: trashed.TrashPath})
+	if err != nil {
+		return fmt.Errorf("unable to move file '%v' to the trash: %w", file.Path, err)
+	}
+
+	// the file is recorded before being removed from its path,
+	// so it's hidden even if the removal fails
+	err = t.trashRepo.Add(trashed)
+	if err != nil {
+		return err
+	}
+
+	err = t.fileRepo.RemoveFile(file)
+	if err != nil {
+		return fmt.Errorf("unable to move file '%v' to the trash: %w", file.Path, err)
+	}
+
+	return nil
+}
+
+// List returns the trashed files, sorted by path
+func (t *Trash) List() ([]manager.TrashedFile, error) {
+	files, err := t.trashRepo.List()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].File.Path < files[j].File.Path })
+
+	return files, nil
+}
+
+// Restore moves the file back to its path, it returns nil if the file is not in the trash.
+// The restored file is dated from its restoration by the storage, unless its date is read from its name
+// or its metadata: it must be pinned not to be removed again by the rules.
+func (t *Trash) Restore(path string) (*manager.File, error) {
+	trashed, err := t.trashRepo.Get(path)
+	if err != nil || trashed == nil {
+		return nil, err
+	}
+
+	// the files trashed before the trash prefix was introduced are kept at their path
+	if trashed.TrashPath != "" {
+		err = t.fileRepo.CopyFile(manager.File{Path: trashed.TrashPath}, trashed.File)
+		if err != nil {
+			return nil, fmt.Errorf("unable to restore file '%v': %w", path, err)
+		}
+	}
+
+	err = t.trashRepo.Remove(path)
+	if err != nil {
+		return nil, err
+	}
+
+	restored := trashed.File
+	if trashed.TrashPath != "" {
+		// the file is restored: a copy left in the trash prefix is only logged
+		err = t.fileRepo.RemoveFile(manager.File{Path: trashed.TrashPath})
+		if err != nil {
+			log.Warn().Err(err).Str("path", trashed.TrashPath).Msg("trash: unable to remove the copy of a restored file")
+		}
+
+		stat, err := t.fileRepo.Stat(trashed.File)
+		if err == nil && stat != nil {
+			restored = *stat
+		}
+	}
+
+	return &restored, nil
+}
+
+// Purge deletes permanently the file from the trash, it returns nil if the file is not in the trash
+func (t *Trash) Purge(path string) (*manager.TrashedFile, error) {
+	trashed, err := t.trashRepo.Get(path)
+	if err != nil || trashed == nil {
+		return nil, err
+	}
+
+	err = t.fileRepo.RemoveFile(manager.File{Path: location(*trashed)})
+	if err != nil {
+		return nil, fmt.Errorf("unable to remove file '%v': %w", path, err)
+	}
+
+	err = t.trashRepo.Remove(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return trashed, nil
+}
+
+// PurgeExpired deletes permanently the files whose grace period is over at the date.
+// The files purged before an error are returned along with the error.
+func (t *Trash) PurgeExpired(date time.Time) ([]manager.TrashedFile, error) {
+	files, err := t.List()
+	if err != nil {
+		return nil, err
+	}
+
+	purged := []manager.TrashedFile{}
+	for _, f := range files {
+		if f.PurgeAt.After(date) {
+			continue
+		}
+
+		_, err := t.Purge(f.File.Path)
+		if err != nil {
+			return purged, err
+		}
+		log.Info().Str("path", f.File.Path).Time("trashed_at", f.TrashedAt).Msg("trash: file purged")
+		purged = append(purged, f)
+	}
+
+	return purged, nil
+}
+
+// location returns the path of the trashed file in the file repository
+func location(trashed manager.TrashedFile) string {
+	if trashed.TrashPath == "" {
+		return trashed.File.Path
+	}
+	return trashed.TrashPath
+}
+
+// trashedPaths returns the set of the paths of the trashed files
+func (t *Trash) trashedPaths() (map[string]bool, error) {
+	files, err := t.trashRepo.List()
+	if err != nil {
+		return nil, err
+	}
+
+	paths := map[string]bool{}
+	for _, f := range files {
+		paths[f.File.Path] = true
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,124 @@
+package trash
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agence-webup/backr/manager"
+	"github.com/agence-webup/backr/manager/repositories/bolt"
+	"github.com/agence-webup/backr/manager/repositories/inmem"
+	bbolt "go.etcd.io/bbolt"
+)
+
+func TestTrash(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backr-trash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := bbolt.Open(filepath.Join(dir, "test.db"), 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	storage := inmem.NewFileRepository()
+	file1 := manager.File{Path: "project1/file1.tar.gz", Date: time.Date(2019, 03, 20, 5, 0, 0, 0, time.UTC)}
+	file2 := manager.File{Path: "project1/file2.tar.gz", Date: time.Date(2019, 03, 21, 5, 0, 0, 0, time.UTC)}
+	inmem.CreateFakeFile(storage, file1)
+	inmem.CreateFakeFile(storage, file2)
+
+	trash := New(storage, bolt.NewTrashRepository(db), manager.TrashConfig{GracePeriod: 24 * time.Hour, Prefix: "trash"})
+	fileRepo := trash.FileRepository()
+
+	// the removed files are moved under the trash prefix, and hidden
+	for _, f := range []manager.File{file1, file2} {
+		err = fileRepo.RemoveFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	byFolder, _ := fileRepo.GetAllByFolder()
+	if len(byFolder["project1"]) != 0 {
+		t.Errorf("expected the trashed files to be hidden, got %v", byFolder["project1"])
+	}
+	if stat, _ := fileRepo.Stat(file1); stat != nil {
+		t.Errorf("expected the trashed file to be hidden, got %v", stat)
+	}
+	if files, _ := storage.GetAll(); len(files) != 2 || files[0].Path != "trash/project1/file1.tar.gz" || files[1].Path != "trash/project1/file2.tar.gz" {
+		t.Errorf("expected the trashed files to be moved under the prefix, got %v", files)
+	}
+	if files, _ := fileRepo.GetAll(); len(files) != 0 {
+		t.Errorf("expected the trash prefix to be hidden, got %v", files)
+	}
+
+	// a restored file is moved back to its path
+	restored, err := trash.Restore(file1.Path)
+	if err != nil || restored == nil || restored.Path != file1.Path {
+		t.Fatalf("expected the file to be restored, got %v, %v", restored, err)
+	}
+	if files, _ := fileRepo.GetAll(); len(files) != 1 || files[0].Path != file1.Path {
+		t.Errorf("expected the restored file to be listed, got %v", files)
+	}
+	if files, _ := storage.GetAll(); len(files) != 2 {
+		t.Errorf("expected the copy of the restored file to be removed from the trash prefix, got %v", files)
+	}
+
+	// the files are purged after the grace period
+	purged, err := trash.PurgeExpired(time.Now())
+	if err != nil || len(purged) != 0 {
+		t.Errorf("expected no file to be purged during the grace period, got %v, %v", purged, err)
+	}
+	purged, err = trash.PurgeExpired(time.Now().Add(25 * time.Hour))
+	if err != nil || len(purged) != 1 || purged[0].File.Path != file2.Path {
+		t.Errorf("expected the file to be purged, got %v, %v", purged, err)
+	}
+	if files, _ := storage.GetAll(); len(files) != 1 || files[0].Path != file1.Path {
+		t.Errorf("expected the purged file to be removed from the storage, got %v", files)
+	}
+	if files, _ := trash.List(); len(files) != 0 {
+		t.Errorf("expected the trash to be empty, got %v", files)
+	}
+}
+
+func TestTrashKeptAtPath(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backr-trash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := bbolt.Open(filepath.Join(dir, "test.db"), 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// a file trashed before the trash prefix was introduced is kept at its path
+	storage := inmem.NewFileRepository()
+	file := manager.File{Path: "project1/file1.tar.gz", Date: time.Date(2019, 03, 20, 5, 0, 0, 0, time.UTC)}
+	inmem.CreateFakeFile(storage, file)
+	trashRepo := bolt.NewTrashRepository(db)
+	err = trashRepo.Add(manager.TrashedFile{File: file, TrashedAt: time.Now(), PurgeAt: time.Now().Add(time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	trash := New(storage, trashRepo, manager.TrashConfig{})
+	if files, _ := trash.FileRepository().GetAll(); len(files) != 0 {
+		t.Errorf("expected the trashed file to be hidden, got %v", files)
+	}
+
+	// it keeps its date once restored
+	restored, err := trash.Restore(file.Path)
+	if err != nil || restored == nil || !restored.Date.Equal(file.Date) {
+		t.Fatalf("expected the file to be restored, got %v, %v", restored, err)
+	}
+	if files, _ := trash.FileRepository().GetAll(); len(files) != 1 {
+		t.Errorf("expected the restored file to be listed, got %v", files)
+	}
+}
@@ -2,7 +2,10 @@ package manager
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -15,6 +18,142 @@ type Project struct {
 	Rules     []Rule
 	State     ProjectState
 	CreatedAt time.Time
+	// DeletionPlan is the deletion blocked by the deletion guard, waiting for an approval (nil if none)
+	DeletionPlan *DeletionPlan
+	// Pins are the files kept regardless of the rules
+	Pins []Pin
+	// Pause is set when the processing of the project is paused (nil if the project is active)
+	Pause *Pause
+	// UploadInterval is the declared delay between two uploads, used to check the rules (unknown if zero)
+	UploadInterval time.Duration
+	// Caps limit the files kept by the rules
+	Caps Caps
+	// CapConflicts describe the files kept by the rules but removed because of the caps, during the last process
+	CapConflicts []string
+	// DateSource defines how the date of the files is determined (last modification date if not set)
+	DateSource DateSource
+}
+
+// Caps limit the files of a project, a zero value disables the cap.
+// The precedence is: pinned files, then the newest files (KeepNewest), then the caps, then the rules.
+type Caps struct {
+	// MaxFiles is the max count of files
+	MaxFiles int
+	// MaxBytes is the max total size of the files
+	MaxBytes int64
+	// MaxAge is the age after which the files are removed
+	MaxAge time.Duration
+	// KeepNewest is the count of the newest files always kept, regardless of their errors and of the caps
+	KeepNewest int
+}
+
+// IsSet returns true if at least one cap is set
+func (caps Caps) IsSet() bool {
+	return caps != Caps{}
+}
+
+// ProjectStatus represents the processing status of a project
+type ProjectStatus string
+
+const (
+	// ProjectStatusActive indicates that the project is fully processed
+	ProjectStatusActive ProjectStatus = "active"
+	// ProjectStatusPausedDeletions indicates that the files are selected and monitored, but never removed
+	ProjectStatusPausedDeletions ProjectStatus = "paused-deletions"
+	// ProjectStatusPausedAll indicates that the project is not processed at all, and no alert is sent
+	ProjectStatusPausedAll ProjectStatus = "paused-all"
+)
+
+// IsValid returns true if the status is known
+func (status ProjectStatus) IsValid() bool {
+	switch status {
+	case ProjectStatusActive, ProjectStatusPausedDeletions, ProjectStatusPausedAll:
+		return true
+	}
+	return false
+}
+
+// Pause describes why and until when the processing of a project is paused
+type Pause struct {
+	Status   ProjectStatus
+	Reason   string
+	Author   string
+	PausedAt time.Time
+	// ResumeAt is the date at which the project is automatically resumed (never if zero)
+	ResumeAt time.Time
+}
+
+// GetStatus returns the status of the project at the date
+func (project *Project) GetStatus(date time.Time) ProjectStatus {
+	if project.Pause == nil {
+		return ProjectStatusActive
+	}
+	if !project.Pause.ResumeAt.IsZero() && !project.Pause.ResumeAt.After(date) {
+		return ProjectStatusActive
+	}
+	return project.Pause.Status
+}
+
+// Pin keeps a file regardless of the rules (legal hold, incident investigation...)
+type Pin struct {
+	Path      string
+	Reason    string
+	Author    string
+	CreatedAt time.Time
+	// ExpiresAt is the date after which the file is not pinned anymore (never if zero)
+	ExpiresAt time.Time
+}
+
+// IsActive returns true if the pin is not expired at the date
+func (pin Pin) IsActive(date time.Time) bool {
+	return pin.ExpiresAt.IsZero() || pin.ExpiresAt.After(date)
+}
+
+// GetPin returns the pin of the file if it's active at the date, nil otherwise
+func (project *Project) GetPin(path string, date time.Time) *Pin {
+	for _, pin := range project.Pins {
+		if pin.Path == path && pin.IsActive(date) {
+			p := pin
+			return &p
+		}
+	}
+	return nil
+}
+
+// AddPin pins a file, replacing its previous pin if any
+func (project *Project) AddPin(pin Pin) {
+	project.RemovePin(pin.Path)
+	project.Pins = append(project.Pins, pin)
+}
+
+// RemovePin unpins a file, and returns false if the file was not pinned
+func (project *Project) RemovePin(path string) bool {
+	pins := []Pin{}
+	for _, pin := range project.Pins {
+		if pin.Path != path {
+			pins = append(pins, pin)
+		}
+	}
+	removed := len(pins) != len(project.Pins)
+	project.Pins = pins
+	return removed
+}
+
+// DeletionPlan is a set of files to remove, considered as abnormal by the deletion guard.
+// The files are removed only when the plan is approved.
+type DeletionPlan struct {
+	// ID identifies the files of the plan: if the files to remove change, a new approval is required
+	ID         string
+	Files      []File
+	Reasons    []string
+	CreatedAt  time.Time
+	ApprovedBy string
+	ApprovedAt time.Time
+}
+
+// IsApproved returns true if the plan has been approved
+func (plan *DeletionPlan) IsApproved() bool {
+	return plan != nil && plan.ApprovedBy != ""
 }
 
 // UpdateState update the rule state of the project, for the specified the ruleID, using the state passed as parameter
@@ -74,17 +213,82 @@ func (project *Project) DebugPrint() {
 	fmt.Println("")
 }
 
+// Day is the unit of the min age of the rules expressed as COUNT.DAYS
+const Day = 24 * time.Hour
+
+// DefaultRuleTolerance is the delay granted to the uploads before the rule is checked, when the rule doesn't define it
+const DefaultRuleTolerance = 2 * time.Hour
+
 // Rule defines the spec of a backup lifetime management
 type Rule struct {
 	Count  int
-	MinAge int
+	MinAge time.Duration
+	// Tolerance is the delay granted to the uploads, after the min age, before checking the rule (DefaultRuleTolerance if zero)
+	Tolerance time.Duration
 }
 
-// GetID returns the ID identifying the rule (in project rules scope)
+// GetID returns the ID identifying the rule (in project rules scope).
+// The tolerance is not a part of the ID: changing it keeps the state of the rule.
 func (r Rule) GetID() RuleID {
-	return RuleID(fmt.Sprintf("rule%d.%d", r.Count, r.MinAge))
+	return RuleID("rule" + r.String())
+}
+
+// GetTolerance returns the tolerance of the rule, or the default one
+func (r Rule) GetTolerance() time.Duration {
+	if r.Tolerance > 0 {
+		return r.Tolerance
+	}
+	return DefaultRuleTolerance
+}
+
+// String returns the rule as COUNT.MIN_AGE: the min age is a count of days if it is a whole count of days (i.e 3.1),
+// a duration otherwise (i.e 4.6h)
+func (r Rule) String() string {
+	return fmt.Sprintf("%d.%v", r.Count, FormatRuleDuration(r.MinAge))
 }
 
+// FormatRuleDuration returns a whole count of days as a number (i.e 15), and the other durations with
+// the Go format, without the zero units (i.e 6h, 1h30m)
+func FormatRuleDuration(d time.Duration) string {
+	if d > 0 && d%Day == 0 {
+		return fmt.Sprintf("%d", d/Day)
+	}
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = s[:len(s)-2]
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = s[:len(s)-2]
+	}
+	return s
+}
+
+// ParseRuleDuration parses a duration of a rule: a count of days (i.e 15), or a Go duration
+// accepting the units d (day) and w (week) (i.e 6h, 1d12h, 2w)
+func ParseRuleDuration(raw string) (time.Duration, error) {
+	if days, err := strconv.Atoi(raw); err == nil {
+		return time.Duration(days) * Day, nil
+	}
+
+	// convert the days & weeks to hours, ParseDuration adds up the repeated units
+	converted := ruleDurationUnits.ReplaceAllStringFunc(raw, func(value string) string {
+		unit := Day
+		if strings.HasSuffix(value, "w") {
+			unit = 7 * Day
+		}
+		count, _ := strconv.ParseFloat(value[:len(value)-1], 64)
+		return strconv.FormatFloat(count*unit.Hours(), 'f', -1, 64) + "h"
+	})
+
+	d, err := time.ParseDuration(converted)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration '%v'", raw)
+	}
+	return d, nil
+}
+
+var ruleDurationUnits = regexp.MustCompile(`[0-9]+(\.[0-9]+)?[dw]`)
+
 // RuleID represents an unique identifier for a rule
 type RuleID string
 
@@ -126,6 +330,10 @@ const (
 	RuleStateErrorSizeTooSmall
 	// RuleStateErrorNoFile indicates that backup files are missing (no specific file is linked)
 	RuleStateErrorNoFile
+	// RuleStateErrorDeletionBlocked indicates that a deletion plan is blocked, waiting for an approval
+	RuleStateErrorDeletionBlocked
+	// RuleStateErrorCapConflict indicates that files kept by the rules are removed because of the caps of the project
+	RuleStateErrorCapConflict
 )
 
 func (r RuleStateErrorType) String() string {
@@ -137,6 +345,10 @@ func (r RuleStateErrorType) String() string {
 		reason = "file is too small"
 	case RuleStateErrorNoFile:
 		reason = "no available file"
+	case RuleStateErrorDeletionBlocked:
+		reason = "deletion blocked"
+	case RuleStateErrorCapConflict:
+		reason = "caps conflict with the rules"
 	default:
 		reason = "unknown error"
 	}
@@ -245,4 +457,84 @@ func (files selectedFilesByExpirationDate) Swap(i, j int) {
 type Account struct {
 	Username       string
 	HashedPassword string
+	Role           Role
+	// Projects restricts the account to these projects (all the projects if empty).
+	// It is used to scope the credentials of the uploaders.
+	Projects []string
+}
+
+// GetRole returns the role of the account.
+// Accounts created before the introduction of roles are administrators.
+func (a Account) GetRole() Role {
+	if a.Role == "" {
+		return RoleAdmin
+	}
+	return a.Role
+}
+
+// AllowsProject returns true if the account is allowed to access the project
+func (a Account) AllowsProject(name string) bool {
+	if len(a.Projects) == 0 {
+		return true
+	}
+	for _, p := range a.Projects {
+		if p == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Role represents the permissions granted to an account
+type Role string
+
+const (
+	// RoleAdmin is allowed to do everything
+	RoleAdmin Role = "admin"
+	// RoleReader is only allowed to read projects & files
+	RoleReader Role = "reader"
+	// RoleUploader is only allowed to upload files
+	RoleUploader Role = "uploader"
+)
+
+// IsValid returns true if the role is known
+func (r Role) IsValid() bool {
+	switch r {
+	case RoleAdmin, RoleReader, RoleUploader:
+		return true
+	}
+	return false
+}
+
+// TrashedFile is a removed file, kept in the trash during a grace period before being permanently deleted
+type TrashedFile struct {
+	File File
+	// TrashPath is the path of the file moved into the trash prefix (empty if it was kept at its path)
+	TrashPath string
+	TrashedAt time.Time
+	PurgeAt   time.Time
+}
+
+// Maintenance describes the maintenance mode of the daemon: all the deletions and alerts are frozen
+type Maintenance struct {
+	Reason    string
+	Author    string
+	StartedAt time.Time
+	// Until is the date at which the maintenance mode ends (never if zero)
+	Until time.Time
+}
+
+// IsActive returns true if the maintenance mode is not over at the date
+func (m *Maintenance) IsActive(date time.Time) bool {
+	return m != nil && (m.Until.IsZero() || m.Until.After(date))
+}
+
+// DownloadToken describes a one-time download URL, served by the daemon
+type DownloadToken struct {
+	FilePath string
+	// Filename forces the browser to download the file, saved with this name (optional)
+	Filename  string
+	CreatedBy string
+	CreatedAt time.Time
+	ExpiresAt time.Time
 }
@@ -47,7 +47,7 @@ func getSampleRuleState(next *time.Time) RuleState {
 	return RuleState{
 		Rule: Rule{
 			Count:  3,
-			MinAge: 1,
+			MinAge: Day,
 		},
 		Next: next,
 	}
@@ -60,3 +60,42 @@ func getSampleFiles() []File {
 		File{Path: "file3", Date: time.Date(2019, 03, 26, 6, 0, 0, 0, time.UTC)},
 	}
 }
+
+func TestParseRuleDuration(t *testing.T) {
+	tests := []struct {
+		raw       string
+		expected  time.Duration
+		formatted string
+	}{
+		{"1", Day, "1"},
+		{"15", 15 * Day, "15"},
+		{"6h", 6 * time.Hour, "6h"},
+		{"1d12h", 36 * time.Hour, "36h"},
+		{"2w", 14 * Day, "14"},
+		{"1.5d", 36 * time.Hour, "36h"},
+		{"90m", 90 * time.Minute, "1h30m"},
+	}
+
+	for _, test := range tests {
+		d, err := ParseRuleDuration(test.raw)
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", test.raw, err)
+			continue
+		}
+		if d != test.expected {
+			t.Errorf("%v: expected %v, got %v", test.raw, test.expected, d)
+		}
+		if formatted := FormatRuleDuration(d); formatted != test.formatted {
+			t.Errorf("%v: expected to be formatted as %v, got %v", test.raw, test.formatted, formatted)
+		}
+	}
+
+	if _, err := ParseRuleDuration("6x"); err == nil {
+		t.Error("an invalid duration must be rejected")
+	}
+
+	// the rules in days keep their ID
+	if id := (Rule{Count: 3, MinAge: Day}).GetID(); id != "rule3.1" {
+		t.Errorf("unexpected rule ID: %v", id)
+	}
+}